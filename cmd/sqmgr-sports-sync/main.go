@@ -37,7 +37,7 @@ var (
 	syncSchedule = flag.Bool("sync-schedule", false, "Sync upcoming game schedule")
 	syncScores   = flag.Bool("sync-scores", false, "Sync scores for in-progress/recent games")
 	dryRun       = flag.Bool("dry-run", false, "Don't persist changes to database")
-	league       = flag.String("league", "", "Specific league to sync (nfl, nba, wnba, ncaab, ncaaf)")
+	league       = flag.String("league", "", "Specific league to sync (nfl, nba, wnba, ncaab, ncaaf, nhl, mlb, mls)")
 	log          = logrus.NewEntry(logrus.StandardLogger())
 )
 
@@ -119,6 +119,9 @@ func getLeaguesToSync() []model.SportsLeague {
 		model.SportsLeagueWNBA,
 		model.SportsLeagueNCAAB,
 		model.SportsLeagueNCAAF,
+		model.SportsLeagueNHL,
+		model.SportsLeagueMLB,
+		model.SportsLeagueMLS,
 	}
 }
 
@@ -253,7 +256,7 @@ func doSyncSchedule(ctx context.Context, m *model.Model, client *sports.Client,
 			}
 
 		default:
-			// For NBA/WNBA, use date range (all games appear in scoreboard)
+			// For NBA/WNBA/NHL/MLB/MLS, use date range (all games appear in scoreboard)
 			var startDate, endDate time.Time
 			if seasonInfo.InSeason {
 				startDate = now
@@ -504,16 +507,19 @@ func doSyncScores(ctx context.Context, m *model.Model, client *sports.Client, le
 			})
 
 			espnEvent, found := espnEventMap[dbEvent.ESPNID]
-			if !found {
-				// Event not in scoreboard - fetch it individually via summary endpoint
-				// This handles smaller school games that ESPN doesn't feature in daily scoreboards
-				eventLog.Debug("event not found in scoreboard, fetching via summary endpoint")
+			if !found || league.HasScoringPlays() {
+				// Fetch the event individually via summary endpoint. This handles smaller school games that ESPN
+				// doesn't feature in daily scoreboards, and only the summary has the scoring plays.
+				eventLog.WithField("inScoreboard", found).Debug("fetching event via summary endpoint")
 				fetchedEvent, err := client.GetEventSummary(ctx, sports.League(league), dbEvent.ESPNID)
 				if err != nil {
 					eventLog.WithError(err).Debug("failed to fetch event summary")
-					continue
+					if !found {
+						continue
+					}
+				} else {
+					espnEvent = *fetchedEvent
 				}
-				espnEvent = *fetchedEvent
 			}
 
 			if err := processEvent(ctx, m, league, espnEvent); err != nil {
@@ -636,11 +642,23 @@ func processEvent(ctx context.Context, m *model.Model, league model.SportsLeague
 	sportsEvent.AwayQ3 = event.AwayQ3
 	sportsEvent.AwayQ4 = event.AwayQ4
 	sportsEvent.AwayOT = event.AwayOT
+	sportsEvent.HomePeriods = event.HomePeriods
+	sportsEvent.AwayPeriods = event.AwayPeriods
 
 	if err := m.UpsertSportsEvent(ctx, nil, sportsEvent); err != nil {
 		return fmt.Errorf("upserting event: %w", err)
 	}
 
+	// Keep every distinct score so grids can pay out on each score change. Only the scoring plays have every score;
+	// without them just the current score is recorded, which misses any score made and beaten between two syncs.
+	if event.ScoringPlays != nil {
+		if _, err := m.RecordSportsEventScoringPlays(ctx, sportsEvent, scoringPlayScores(event.ScoringPlays)); err != nil {
+			return fmt.Errorf("recording event scoring plays: %w", err)
+		}
+	} else if _, err := m.RecordSportsEventScore(ctx, nil, sportsEvent); err != nil {
+		return fmt.Errorf("recording event score: %w", err)
+	}
+
 	// Notify connected clients if score-relevant data changed
 	if existingEvent != nil && sportsEventDataChanged(existingEvent, sportsEvent) {
 		if err := m.NotifySportsEventUpdated(ctx, sportsEvent.ID); err != nil {
@@ -668,6 +686,24 @@ func processEvent(ctx context.Context, m *model.Model, league model.SportsLeague
 	return nil
 }
 
+// scoringPlayScores returns the score after each scoring play
+func scoringPlayScores(plays []sports.ScoringPlay) []*model.SportsEventScore {
+	scores := make([]*model.SportsEventScore, len(plays))
+	for i, play := range plays {
+		period := play.Period
+		scores[i] = &model.SportsEventScore{
+			HomeScore: play.HomeScore,
+			AwayScore: play.AwayScore,
+			Period:    &period,
+		}
+		if play.Clock != "" {
+			clock := play.Clock
+			scores[i].Clock = &clock
+		}
+	}
+	return scores
+}
+
 // sportsEventDataChanged returns true if any score-relevant field differs between two events.
 func sportsEventDataChanged(existing, updated *model.SportsEvent) bool {
 	if existing.Status != updated.Status {
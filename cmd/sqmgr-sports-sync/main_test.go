@@ -22,6 +22,7 @@ import (
 
 	"github.com/onsi/gomega"
 	"github.com/sqmgr/sqmgr-api/pkg/model"
+	"github.com/sqmgr/sqmgr-api/pkg/sports"
 )
 
 func intP(i int) *int       { return &i }
@@ -147,3 +148,18 @@ func TestSportsEventDataChanged(t *testing.T) {
 		g.Expect(sportsEventDataChanged(existing, updated)).Should(gomega.BeFalse())
 	})
 }
+
+func TestScoringPlayScores(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	scores := scoringPlayScores([]sports.ScoringPlay{
+		{HomeScore: 7, AwayScore: 0, Period: 1, Clock: "8:32"},
+		{HomeScore: 7, AwayScore: 3, Period: 2},
+	})
+	g.Expect(scores).Should(gomega.Equal([]*model.SportsEventScore{
+		{HomeScore: 7, AwayScore: 0, Period: intP(1), Clock: strP("8:32")},
+		{HomeScore: 7, AwayScore: 3, Period: intP(2)},
+	}))
+
+	g.Expect(scoringPlayScores([]sports.ScoringPlay{})).Should(gomega.BeEmpty())
+}
@@ -0,0 +1,126 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sqmgr/sqmgr-api/pkg/model"
+)
+
+const autoDrawInterval = time.Minute
+
+// AutoDrawScheduler periodically draws the numbers of grids that are scheduled to be drawn automatically, and
+// notifies the pools they belong to.
+type AutoDrawScheduler struct {
+	*periodicJob
+	model  *model.Model
+	broker *PoolBroker
+}
+
+// NewAutoDrawScheduler creates a new AutoDrawScheduler that runs every interval
+func NewAutoDrawScheduler(m *model.Model, broker *PoolBroker, interval time.Duration) *AutoDrawScheduler {
+	a := &AutoDrawScheduler{
+		model:  m,
+		broker: broker,
+	}
+	a.periodicJob = newPeriodicJob(interval, a.tick)
+	return a
+}
+
+// tick draws the due grids unless another server is already drawing them
+func (a *AutoDrawScheduler) tick(ctx context.Context) {
+	if _, err := a.model.WithAdvisoryLock(ctx, model.AdvisoryLockAutoDraw, a.drawDue); err != nil {
+		logrus.WithError(err).Error("auto-draw: could not draw due grids")
+	}
+}
+
+func (a *AutoDrawScheduler) drawDue(ctx context.Context) error {
+	due, err := a.model.DueAutoDraws(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range due {
+		lr := logrus.WithFields(logrus.Fields{
+			"pool": d.PoolToken,
+			"grid": d.GridID,
+		})
+
+		drawn, err := a.drawGrid(ctx, d)
+		if err != nil {
+			lr.WithError(err).Error("auto-draw: could not draw numbers")
+			continue
+		}
+
+		if !drawn {
+			continue
+		}
+
+		lr.Info("auto-draw: drew numbers")
+		a.broker.Publish(d.PoolToken, PoolEvent{Type: EventGridUpdated})
+	}
+
+	return nil
+}
+
+// drawGrid draws the grid's numbers the same way a manager pressing "draw numbers" does, including locking the pool
+// if it is not already locked. It returns false if the manager drew the numbers first.
+func (a *AutoDrawScheduler) drawGrid(ctx context.Context, d model.DueAutoDraw) (bool, error) {
+	pool, err := a.model.PoolByToken(ctx, d.PoolToken)
+	if err != nil {
+		return false, fmt.Errorf("loading pool: %w", err)
+	}
+
+	grid, err := pool.GridByID(ctx, d.GridID)
+	if err != nil {
+		return false, fmt.Errorf("loading grid: %w", err)
+	}
+
+	config := pool.NumberSetConfig()
+	if config != model.NumberSetConfigStandard {
+		if err := grid.LoadNumberSets(ctx); err != nil {
+			return false, err
+		}
+	}
+
+	if grid.NumbersAreDrawn(config) {
+		return false, nil
+	}
+
+	// the check above may be stale, the draw itself refuses to overwrite numbers drawn since then
+	if err := grid.DrawAllNumbersRandom(ctx, config, 0); err != nil {
+		if errors.Is(err, model.ErrNumbersAlreadyDrawn) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if !pool.IsLocked() {
+		pool.SetLocks(time.Now())
+		if err := pool.Save(ctx); err != nil {
+			return false, fmt.Errorf("locking pool: %w", err)
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/onsi/gomega"
+	"github.com/sqmgr/sqmgr-api/pkg/model"
+)
+
+func TestAutoDrawSchedulerTick_SkipsWhenAnotherServerHoldsLock(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT pg_try_advisory_xact_lock\\(\\$1\\)").
+		WithArgs(model.AdvisoryLockAutoDraw).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(false))
+	mock.ExpectRollback()
+
+	scheduler := NewAutoDrawScheduler(model.New(db), NewPoolBroker(), time.Minute)
+	scheduler.tick(context.Background())
+
+	g.Expect(mock.ExpectationsWereMet()).Should(gomega.Succeed())
+}
+
+func TestAutoDrawSchedulerTick_DrawsDueGridAndLocksPool(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT pg_try_advisory_xact_lock\\(\\$1\\)").
+		WithArgs(model.AdvisoryLockAutoDraw).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(true))
+	mock.ExpectQuery("SELECT pools.token, grids.id FROM grid_auto_draws").
+		WillReturnRows(sqlmock.NewRows([]string{"token", "id"}).AddRow("pool-abc", int64(7)))
+	mock.ExpectQuery("SELECT .+ FROM pools WHERE token = \\$1").
+		WithArgs("pool-abc").
+		WillReturnRows(sqlmock.NewRows(poolColumns()).
+			AddRow(1, "pool-abc", int64(100), "Test Pool", "std100", "standard", "hash", true, false, nil, now, now, 0, false))
+	mock.ExpectQuery("SELECT .+ FROM grids WHERE id = \\$1 AND pool_id = \\$2").
+		WithArgs(int64(7), int64(1)).
+		WillReturnRows(sqlmock.NewRows(gridColumns()).
+			AddRow(int64(7), int64(1), 0, nil, nil, nil, nil, nil, nil, false, "active", now, now, false, int64(55), nil, "include"))
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FROM grids WHERE id = \\$1 FOR UPDATE").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"has_numbers", "has_number_sets"}).AddRow(false, false))
+	mock.ExpectQuery("SELECT seed, drawn, created FROM grid_draw_commitments WHERE grid_id = \\$1 FOR UPDATE").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"seed", "drawn", "created"}).AddRow(make([]byte, 32), nil, now))
+	mock.ExpectQuery("SELECT entropy FROM grid_draw_entropy WHERE grid_id = \\$1").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"entropy"}))
+	mock.ExpectQuery("UPDATE grid_draw_commitments").
+		WithArgs(int64(7), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"drawn"}).AddRow(now))
+	mock.ExpectExec("UPDATE grids SET home_numbers = \\$1").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO grid_draws").
+		WithArgs(int64(7), nil, model.GridDrawMethodRandom, nil, sqlmock.AnyArg(), make([]byte, 32)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec("UPDATE pools").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	broker := NewPoolBroker()
+	ch := broker.Subscribe("pool-abc")
+	defer broker.Unsubscribe("pool-abc", ch)
+
+	scheduler := NewAutoDrawScheduler(model.New(db), broker, time.Minute)
+	scheduler.tick(context.Background())
+
+	g.Expect(ch).Should(gomega.Receive(gomega.Equal(PoolEvent{Type: EventGridUpdated})))
+	g.Expect(mock.ExpectationsWereMet()).Should(gomega.Succeed())
+}
+
+func TestAutoDrawSchedulerTick_SkipsGridDrawnSinceItWasLoaded(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT pg_try_advisory_xact_lock\\(\\$1\\)").
+		WithArgs(model.AdvisoryLockAutoDraw).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(true))
+	mock.ExpectQuery("SELECT pools.token, grids.id FROM grid_auto_draws").
+		WillReturnRows(sqlmock.NewRows([]string{"token", "id"}).AddRow("pool-abc", int64(7)))
+	mock.ExpectQuery("SELECT .+ FROM pools WHERE token = \\$1").
+		WithArgs("pool-abc").
+		WillReturnRows(sqlmock.NewRows(poolColumns()).
+			AddRow(1, "pool-abc", int64(100), "Test Pool", "std100", "standard", "hash", true, false, nil, now, now, 0, false))
+	mock.ExpectQuery("SELECT .+ FROM grids WHERE id = \\$1 AND pool_id = \\$2").
+		WithArgs(int64(7), int64(1)).
+		WillReturnRows(sqlmock.NewRows(gridColumns()).
+			AddRow(int64(7), int64(1), 0, nil, nil, nil, nil, nil, nil, false, "active", now, now, false, int64(55), nil, "include"))
+	// a manager drew the numbers after the grid was loaded
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FROM grids WHERE id = \\$1 FOR UPDATE").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"has_numbers", "has_number_sets"}).AddRow(true, false))
+	mock.ExpectRollback()
+	mock.ExpectRollback()
+
+	broker := NewPoolBroker()
+	ch := broker.Subscribe("pool-abc")
+	defer broker.Unsubscribe("pool-abc", ch)
+
+	scheduler := NewAutoDrawScheduler(model.New(db), broker, time.Minute)
+	scheduler.tick(context.Background())
+
+	g.Consistently(ch).ShouldNot(gomega.Receive())
+	g.Expect(mock.ExpectationsWereMet()).Should(gomega.Succeed())
+}
@@ -0,0 +1,72 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sqmgr/sqmgr-api/pkg/model"
+)
+
+const autoLockInterval = time.Minute
+
+// AutoLockScheduler periodically moves the locks of pools that lock at kickoff to match their linked events, and
+// notifies the pools once they lock.
+type AutoLockScheduler struct {
+	*periodicJob
+	model  *model.Model
+	broker *PoolBroker
+}
+
+// NewAutoLockScheduler creates a new AutoLockScheduler that runs every interval
+func NewAutoLockScheduler(m *model.Model, broker *PoolBroker, interval time.Duration) *AutoLockScheduler {
+	a := &AutoLockScheduler{
+		model:  m,
+		broker: broker,
+	}
+	a.periodicJob = newPeriodicJob(interval, a.tick)
+	return a
+}
+
+// tick locks the due pools unless another server is already locking them
+func (a *AutoLockScheduler) tick(ctx context.Context) {
+	if _, err := a.model.WithAdvisoryLock(ctx, model.AdvisoryLockAutoLock, a.lockDue); err != nil {
+		logrus.WithError(err).Error("auto-lock: could not lock due pools")
+	}
+}
+
+func (a *AutoLockScheduler) lockDue(ctx context.Context) error {
+	// catches grids that were linked to an event since the auto-lock was set
+	if err := a.model.SyncAutoLocks(ctx); err != nil {
+		return err
+	}
+
+	tokens, err := a.model.LockDueAutoLocks(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		logrus.WithField("pool", token).Info("auto-lock: pool locked at kickoff")
+		a.broker.Publish(token, PoolEvent{Type: EventPoolUpdated})
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/onsi/gomega"
+	"github.com/sqmgr/sqmgr-api/pkg/model"
+)
+
+func TestAutoLockSchedulerTick_PublishesWhenPoolsLock(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT pg_try_advisory_xact_lock\\(\\$1\\)").
+		WithArgs(model.AdvisoryLockAutoLock).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(true))
+	mock.ExpectExec("UPDATE pools SET locks = kickoffs.kickoff").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery("UPDATE pool_auto_locks SET locked_at .+ NOT pools.archived AND pools.locks <=").
+		WillReturnRows(sqlmock.NewRows([]string{"token"}).AddRow("pool-abc"))
+	mock.ExpectRollback()
+
+	broker := NewPoolBroker()
+	abc := broker.Subscribe("pool-abc")
+	defer broker.Unsubscribe("pool-abc", abc)
+	def := broker.Subscribe("pool-def")
+	defer broker.Unsubscribe("pool-def", def)
+
+	scheduler := NewAutoLockScheduler(model.New(db), broker, time.Minute)
+	scheduler.tick(context.Background())
+
+	g.Expect(abc).Should(gomega.Receive(gomega.Equal(PoolEvent{Type: EventPoolUpdated})))
+	g.Expect(def).ShouldNot(gomega.Receive())
+	g.Expect(mock.ExpectationsWereMet()).Should(gomega.Succeed())
+}
+
+func TestAutoLockSchedulerTick_SkipsWhenAnotherServerHoldsLock(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT pg_try_advisory_xact_lock\\(\\$1\\)").
+		WithArgs(model.AdvisoryLockAutoLock).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(false))
+	mock.ExpectRollback()
+
+	scheduler := NewAutoLockScheduler(model.New(db), NewPoolBroker(), time.Minute)
+	scheduler.tick(context.Background())
+
+	g.Expect(mock.ExpectationsWereMet()).Should(gomega.Succeed())
+}
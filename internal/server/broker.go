@@ -19,6 +19,8 @@ package server
 
 import (
 	"sync"
+
+	"github.com/sqmgr/sqmgr-api/pkg/model"
 )
 
 // PoolEventType represents the type of pool event
@@ -29,11 +31,18 @@ const (
 	EventSquareUpdated PoolEventType = "square_updated"
 	EventGridUpdated   PoolEventType = "grid_updated"
 	EventPoolUpdated   PoolEventType = "pool_updated"
+	EventDraftTurn     PoolEventType = "draft_turn"
 )
 
 // PoolEvent represents an event that occurred in a pool
 type PoolEvent struct {
 	Type PoolEventType `json:"type"`
+	// SquareID and AvailableShare are set when a share of a square changes, so clients can show how much
+	// of the square is left to claim
+	SquareID       int  `json:"squareId,omitempty"`
+	AvailableShare *int `json:"availableShare,omitempty"`
+	// Draft is set when the turn changes in a draft. It is nil when the draft has ended.
+	Draft *model.PoolDraftJSON `json:"draft,omitempty"`
 }
 
 // PoolBroker manages per-pool SSE subscriptions
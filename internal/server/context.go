@@ -32,6 +32,7 @@ const (
 	ctxGridKey
 	ctxSquareIDKey
 	ctxRequestIDKey
+	ctxSeriesKey
 )
 
 func userFromContext(ctx context.Context) (*model.User, bool) {
@@ -59,6 +60,11 @@ func squareIDFromContext(ctx context.Context) (int, bool) {
 	return id, ok
 }
 
+func seriesFromContext(ctx context.Context) (*model.PoolSeries, bool) {
+	s, ok := ctx.Value(ctxSeriesKey).(*model.PoolSeries)
+	return s, ok
+}
+
 func requestIDFromContext(ctx context.Context) string {
 	id, _ := ctx.Value(ctxRequestIDKey).(string)
 	return id
@@ -0,0 +1,84 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sqmgr/sqmgr-api/pkg/model"
+)
+
+const draftTimerInterval = 5 * time.Second
+
+// DraftTimer periodically makes a random pick for draft members whose turn has timed out, and notifies the pools
+// of the turn change.
+type DraftTimer struct {
+	*periodicJob
+	model  *model.Model
+	broker *PoolBroker
+}
+
+// NewDraftTimer creates a new DraftTimer that runs every interval
+func NewDraftTimer(m *model.Model, broker *PoolBroker, interval time.Duration) *DraftTimer {
+	d := &DraftTimer{
+		model:  m,
+		broker: broker,
+	}
+	d.periodicJob = newPeriodicJob(interval, d.autoPick)
+	return d
+}
+
+func (d *DraftTimer) autoPick(ctx context.Context) {
+	tokens, err := d.model.TimedOutDraftPoolTokens(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("draft timer: could not load timed out drafts")
+		return
+	}
+
+	for _, token := range tokens {
+		lr := logrus.WithField("pool", token)
+
+		pool, err := d.model.PoolByToken(ctx, token)
+		if err != nil {
+			lr.WithError(err).Error("draft timer: could not load pool")
+			continue
+		}
+
+		draft, passed, err := pool.AutoPickDraftTurn(ctx)
+		if err != nil {
+			lr.WithError(err).Error("draft timer: could not auto-pick")
+			continue
+		}
+
+		if draft == nil {
+			lr.Info("draft timer: draft is over")
+			d.broker.Publish(token, PoolEvent{Type: EventDraftTurn})
+			continue
+		}
+
+		if !passed {
+			continue
+		}
+
+		lr.WithField("pick", draft.PickNumber()).Info("draft timer: passed on timed out turn")
+		d.broker.Publish(token, PoolEvent{Type: EventSquareUpdated})
+		d.broker.Publish(token, PoolEvent{Type: EventDraftTurn, Draft: draft.JSON()})
+	}
+}
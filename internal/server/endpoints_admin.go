@@ -142,6 +142,11 @@ func (s *Server) postAdminPoolJoinEndpoint() http.HandlerFunc {
 		}
 
 		if err := user.JoinPool(r.Context(), pool); err != nil {
+			if errors.Is(err, model.ErrBannedFromPool) {
+				s.writeErrorResponse(w, http.StatusForbidden, err)
+				return
+			}
+
 			s.writeErrorResponse(w, http.StatusInternalServerError, err)
 			return
 		}
@@ -150,6 +155,62 @@ func (s *Server) postAdminPoolJoinEndpoint() http.HandlerFunc {
 	}
 }
 
+// postAdminPoolTransferEndpoint will force a transfer of the ownership of a pool. The previous owner becomes a
+// manager.
+func (s *Server) postAdminPoolTransferEndpoint() http.HandlerFunc {
+	type payload struct {
+		UserID int64 `json:"userId"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := userFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+		token := mux.Vars(r)["token"]
+
+		pool, err := s.model.PoolByToken(r.Context(), token)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				s.writeErrorResponse(w, http.StatusNotFound, nil)
+				return
+			}
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		var data payload
+		if ok := s.parseJSONPayload(w, r, &data); !ok {
+			return
+		}
+
+		owner, ok := s.loadTransferNominee(w, r, data.UserID)
+		if !ok {
+			return
+		}
+
+		transfer, err := pool.ForceTransfer(r.Context(), owner.ID, user.ID)
+		if err != nil {
+			if errors.Is(err, model.ErrAlreadyOwner) {
+				s.writeErrorResponse(w, http.StatusBadRequest, err)
+				return
+			}
+
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"pool":  pool.ID(),
+			"owner": owner.ID,
+			"admin": user.ID,
+		}).Warn("forced pool ownership transfer")
+		s.broker.Publish(pool.Token(), PoolEvent{Type: EventPoolUpdated})
+		s.writeJSONResponse(w, http.StatusOK, transfer)
+	}
+}
+
 // getAdminUserEndpoint returns user profile and stats for admin view
 func (s *Server) getAdminUserEndpoint() http.HandlerFunc {
 	type userResponse struct {
@@ -70,6 +70,11 @@ func (s *Server) poolHandler(next http.Handler) http.Handler {
 			if (pool.IsLocked() && pool.OpenAccessOnLock()) || !pool.PasswordRequired() {
 				// Auto-join user to pool since no password is required
 				if err := user.JoinPool(r.Context(), pool); err != nil {
+					if errors.Is(err, model.ErrBannedFromPool) {
+						s.writeErrorResponse(w, http.StatusForbidden, err)
+						return
+					}
+
 					s.writeErrorResponse(w, http.StatusInternalServerError, err)
 					return
 				}
@@ -120,45 +125,27 @@ func (s *Server) poolGridHandler(next http.Handler) http.Handler {
 	})
 }
 
-func (s *Server) poolManagerHandler(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		pool, ok := poolFromContext(r.Context())
-		if !ok {
-			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
-			return
-		}
-		user, ok := userFromContext(r.Context())
-		if !ok {
-			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
-			return
-		}
-
-		// Note: uses IsManagerOf (not HasManagerVisibility) intentionally;
-		// site admins get read-only visibility on pools but not write authority.
-		if isManager, err := user.IsManagerOf(r.Context(), pool); err != nil {
-			s.writeErrorResponse(w, http.StatusInternalServerError, err)
-			return
-		} else if !isManager {
-			s.writeErrorResponse(w, http.StatusForbidden, nil)
-			return
-		}
+// poolPermissionHandler will ensure the user's role in the pool allows the action
+func (s *Server) poolPermissionHandler(action model.Action) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !s.checkPoolPermission(w, r, action) {
+				return
+			}
 
-		next.ServeHTTP(w, r)
-	})
+			next.ServeHTTP(w, r)
+		})
+	}
 }
 
-func (s *Server) poolGridSquareManagerHandler(next http.Handler) http.Handler {
+// poolGridSquareEditorHandler will ensure the square in the route is on the grid and that the user may edit grids
+func (s *Server) poolGridSquareEditorHandler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		pool, ok := poolFromContext(r.Context())
 		if !ok {
 			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
 			return
 		}
-		user, ok := userFromContext(r.Context())
-		if !ok {
-			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
-			return
-		}
 
 		squareID, err := strconv.Atoi(mux.Vars(r)["square_id"])
 		if err != nil {
@@ -171,13 +158,7 @@ func (s *Server) poolGridSquareManagerHandler(next http.Handler) http.Handler {
 			return
 		}
 
-		// Note: uses IsManagerOf (not HasManagerVisibility) intentionally;
-		// site admins get read-only visibility on pools but not write authority.
-		if isManager, err := user.IsManagerOf(r.Context(), pool); err != nil {
-			s.writeErrorResponse(w, http.StatusInternalServerError, err)
-			return
-		} else if !isManager {
-			s.writeErrorResponse(w, http.StatusForbidden, nil)
+		if !s.checkPoolPermission(w, r, model.ActionEditGrids) {
 			return
 		}
 
@@ -185,16 +166,57 @@ func (s *Server) poolGridSquareManagerHandler(next http.Handler) http.Handler {
 	})
 }
 
+// checkPoolPermission returns true if the user's role in the pool allows the action. Otherwise the error is written
+// to the response and false is returned.
+//
+// Note: site admins get read-only visibility on pools but no role in them, so they are not given write authority.
+func (s *Server) checkPoolPermission(w http.ResponseWriter, r *http.Request, action model.Action) bool {
+	pool, ok := poolFromContext(r.Context())
+	if !ok {
+		s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+		return false
+	}
+	user, ok := userFromContext(r.Context())
+	if !ok {
+		s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+		return false
+	}
+
+	if err := s.model.CanInPool(r.Context(), action, user, pool); err != nil {
+		var actionErr model.ActionError
+		if errors.As(err, &actionErr) {
+			s.writeErrorResponse(w, http.StatusForbidden, actionErr)
+			return false
+		}
+
+		s.writeErrorResponse(w, http.StatusInternalServerError, err)
+		return false
+	}
+
+	return true
+}
+
 func (s *Server) postPoolTokenEndpoint() http.HandlerFunc {
 	type payload struct {
-		Action           string  `json:"action"`
-		IDs              []int64 `json:"ids"`
-		Name             string  `json:"name"`
-		Password         string  `json:"password"`
-		ResetMembership  bool    `json:"resetMembership"`
-		PasswordRequired bool    `json:"passwordRequired"`
-		OpenAccessOnLock bool    `json:"openAccessOnLock"`
-		NumberSetConfig  string  `json:"numberSetConfig"`
+		Action                string                        `json:"action"`
+		IDs                   []int64                       `json:"ids"`
+		Name                  string                        `json:"name"`
+		Password              string                        `json:"password"`
+		ResetMembership       bool                          `json:"resetMembership"`
+		PasswordRequired      bool                          `json:"passwordRequired"`
+		OpenAccessOnLock      bool                          `json:"openAccessOnLock"`
+		NumberSetConfig       string                        `json:"numberSetConfig"`
+		SquarePrice           int64                         `json:"squarePrice"`
+		PayoutType            string                        `json:"payoutType"`
+		RolloverFinalRule     string                        `json:"rolloverFinalRule"`
+		Payouts               map[model.NumberSetType]int64 `json:"payouts"`
+		MaxSquaresPerUser     int                           `json:"maxSquaresPerUser"`
+		MaxSquaresPerClaimant int                           `json:"maxSquaresPerClaimant"`
+		HoldHours             int                           `json:"holdHours"`
+		AutoLock              *model.PoolAutoLock           `json:"autoLock"`
+		IncludeMembers        bool                          `json:"includeMembers"`
+		IncludeClaimants      bool                          `json:"includeClaimants"`
+		SportsEventID         *int64                        `json:"sportsEventId"`
 	}
 
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -325,6 +347,185 @@ func (s *Server) postPoolTokenEndpoint() http.HandlerFunc {
 
 			pool.SetNumberSetConfig(newConfig)
 			err = pool.Save(r.Context())
+		case "setPayouts":
+			v := validator.New()
+			if resp.SquarePrice < 0 {
+				v.AddError("squarePrice", "Square price cannot be negative")
+			}
+
+			payoutType := model.PayoutType(resp.PayoutType)
+			if payoutType == "" {
+				payoutType = model.PayoutTypeAmount
+			}
+			if !payoutType.IsValid() {
+				v.AddError("payoutType", "Invalid payout type")
+			}
+
+			rolloverFinalRule := model.RolloverFinalRule(resp.RolloverFinalRule)
+			if rolloverFinalRule == "" {
+				rolloverFinalRule = model.RolloverFinalRuleSplit
+			}
+			if !rolloverFinalRule.IsValid() {
+				v.AddError("rolloverFinalRule", "Invalid rollover rule")
+			}
+
+			var totalPercent int64
+			for period, value := range resp.Payouts {
+				if !model.IsValidNumberSetType(string(period)) {
+					v.AddError("payouts", "Invalid period %s", period)
+				}
+				if value < 0 {
+					v.AddError("payouts", "Payout for %s cannot be negative", period)
+				}
+				totalPercent += value
+			}
+			if payoutType == model.PayoutTypePercent && totalPercent > model.PercentBasisPoints {
+				v.AddError("payouts", "Payout percentages cannot exceed 100%%")
+			}
+
+			if !v.OK() {
+				s.writeJSONResponse(w, http.StatusBadRequest, ErrorResponse{
+					Status:           statusError,
+					Error:            validationErrorMessage,
+					ValidationErrors: v.Errors,
+				})
+				return
+			}
+
+			var settings *model.PoolPayoutSettings
+			settings, err = pool.PayoutSettings(r.Context())
+			if err != nil {
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				return
+			}
+
+			settings.SetSquarePrice(resp.SquarePrice)
+			settings.SetPayoutType(payoutType)
+			settings.SetRolloverFinalRule(rolloverFinalRule)
+			settings.SetPeriods(resp.Payouts)
+			err = settings.Save(r.Context())
+		case "setSquareLimits":
+			v := validator.New()
+			maxPerUser := v.IntInRange("maxSquaresPerUser", resp.MaxSquaresPerUser, 0, pool.NumberOfSquares()+1)
+			maxPerClaimant := v.IntInRange("maxSquaresPerClaimant", resp.MaxSquaresPerClaimant, 0, pool.NumberOfSquares()+1)
+			if !v.OK() {
+				s.writeJSONResponse(w, http.StatusBadRequest, ErrorResponse{
+					Status:           statusError,
+					Error:            validationErrorMessage,
+					ValidationErrors: v.Errors,
+				})
+				return
+			}
+
+			var limits *model.PoolSquareLimits
+			limits, err = pool.SquareLimits(r.Context())
+			if err != nil {
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				return
+			}
+
+			limits.SetMaxPerUser(maxPerUser)
+			limits.SetMaxPerClaimant(maxPerClaimant)
+			err = limits.Save(r.Context())
+		case "setHoldPeriod":
+			v := validator.New()
+			holdHours := v.IntInRange("holdHours", resp.HoldHours, 0, model.MaxHoldHours+1)
+			if !v.OK() {
+				s.writeJSONResponse(w, http.StatusBadRequest, ErrorResponse{
+					Status:           statusError,
+					Error:            validationErrorMessage,
+					ValidationErrors: v.Errors,
+				})
+				return
+			}
+
+			err = pool.SetHoldPeriod(r.Context(), holdHours)
+		case "setAutoLock":
+			if resp.AutoLock != nil {
+				v := validator.New()
+				v.IntInRange("autoLock", resp.AutoLock.MinutesBefore, 0, model.MaxAutoLockMinutes+1)
+				if !v.OK() {
+					s.writeJSONResponse(w, http.StatusBadRequest, ErrorResponse{
+						Status:           statusError,
+						Error:            validationErrorMessage,
+						ValidationErrors: v.Errors,
+					})
+					return
+				}
+			}
+
+			err = pool.SetAutoLock(r.Context(), resp.AutoLock)
+		case "clone":
+			user, ok := userFromContext(r.Context())
+			if !ok {
+				s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+				return
+			}
+			if !user.HasPermission(model.PermissionCreatePool) {
+				s.writeErrorResponse(w, http.StatusForbidden, nil)
+				return
+			}
+
+			if resp.Name == "" {
+				resp.Name = pool.Name()
+			}
+
+			v := validator.New()
+			name := v.Printable("Name", resp.Name)
+			name = v.MaxLength("Name", name, model.NameMaxLength)
+
+			var event *model.SportsEvent
+			if resp.SportsEventID != nil {
+				event, err = s.model.SportsEventByIDWithTeams(r.Context(), *resp.SportsEventID)
+				if err != nil {
+					s.writeErrorResponse(w, http.StatusInternalServerError, err)
+					return
+				}
+
+				if event == nil {
+					v.AddError("sportsEventId", "the event does not exist")
+				} else if !model.IsValidNumberSetConfigForLeague(pool.NumberSetConfig(), event.League) {
+					v.AddError("sportsEventId", "%s games do not support the pool's number set configuration", event.League)
+				}
+			}
+
+			if err := user.Can(r.Context(), model.ActionCreatePool, user); err != nil {
+				if _, ok := err.(model.ActionError); ok {
+					s.writeErrorResponse(w, http.StatusBadRequest, err)
+					return
+				}
+
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				return
+			}
+
+			if !v.OK() {
+				s.writeJSONResponse(w, http.StatusBadRequest, ErrorResponse{
+					Status:           statusError,
+					Error:            validationErrorMessage,
+					ValidationErrors: v.Errors,
+				})
+				return
+			}
+
+			clone, err := pool.Clone(r.Context(), user.ID, model.PoolCloneOptions{
+				Name:             name,
+				IncludeMembers:   resp.IncludeMembers,
+				IncludeClaimants: resp.IncludeClaimants,
+				SportsEvent:      event,
+			})
+			if err != nil {
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				return
+			}
+
+			s.writeJSONResponse(w, http.StatusCreated, poolResponse{
+				PoolJSON:                 clone.JSON(),
+				HasManagerVisibility:     true,
+				IsPoolManager:            true,
+				CanChangeNumberSetConfig: true,
+			})
+			return
 		default:
 			s.writeErrorResponse(w, http.StatusBadRequest, fmt.Errorf("unsupported action %s", resp.Action))
 			return
@@ -442,42 +643,32 @@ func (s *Server) deletePoolTokenGridIDEndpoint() http.HandlerFunc {
 }
 
 func (s *Server) getPoolConfiguration() http.HandlerFunc {
-	type keyDescription struct {
-		Key         model.GridType `json:"key"`
-		Description string         `json:"description"`
-	}
-
-	gridTypes := model.GridTypes()
-	gridTypesSlice := make([]keyDescription, len(gridTypes))
-	for i, gt := range gridTypes {
-		gridTypesSlice[i] = keyDescription{
-			Key:         gt,
-			Description: gt.Description(),
-		}
-	}
-
 	resp := struct {
 		ClaimantMaxLength     int                                             `json:"claimantMaxLength"`
 		NameMaxLength         int                                             `json:"nameMaxLength"`
 		NotesMaxLength        int                                             `json:"notesMaxLength"`
 		TeamNameMaxLength     int                                             `json:"teamNameMaxLength"`
 		PoolSquareStates      []model.PoolSquareState                         `json:"poolSquareStates"`
-		GridTypes             []keyDescription                                `json:"gridTypes"`
+		GridTypes             []model.GridTypeInfo                            `json:"gridTypes"`
 		NumberSetConfigs      []model.NumberSetConfigInfo                     `json:"numberSetConfigs"`
 		NumberSetTypeInfos    map[model.NumberSetType]model.NumberSetTypeInfo `json:"numberSetTypeInfos"`
 		MinJoinPasswordLength int                                             `json:"minJoinPasswordLength"`
 		GridAnnotationIcons   model.GridAnnotationIconMapping                 `json:"gridAnnotationIcons"`
+		PaymentMethods        []model.PaymentMethod                           `json:"paymentMethods"`
+		OvertimeModes         []model.OvertimeMode                            `json:"overtimeModes"`
 	}{
 		ClaimantMaxLength:     model.ClaimantMaxLength,
 		NameMaxLength:         model.NameMaxLength,
 		NotesMaxLength:        model.NotesMaxLength,
 		TeamNameMaxLength:     model.TeamNameMaxLength,
 		PoolSquareStates:      model.PoolSquareStates,
-		GridTypes:             gridTypesSlice,
+		GridTypes:             model.GridTypeInfos(),
 		NumberSetConfigs:      model.ValidNumberSetConfigs(),
 		NumberSetTypeInfos:    model.NumberSetTypeInfos(),
 		MinJoinPasswordLength: minJoinPasswordLength,
 		GridAnnotationIcons:   model.AnnotationIcons,
+		PaymentMethods:        model.PaymentMethods,
+		OvertimeModes:         model.OvertimeModes,
 	}
 
 	jsonResp, err := json.Marshal(resp)
@@ -576,11 +767,12 @@ func (s *Server) getPoolTokenEndpoint() http.HandlerFunc {
 			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
 			return
 		}
-		isPoolManager, err := user.IsManagerOf(r.Context(), pool)
+		role, err := user.PoolRole(r.Context(), pool)
 		if err != nil {
 			s.writeErrorResponse(w, http.StatusInternalServerError, err)
 			return
 		}
+		isPoolManager := role.Can(model.ActionManagePool)
 
 		// Site admins get read-only manager visibility even if they're not pool managers
 		hasManagerVisibility := isPoolManager || user.IsSiteAdmin
@@ -589,6 +781,7 @@ func (s *Server) getPoolTokenEndpoint() http.HandlerFunc {
 			PoolJSON:             pool.JSON(),
 			HasManagerVisibility: hasManagerVisibility,
 			IsPoolManager:        isPoolManager,
+			Role:                 role,
 		}
 
 		if hasManagerVisibility {
@@ -598,7 +791,29 @@ func (s *Server) getPoolTokenEndpoint() http.HandlerFunc {
 				return
 			}
 			resp.CanChangeNumberSetConfig = canChange
+
+			autoLock, err := pool.AutoLock(r.Context())
+			if err != nil {
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				return
+			}
+			resp.AutoLock = autoLock
+		}
+
+		// managers may assign squares on behalf of others, so only the per-claimant limit applies to them
+		allowance, err := pool.SquareAllowance(r.Context(), user.ID, !isPoolManager)
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+		resp.SquareLimits = allowance
+
+		hold, err := pool.HoldPeriod(r.Context())
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
 		}
+		resp.HoldHours = int(hold / time.Hour)
 
 		s.writeJSONResponse(w, http.StatusOK, resp)
 	}
@@ -634,6 +849,128 @@ func (s *Server) getPoolTokenInviteTokenEndpoint() http.HandlerFunc {
 	}
 }
 
+// getPoolTokenInvitesEndpoint returns the pool's invite links with how many times each has been used
+func (s *Server) getPoolTokenInvitesEndpoint() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pool, ok := poolFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		invites, err := pool.InviteLinks(r.Context())
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		s.writeJSONResponse(w, http.StatusOK, invites)
+	}
+}
+
+// postPoolTokenInvitesEndpoint will create a named invite link. Only the owner may create links that grant a role
+// above claimer, as they cannot otherwise be given by managers.
+func (s *Server) postPoolTokenInvitesEndpoint() http.HandlerFunc {
+	type payload struct {
+		Label string         `json:"label"`
+		Role  model.PoolRole `json:"role"`
+		// MaxUses of 0 allows any number of uses
+		MaxUses int `json:"maxUses"`
+		// ExpiresAt of nil means the link never expires
+		ExpiresAt *time.Time `json:"expiresAt"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		pool, ok := poolFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+		user, ok := userFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		var data payload
+		if ok := s.parseJSONPayload(w, r, &data); !ok {
+			return
+		}
+
+		if data.Role == model.PoolRoleNone {
+			data.Role = model.PoolRoleClaimer
+		}
+
+		v := validator.New()
+		label := v.Printable("label", data.Label, true)
+		label = v.MaxLength("label", label, model.InviteLabelMaxLength)
+		maxUses := v.IntInRange("maxUses", data.MaxUses, 0, model.InviteMaxUses+1)
+
+		if !data.Role.IsValid() {
+			v.AddError("role", "must be one of spectator, claimer, treasurer, grid-editor or manager")
+		}
+
+		if data.ExpiresAt != nil {
+			now := time.Now()
+			if !data.ExpiresAt.After(now) {
+				v.AddError("expiresAt", "must be in the future")
+			} else if data.ExpiresAt.After(now.Add(inviteTokenTTL)) {
+				v.AddError("expiresAt", "must be within a year")
+			}
+		}
+
+		if !v.OK() {
+			s.writeJSONResponse(w, http.StatusBadRequest, ErrorResponse{
+				Status:           statusError,
+				Error:            validationErrorMessage,
+				ValidationErrors: v.Errors,
+			})
+			return
+		}
+
+		if data.Role != model.PoolRoleClaimer && data.Role != model.PoolRoleSpectator &&
+			!s.checkPoolPermission(w, r, model.ActionManageRoles) {
+			return
+		}
+
+		var maxUsesPtr *int
+		if maxUses > 0 {
+			maxUsesPtr = &maxUses
+		}
+
+		invite, err := pool.NewInviteLink(r.Context(), label, data.Role, maxUsesPtr, data.ExpiresAt, user.ID)
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		s.writeJSONResponse(w, http.StatusCreated, invite)
+	}
+}
+
+// deletePoolTokenInvitesInviteEndpoint will revoke an invite link
+func (s *Server) deletePoolTokenInvitesInviteEndpoint() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pool, ok := poolFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		if err := pool.RevokeInviteLink(r.Context(), mux.Vars(r)["invite"]); err != nil {
+			if errors.Is(err, model.ErrInviteNotFound) {
+				s.writeErrorResponse(w, http.StatusNotFound, err)
+				return
+			}
+
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
 func (s *Server) getPoolTokenGridEndpoint() http.HandlerFunc {
 	const defaultPerPage = model.MaxGridsPerPool
 	const maxPerPage = model.MaxGridsPerPool
@@ -732,13 +1069,111 @@ func (s *Server) getPoolTokenGridIDEndpoint() http.HandlerFunc {
 			}
 		}
 
+		if err := grid.LoadAutoDraw(r.Context()); err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		if err := grid.LoadDrawCommitment(r.Context()); err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
 		// Load BDL event if linked
 		if err := grid.LoadBDLEvent(r.Context()); err != nil {
 			s.writeErrorResponse(w, http.StatusInternalServerError, err)
 			return
 		}
 
-		s.writeJSONResponse(w, http.StatusOK, grid.JSONWithWinningSquares(pool.NumberSetConfig(), pool.GridType()))
+		// Load the score history when paying out on every score change
+		if effectiveConfig == model.NumberSetConfigEvery && grid.BDLEvent() != nil {
+			if err := grid.BDLEvent().LoadScoreHistory(r.Context()); err != nil {
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				return
+			}
+		}
+
+		gridJSON := grid.JSONWithWinningSquares(pool.NumberSetConfig(), pool.GridType())
+		if effectiveConfig == model.NumberSetConfigEvery {
+			gridJSON.ScoreChanges = grid.GetGridScoreChangeWinners(grid.BDLEvent(), pool.GridType())
+		}
+
+		if grid.Rollover() && grid.BDLEvent() != nil {
+			squares, err := pool.Squares()
+			if err != nil {
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				return
+			}
+
+			winningSquares := grid.GetGridWinningSquares(grid.BDLEvent(), effectiveConfig, pool.GridType())
+			winningSquares.ApplyRollover(grid.BDLEvent(), grid.PeriodSetTypes(effectiveConfig), squares)
+			gridJSON.Rollovers = winningSquares.Rollovers
+		}
+
+		payouts, err := pool.GridPayouts(r.Context(), grid)
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+		gridJSON.Payouts = payouts
+
+		s.writeJSONResponse(w, http.StatusOK, gridJSON)
+	}
+}
+
+func (s *Server) getPoolTokenPayoutsEndpoint() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pool, ok := poolFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		payouts, err := pool.Payouts(r.Context())
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		s.writeJSONResponse(w, http.StatusOK, payouts)
+	}
+}
+
+func (s *Server) getPoolTokenBalancesEndpoint() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pool, ok := poolFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		balances, err := pool.Balances(r.Context())
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		s.writeJSONResponse(w, http.StatusOK, balances)
+	}
+}
+
+// validatePayment will validate the payment fields of a square update and return the payment to record
+func validatePayment(v *validator.Validator, amount int64, method model.PaymentMethod, reference string) *model.SquarePayment {
+	if amount <= 0 {
+		v.AddError("paymentAmount", "must be greater than zero")
+	}
+
+	if !method.IsValid() {
+		v.AddError("paymentMethod", "must be a valid payment method")
+	}
+
+	reference = v.Printable("paymentReference", reference, true)
+	reference = v.MaxLength("paymentReference", reference, model.PaymentReferenceMaxLength)
+
+	return &model.SquarePayment{
+		Amount:    amount,
+		Method:    method,
+		Reference: reference,
 	}
 }
 
@@ -756,6 +1191,11 @@ func (s *Server) getPoolTokenSquareEndpoint() http.HandlerFunc {
 			return
 		}
 
+		if err := pool.LoadSquareShares(r.Context()); err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
 		squaresJSON := make(map[int]*model.PoolSquareJSON)
 		for key, square := range squares {
 			squaresJSON[key] = square.JSON()
@@ -799,6 +1239,11 @@ func (s *Server) getPoolTokenSquaresPublicEndpoint() http.HandlerFunc {
 			return
 		}
 
+		if err := pool.LoadSquareShares(r.Context()); err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
 		// Convert to JSON (no admin fields populated by default)
 		squaresJSON := make(map[int]*model.PoolSquareJSON)
 		for key, square := range squares {
@@ -809,6 +1254,60 @@ func (s *Server) getPoolTokenSquaresPublicEndpoint() http.HandlerFunc {
 	}
 }
 
+func (s *Server) getPoolTokenGridIDDrawProofEndpoint() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := mux.Vars(r)["token"]
+		gridID, _ := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+
+		pool, err := s.model.PoolByToken(r.Context(), token)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				s.writeErrorResponse(w, http.StatusNotFound, nil)
+				return
+			}
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		// same access rules as the public squares
+		authRequired := pool.PasswordRequired() && (!pool.IsLocked() || !pool.OpenAccessOnLock())
+		if authRequired {
+			_, password, ok := r.BasicAuth()
+			if !ok || !pool.PasswordIsValid(password) {
+				w.Header().Set("WWW-Authenticate", `Basic realm="Pool Access"`)
+				s.writeErrorResponse(w, http.StatusUnauthorized, errors.New("authentication required"))
+				return
+			}
+		}
+
+		grid, err := pool.GridByID(r.Context(), gridID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				s.writeErrorResponse(w, http.StatusNotFound, nil)
+				return
+			}
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		config := pool.NumberSetConfig()
+		if config != model.NumberSetConfigStandard {
+			if err := grid.LoadNumberSets(r.Context()); err != nil {
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				return
+			}
+		}
+
+		proof, err := grid.DrawProof(r.Context(), config)
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		s.writeJSONResponse(w, http.StatusOK, proof)
+	}
+}
+
 func (s *Server) getPoolTokenSquareIDEndpoint() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		pool, ok := poolFromContext(r.Context())
@@ -834,6 +1333,11 @@ func (s *Server) getPoolTokenSquareIDEndpoint() http.HandlerFunc {
 			return
 		}
 
+		if err := square.LoadShares(r.Context()); err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
 		squareJSON := square.JSON()
 
 		// Handle optional gridId parameter for winning periods
@@ -926,6 +1430,9 @@ func (s *Server) postPoolTokenSquareIDEndpoint() http.HandlerFunc {
 		Unclaim           bool                  `json:"unclaim"`
 		Rename            bool                  `json:"rename"`
 		SecondarySquareID int                   `json:"secondarySquareId"`
+		PaymentAmount     int64                 `json:"paymentAmount"`
+		PaymentMethod     model.PaymentMethod   `json:"paymentMethod"`
+		PaymentReference  string                `json:"paymentReference"`
 	}
 
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -957,14 +1464,16 @@ func (s *Server) postPoolTokenSquareIDEndpoint() http.HandlerFunc {
 
 		lr := logrus.WithField("square-id", squareID)
 
-		isPoolManager, err := user.IsManagerOf(r.Context(), pool)
+		role, err := user.PoolRole(r.Context(), pool)
 		if err != nil {
 			s.writeErrorResponse(w, http.StatusInternalServerError, err)
 			return
 		}
+		isPoolManager := role.Can(model.ActionManagePool)
+		canRecordPayments := role.Can(model.ActionRecordPayments)
 
-		// if the user isn't a manager and the grid is locked, do not let the user do anything
-		if pool.IsLocked() && !isPoolManager {
+		// if the user can't record payments and the grid is locked, do not let the user do anything
+		if pool.IsLocked() && !canRecordPayments {
 			s.writeErrorResponse(w, http.StatusForbidden, errors.New("the grid is locked"))
 			return
 		}
@@ -982,6 +1491,7 @@ func (s *Server) postPoolTokenSquareIDEndpoint() http.HandlerFunc {
 		}
 
 		var secondSquare *model.PoolSquare
+		var draft *model.PoolDraft
 		if payload.SecondarySquareID > 0 {
 			secondSquare, err = pool.SquareBySquareID(payload.SecondarySquareID)
 			if err != nil {
@@ -1075,6 +1585,11 @@ func (s *Server) postPoolTokenSquareIDEndpoint() http.HandlerFunc {
 			}
 		} else if len(payload.Claimant) > 0 {
 			// making a claim
+			if err := checkCanClaim(pool, role); err != nil {
+				s.writeErrorResponse(w, http.StatusForbidden, err)
+				return
+			}
+
 			v := validator.New()
 			claimant := v.Printable("name", payload.Claimant)
 			claimant = v.ContainsWordChar("name", claimant)
@@ -1098,6 +1613,43 @@ func (s *Server) postPoolTokenSquareIDEndpoint() http.HandlerFunc {
 				return
 			}
 
+			// in a draft, only the member on the clock may claim, and claiming passes the turn on
+			draft, err = pool.LockDraft(r.Context(), tx)
+			if err != nil {
+				_ = tx.Rollback()
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				return
+			}
+
+			if draft != nil {
+				if err := draft.CheckTurn(user.ID); err != nil {
+					_ = tx.Rollback()
+					s.writeErrorResponse(w, http.StatusForbidden, err)
+					return
+				}
+			}
+
+			if err := pool.CheckSquareLimits(r.Context(), tx, user.ID, claimant, 1, !isPoolManager); err != nil {
+				_ = tx.Rollback()
+				s.writeSquareLimitError(w, err)
+				return
+			}
+
+			// squares claimed by members are reserved until they are paid for if the pool has a hold period
+			var hold time.Duration
+			if !isPoolManager {
+				hold, err = pool.HoldPeriod(r.Context())
+				if err != nil {
+					_ = tx.Rollback()
+					s.writeErrorResponse(w, http.StatusInternalServerError, err)
+					return
+				}
+			}
+
+			if hold > 0 {
+				square.Reserve(hold)
+			}
+
 			lr.WithField("claimant", payload.Claimant).Info("claiming square")
 			if err := square.Save(r.Context(), tx, false, model.PoolSquareLog{
 				RemoteAddr: r.RemoteAddr,
@@ -1118,6 +1670,9 @@ func (s *Server) postPoolTokenSquareIDEndpoint() http.HandlerFunc {
 				secondSquare.SetClaimant(claimant)
 				secondSquare.State = model.PoolSquareStateClaimed
 				secondSquare.SetUserID(user.ID)
+				if hold > 0 {
+					secondSquare.Reserve(hold)
+				}
 
 				if err := secondSquare.Save(r.Context(), tx, false, model.PoolSquareLog{
 					RemoteAddr: r.RemoteAddr,
@@ -1135,12 +1690,25 @@ func (s *Server) postPoolTokenSquareIDEndpoint() http.HandlerFunc {
 				}
 			}
 
+			if draft != nil {
+				if err := draft.Advance(r.Context(), tx); err != nil {
+					_ = tx.Rollback()
+					s.writeErrorResponse(w, http.StatusInternalServerError, err)
+					return
+				}
+			}
+
 			if err := tx.Commit(); err != nil {
 				_ = tx.Rollback()
 				s.writeErrorResponse(w, http.StatusInternalServerError, err)
 				return
 			}
 		} else if payload.Unclaim && square.UserID() == user.ID {
+			if err := checkCanClaim(pool, role); err != nil {
+				s.writeErrorResponse(w, http.StatusForbidden, err)
+				return
+			}
+
 			tx, err := square.Model.DB.BeginTx(r.Context(), nil)
 			if err != nil {
 				s.writeErrorResponse(w, http.StatusInternalServerError, err)
@@ -1185,9 +1753,33 @@ func (s *Server) postPoolTokenSquareIDEndpoint() http.HandlerFunc {
 				s.writeErrorResponse(w, http.StatusInternalServerError, err)
 				return
 			}
-		} else if isPoolManager {
-			// manager actions
+		} else if canRecordPayments {
+			// manager actions. Treasurers may only record payments and mark squares paid.
+			if !isPoolManager && (payload.State == model.PoolSquareStateUnclaimed || payload.State == model.PoolSquareStateReserved) {
+				s.writeErrorResponse(w, http.StatusForbidden, errors.New("your role in this pool only allows you to record payments"))
+				return
+			}
+
+			var payment *model.SquarePayment
+			if payload.PaymentAmount != 0 || payload.PaymentMethod != "" || payload.PaymentReference != "" {
+				v := validator.New()
+				payment = validatePayment(v, payload.PaymentAmount, payload.PaymentMethod, payload.PaymentReference)
+				if !v.OK() {
+					s.writeJSONResponse(w, http.StatusBadRequest, ErrorResponse{
+						Status:           statusError,
+						Error:            validationErrorMessage,
+						ValidationErrors: v.Errors,
+					})
+					return
+				}
+			}
+
 			if payload.State.IsValid() {
+				if payload.State == model.PoolSquareStateReserved && square.State != model.PoolSquareStateReserved {
+					s.writeErrorResponse(w, http.StatusBadRequest, errors.New("squares can only be reserved when claimed by a member"))
+					return
+				}
+
 				if square.State == model.PoolSquareStateUnclaimed && payload.State != model.PoolSquareStateUnclaimed {
 					s.writeErrorResponse(w, http.StatusBadRequest, errors.New("cannot change state of an unclaimed square"))
 					return
@@ -1195,6 +1787,11 @@ func (s *Server) postPoolTokenSquareIDEndpoint() http.HandlerFunc {
 				square.State = payload.State
 			}
 
+			if payment != nil && square.State == model.PoolSquareStateUnclaimed {
+				s.writeErrorResponse(w, http.StatusBadRequest, errors.New("cannot record a payment for an unclaimed square"))
+				return
+			}
+
 			tx, err := s.model.DB.BeginTx(r.Context(), nil)
 			if err != nil {
 				s.writeErrorResponse(w, http.StatusInternalServerError, err)
@@ -1206,10 +1803,28 @@ func (s *Server) postPoolTokenSquareIDEndpoint() http.HandlerFunc {
 				Note:       payload.Note,
 			}); err != nil {
 				_ = tx.Rollback()
-				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+
+				if err == model.ErrSquareShared {
+					s.writeErrorResponse(w, http.StatusBadRequest, errors.New("the square is shared; change its shares instead"))
+				} else {
+					s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				}
+
 				return
 			}
 
+			if payment != nil {
+				lr.WithField("amount", payment.Amount).Info("recording payment")
+				if err := square.RecordPayment(r.Context(), tx, *payment, model.PoolSquareLog{
+					RemoteAddr: r.RemoteAddr,
+					Note:       fmt.Sprintf("admin: payment received (%s)", payment.Method),
+				}); err != nil {
+					_ = tx.Rollback()
+					s.writeErrorResponse(w, http.StatusInternalServerError, err)
+					return
+				}
+			}
+
 			// when unclaiming a primary square, also unclaim its secondary squares
 			if square.State == model.PoolSquareStateUnclaimed {
 				childSquares, err := square.ChildSquares(r.Context(), tx)
@@ -1243,6 +1858,9 @@ func (s *Server) postPoolTokenSquareIDEndpoint() http.HandlerFunc {
 		}
 
 		s.broker.Publish(pool.Token(), PoolEvent{Type: EventSquareUpdated})
+		if draft != nil {
+			s.broker.Publish(pool.Token(), PoolEvent{Type: EventDraftTurn, Draft: draft.JSON()})
+		}
 
 		if isPoolManager {
 			if err := square.LoadLogs(r.Context()); err != nil {
@@ -1255,67 +1873,427 @@ func (s *Server) postPoolTokenSquareIDEndpoint() http.HandlerFunc {
 	}
 }
 
-func (s *Server) postPoolTokenGridIDEndpoint() http.HandlerFunc {
-	type numberSetPayload struct {
-		HomeTeamNumbers []int `json:"homeTeamNumbers"`
-		AwayTeamNumbers []int `json:"awayTeamNumbers"`
+// checkCanClaim returns an error that can be shown to the user if their role does not allow them to claim squares,
+// or if the grid is locked and they may not manage the pool
+func checkCanClaim(pool *model.Pool, role model.PoolRole) error {
+	if err := role.Check(model.ActionClaimSquares); err != nil {
+		return err
 	}
 
-	type payload struct {
-		Action string `json:"action"`
-		Data   *struct {
-			EventDate        string `json:"eventDate"`
-			Notes            string `json:"notes"`
-			Rollover         bool   `json:"rollover"`
-			Label            string `json:"label"`
-			HomeTeamName     string `json:"homeTeamName"`
-			HomeTeamColor1   string `json:"homeTeamColor1"`
-			HomeTeamColor2   string `json:"homeTeamColor2"`
-			AwayTeamName     string `json:"awayTeamName"`
-			AwayTeamColor1   string `json:"awayTeamColor1"`
-			AwayTeamColor2   string `json:"awayTeamColor2"`
-			BrandingImageURL string `json:"brandingImageUrl"`
-			BrandingImageAlt string `json:"brandingImageAlt"`
+	if pool.IsLocked() && !role.Can(model.ActionManagePool) {
+		return errors.New("the grid is locked")
+	}
 
-			// BDL Event linking (optional)
-			BDLEventID *int64 `json:"bdlEventId,omitempty"`
+	return nil
+}
 
-			// Payout configuration (optional, overrides pool's numberSetConfig for payout periods)
-			PayoutConfig *string `json:"payoutConfig,omitempty"`
+// loadSquareForShare loads the square from the route and ensures its shares can be changed by the user
+func (s *Server) loadSquareForShare(w http.ResponseWriter, r *http.Request) (*model.Pool, *model.User, *model.PoolSquare, model.PoolRole, bool) {
+	pool, ok := poolFromContext(r.Context())
+	if !ok {
+		s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+		return nil, nil, nil, model.PoolRoleNone, false
+	}
+	user, ok := userFromContext(r.Context())
+	if !ok {
+		s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+		return nil, nil, nil, model.PoolRoleNone, false
+	}
 
-			// Legacy single set (for "single" config)
-			HomeTeamNumbers []int `json:"homeTeamNumbers"`
-			AwayTeamNumbers []int `json:"awayTeamNumbers"`
+	if pool.GridType() == model.GridTypeRoll100 {
+		s.writeErrorResponse(w, http.StatusBadRequest, errors.New("squares cannot be shared with this grid type"))
+		return nil, nil, nil, model.PoolRoleNone, false
+	}
 
-			// Multiple number sets (for multi-set configs)
-			NumberSets map[model.NumberSetType]numberSetPayload `json:"numberSets"`
+	role, err := user.PoolRole(r.Context(), pool)
+	if err != nil {
+		s.writeErrorResponse(w, http.StatusInternalServerError, err)
+		return nil, nil, nil, model.PoolRoleNone, false
+	}
 
-			// LockPool controls whether to lock the pool after drawing numbers
-			// nil = default (lock if not already locked), true = lock, false = don't lock
-			LockPool *bool `json:"lockPool,omitempty"`
-		} `json:"data,omitempty"`
+	if pool.IsLocked() && !role.Can(model.ActionRecordPayments) {
+		s.writeErrorResponse(w, http.StatusForbidden, errors.New("the grid is locked"))
+		return nil, nil, nil, model.PoolRoleNone, false
 	}
 
-	type drawResponse struct {
-		*model.GridJSON
-		PoolLocks time.Time `json:"poolLocks"`
+	squareID, _ := strconv.Atoi(mux.Vars(r)["id"])
+	square, err := pool.SquareBySquareID(squareID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.writeErrorResponse(w, http.StatusNotFound, nil)
+			return nil, nil, nil, model.PoolRoleNone, false
+		}
+
+		s.writeErrorResponse(w, http.StatusInternalServerError, err)
+		return nil, nil, nil, model.PoolRoleNone, false
+	}
+
+	if err := square.LoadShares(r.Context()); err != nil {
+		s.writeErrorResponse(w, http.StatusInternalServerError, err)
+		return nil, nil, nil, model.PoolRoleNone, false
+	}
+
+	return pool, user, square, role, true
+}
+
+// publishShareUpdate reloads the shares of the square, notifies subscribers of how much of it is left and
+// writes the square to the response
+func (s *Server) publishShareUpdate(w http.ResponseWriter, r *http.Request, pool *model.Pool, square *model.PoolSquare, isPoolManager bool) {
+	if err := square.LoadShares(r.Context()); err != nil {
+		s.writeErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	available := square.AvailableShare()
+	s.broker.Publish(pool.Token(), PoolEvent{Type: EventSquareUpdated, SquareID: square.SquareID, AvailableShare: &available})
+
+	if isPoolManager {
+		if err := square.LoadLogs(r.Context()); err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	s.writeJSONResponse(w, http.StatusOK, square.JSON())
+}
+
+func (s *Server) postPoolTokenSquareIDShareEndpoint() http.HandlerFunc {
+	type postPayload struct {
+		Claimant string `json:"claimant"`
+		Share    int    `json:"share"`
 	}
 
 	return func(w http.ResponseWriter, r *http.Request) {
-		pool, ok := poolFromContext(r.Context())
+		pool, user, square, role, ok := s.loadSquareForShare(w, r)
 		if !ok {
-			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
 			return
 		}
 
-		var data payload
+		if err := checkCanClaim(pool, role); err != nil {
+			s.writeErrorResponse(w, http.StatusForbidden, err)
+			return
+		}
+
 		dec := json.NewDecoder(r.Body)
-		if err := dec.Decode(&data); err != nil {
+		var payload postPayload
+		if err := dec.Decode(&payload); err != nil {
 			s.writeErrorResponse(w, http.StatusBadRequest, err)
 			return
 		}
 
-		gridID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		v := validator.New()
+		claimant := v.Printable("name", payload.Claimant)
+		claimant = v.ContainsWordChar("name", claimant)
+
+		if payload.Share < 1 || payload.Share > model.ShareWhole {
+			v.AddError("share", "must be between 1 and %d", model.ShareWhole)
+		}
+
+		if !v.OK() {
+			s.writeJSONResponse(w, http.StatusBadRequest, ErrorResponse{
+				Status:           statusError,
+				Error:            validationErrorMessage,
+				ValidationErrors: v.Errors,
+			})
+			return
+		}
+
+		// a share would let members claim out of turn
+		draft, err := pool.Draft(r.Context())
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		if draft != nil {
+			s.writeErrorResponse(w, http.StatusBadRequest, errors.New("squares cannot be shared during a draft"))
+			return
+		}
+
+		isPoolManager := role.Can(model.ActionManagePool)
+
+		// shares cannot be reserved, so members may not take unpaid shares that would never be released
+		if !isPoolManager {
+			hold, err := pool.HoldPeriod(r.Context())
+			if err != nil {
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				return
+			}
+
+			if hold > 0 {
+				s.writeErrorResponse(w, http.StatusBadRequest, errors.New("squares cannot be shared in a pool with a hold period"))
+				return
+			}
+		}
+
+		// a share counts as a whole square towards the limits, unless the same name already holds a share of it
+		count := 1
+		if square.HasShare(user.ID, claimant) {
+			count = 0
+		}
+
+		tx, err := s.model.DB.BeginTx(r.Context(), nil)
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		if err := pool.CheckSquareLimits(r.Context(), tx, user.ID, claimant, count, !isPoolManager); err != nil {
+			_ = tx.Rollback()
+			s.writeSquareLimitError(w, err)
+			return
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"square-id": square.SquareID,
+			"claimant":  claimant,
+			"share":     payload.Share,
+		}).Info("claiming share of square")
+
+		if _, err := square.ClaimShare(r.Context(), tx, user.ID, claimant, payload.Share, model.PoolSquareLog{
+			RemoteAddr: r.RemoteAddr,
+			Note:       fmt.Sprintf("user: claimed %s of square", formatShare(payload.Share)),
+		}); err != nil {
+			_ = tx.Rollback()
+
+			if err == model.ErrShareUnavailable {
+				s.writeErrorResponse(w, http.StatusBadRequest, err)
+			} else {
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		if err := tx.Commit(); err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		s.publishShareUpdate(w, r, pool, square, isPoolManager)
+	}
+}
+
+func (s *Server) postPoolTokenSquareIDShareIDEndpoint() http.HandlerFunc {
+	type postPayload struct {
+		State            model.PoolSquareState `json:"state"`
+		Note             string                `json:"note"`
+		Unclaim          bool                  `json:"unclaim"`
+		PaymentAmount    int64                 `json:"paymentAmount"`
+		PaymentMethod    model.PaymentMethod   `json:"paymentMethod"`
+		PaymentReference string                `json:"paymentReference"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		pool, user, square, role, ok := s.loadSquareForShare(w, r)
+		if !ok {
+			return
+		}
+		isPoolManager := role.Can(model.ActionManagePool)
+
+		shareID, _ := strconv.ParseInt(mux.Vars(r)["share_id"], 10, 64)
+		share := square.ShareByID(shareID)
+		if share == nil {
+			s.writeErrorResponse(w, http.StatusNotFound, nil)
+			return
+		}
+
+		dec := json.NewDecoder(r.Body)
+		var payload postPayload
+		if err := dec.Decode(&payload); err != nil {
+			s.writeErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		lr := logrus.WithFields(logrus.Fields{
+			"square-id": square.SquareID,
+			"share-id":  shareID,
+		})
+
+		if payload.Unclaim {
+			if !isPoolManager {
+				if share.UserID() != user.ID {
+					s.writeErrorResponse(w, http.StatusForbidden, nil)
+					return
+				}
+
+				if err := checkCanClaim(pool, role); err != nil {
+					s.writeErrorResponse(w, http.StatusForbidden, err)
+					return
+				}
+			}
+
+			note := fmt.Sprintf("user: `%s` unclaimed share", share.Claimant())
+			if isPoolManager {
+				note = fmt.Sprintf("admin: `%s` unclaimed share", share.Claimant())
+			}
+
+			lr.Info("unclaiming share of square")
+			if err := square.UpdateShare(r.Context(), s.model.DB, shareID, model.PoolSquareStateUnclaimed, user.ID, isPoolManager, model.PoolSquareLog{
+				RemoteAddr: r.RemoteAddr,
+				Note:       note,
+			}); err != nil {
+				if err == model.ErrShareNotUpdated {
+					s.writeErrorResponse(w, http.StatusBadRequest, errors.New("the share cannot be unclaimed"))
+				} else {
+					s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				}
+
+				return
+			}
+
+			s.publishShareUpdate(w, r, pool, square, isPoolManager)
+			return
+		}
+
+		if !role.Can(model.ActionRecordPayments) {
+			lr.WithField("remoteAddr", r.RemoteAddr).Warn("non-manager tried to administer shares")
+			s.writeErrorResponse(w, http.StatusForbidden, nil)
+			return
+		}
+
+		var payment *model.SquarePayment
+		if payload.PaymentAmount != 0 || payload.PaymentMethod != "" || payload.PaymentReference != "" {
+			v := validator.New()
+			payment = validatePayment(v, payload.PaymentAmount, payload.PaymentMethod, payload.PaymentReference)
+			if !v.OK() {
+				s.writeJSONResponse(w, http.StatusBadRequest, ErrorResponse{
+					Status:           statusError,
+					Error:            validationErrorMessage,
+					ValidationErrors: v.Errors,
+				})
+				return
+			}
+		}
+
+		if payload.State != "" && (!payload.State.IsValid() || payload.State == model.PoolSquareStateUnclaimed) {
+			s.writeErrorResponse(w, http.StatusBadRequest, errors.New("invalid state"))
+			return
+		}
+
+		tx, err := s.model.DB.BeginTx(r.Context(), nil)
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		if payload.State != "" {
+			if err := square.UpdateShare(r.Context(), tx, shareID, payload.State, user.ID, true, model.PoolSquareLog{
+				RemoteAddr: r.RemoteAddr,
+				Note:       payload.Note,
+			}); err != nil {
+				_ = tx.Rollback()
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				return
+			}
+		}
+
+		if payment != nil {
+			lr.WithField("amount", payment.Amount).Info("recording share payment")
+			if err := square.RecordSharePayment(r.Context(), tx, share, *payment, model.PoolSquareLog{
+				RemoteAddr: r.RemoteAddr,
+				Note:       fmt.Sprintf("admin: payment received from `%s` (%s)", share.Claimant(), payment.Method),
+			}); err != nil {
+				_ = tx.Rollback()
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				return
+			}
+		}
+
+		if err := tx.Commit(); err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		s.publishShareUpdate(w, r, pool, square, isPoolManager)
+	}
+}
+
+// formatShare formats a share in basis points as a percentage, e.g. 2500 as "25%"
+func formatShare(share int) string {
+	if share%100 == 0 {
+		return fmt.Sprintf("%d%%", share/100)
+	}
+
+	return fmt.Sprintf("%.2f%%", float64(share)/100)
+}
+
+func (s *Server) postPoolTokenGridIDEndpoint() http.HandlerFunc {
+	type numberSetPayload struct {
+		HomeTeamNumbers []int `json:"homeTeamNumbers"`
+		AwayTeamNumbers []int `json:"awayTeamNumbers"`
+	}
+
+	type payload struct {
+		Action string `json:"action"`
+		Data   *struct {
+			EventDate        string `json:"eventDate"`
+			Notes            string `json:"notes"`
+			Rollover         bool   `json:"rollover"`
+			Label            string `json:"label"`
+			HomeTeamName     string `json:"homeTeamName"`
+			HomeTeamColor1   string `json:"homeTeamColor1"`
+			HomeTeamColor2   string `json:"homeTeamColor2"`
+			AwayTeamName     string `json:"awayTeamName"`
+			AwayTeamColor1   string `json:"awayTeamColor1"`
+			AwayTeamColor2   string `json:"awayTeamColor2"`
+			BrandingImageURL string `json:"brandingImageUrl"`
+			BrandingImageAlt string `json:"brandingImageAlt"`
+
+			// BDL Event linking (optional)
+			BDLEventID *int64 `json:"bdlEventId,omitempty"`
+
+			// Payout configuration (optional, overrides pool's numberSetConfig for payout periods)
+			PayoutConfig *string `json:"payoutConfig,omitempty"`
+
+			// Overtime mode (optional, "include" folds overtime into the final score, "separate" pays it on its own)
+			OvertimeMode string `json:"overtimeMode,omitempty"`
+
+			// Legacy single set (for "single" config)
+			HomeTeamNumbers []int `json:"homeTeamNumbers"`
+			AwayTeamNumbers []int `json:"awayTeamNumbers"`
+
+			// Multiple number sets (for multi-set configs)
+			NumberSets map[model.NumberSetType]numberSetPayload `json:"numberSets"`
+
+			// LockPool controls whether to lock the pool after drawing numbers
+			// nil = default (lock if not already locked), true = lock, false = don't lock
+			LockPool *bool `json:"lockPool,omitempty"`
+
+			// AutoDraw schedules the numbers to be drawn automatically ("lock" or "kickoff"). Empty turns it off.
+			AutoDraw        model.AutoDrawTrigger `json:"autoDraw"`
+			AutoDrawMinutes int                   `json:"autoDrawMinutes"`
+
+			// Reason is why the numbers are being redrawn, which is shown to every member
+			Reason string `json:"reason"`
+		} `json:"data,omitempty"`
+	}
+
+	type drawResponse struct {
+		*model.GridJSON
+		PoolLocks time.Time `json:"poolLocks"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		pool, ok := poolFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+		user, ok := userFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		var data payload
+		dec := json.NewDecoder(r.Body)
+		if err := dec.Decode(&data); err != nil {
+			s.writeErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		gridID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
 		if err != nil {
 			s.writeErrorResponse(w, http.StatusBadRequest, err)
 			return
@@ -1364,19 +2342,21 @@ func (s *Server) postPoolTokenGridIDEndpoint() http.HandlerFunc {
 					}
 				}
 
-				if err := grid.DrawAllNumbersManual(r.Context(), config, numberSets); err != nil {
+				if err := grid.DrawAllNumbersManual(r.Context(), config, numberSets, user.ID); err != nil {
 					s.writeErrorResponse(w, http.StatusBadRequest, fmt.Errorf("could not set manual numbers: %w", err))
 					return
 				}
 			} else {
 				// Legacy single set behavior
-				if err := grid.SetManualNumbers(data.Data.HomeTeamNumbers, data.Data.AwayTeamNumbers); err != nil {
-					s.writeErrorResponse(w, http.StatusBadRequest, fmt.Errorf("could not set manual numbers: %w", err))
-					return
+				numberSets := map[model.NumberSetType]model.NumberSetInput{
+					model.NumberSetTypeAll: {
+						HomeNumbers: data.Data.HomeTeamNumbers,
+						AwayNumbers: data.Data.AwayTeamNumbers,
+					},
 				}
 
-				if err := grid.Save(r.Context()); err != nil {
-					s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				if err := grid.DrawAllNumbersManual(r.Context(), model.NumberSetConfigStandard, numberSets, user.ID); err != nil {
+					s.writeErrorResponse(w, http.StatusBadRequest, fmt.Errorf("could not set manual numbers: %w", err))
 					return
 				}
 			}
@@ -1417,31 +2397,14 @@ func (s *Server) postPoolTokenGridIDEndpoint() http.HandlerFunc {
 		case "drawNumbers":
 			config := pool.NumberSetConfig()
 
-			// Handle multi-set configs
-			if config != model.NumberSetConfigStandard {
-				if err := grid.DrawAllNumbersRandom(r.Context(), config); err != nil {
-					if err == model.ErrNumbersAlreadyDrawn {
-						s.writeErrorResponse(w, http.StatusBadRequest, fmt.Errorf("the numbers have already been drawn"))
-						return
-					}
-					s.writeErrorResponse(w, http.StatusInternalServerError, err)
-					return
-				}
-			} else {
-				// Legacy single set behavior
-				if err := grid.SelectRandomNumbers(); err != nil {
-					if err == model.ErrNumbersAlreadyDrawn {
-						s.writeErrorResponse(w, http.StatusBadRequest, fmt.Errorf("the numbers have already been drawn"))
-						return
-					}
-					s.writeErrorResponse(w, http.StatusInternalServerError, err)
-					return
-				}
-
-				if err := grid.Save(r.Context()); err != nil {
-					s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			// The numbers are derived from the grid's draw commitment so that the draw can be verified
+			if err := grid.DrawAllNumbersRandom(r.Context(), config, user.ID); err != nil {
+				if err == model.ErrNumbersAlreadyDrawn {
+					s.writeErrorResponse(w, http.StatusBadRequest, fmt.Errorf("the numbers have already been drawn"))
 					return
 				}
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				return
 			}
 
 			// Load number sets for response
@@ -1477,20 +2440,119 @@ func (s *Server) postPoolTokenGridIDEndpoint() http.HandlerFunc {
 				PoolLocks: pool.Locks(),
 			})
 			return
-		case "save":
-			if data.Data == nil {
-				s.writeErrorResponse(w, http.StatusBadRequest, errors.New("missing data in payload"))
-				return
+		case "redraw":
+			config := pool.NumberSetConfig()
+
+			reason := ""
+			if data.Data != nil {
+				reason = data.Data.Reason
 			}
 
 			v := validator.New()
-			eventDate := v.Datetime("Event Date", data.Data.EventDate, "00:00", "0", true)
-			label := v.Printable("Label", data.Data.Label, true)
-			homeTeamName := v.Printable("Home Team Name", data.Data.HomeTeamName, true)
-			homeTeamName = v.MaxLength("Home Team Name", homeTeamName, model.TeamNameMaxLength)
-			homeTeamColor1 := v.Color("Home Team Colors", data.Data.HomeTeamColor1, true)
-			homeTeamColor2 := v.Color("Home Team Colors", data.Data.HomeTeamColor2, true)
-			awayTeamName := v.Printable("Away Team Name", data.Data.AwayTeamName, true)
+			reason = v.Printable("reason", reason)
+			reason = v.MaxLength("reason", reason, model.RedrawReasonMaxLength)
+			if !v.OK() {
+				s.writeJSONResponse(w, http.StatusBadRequest, ErrorResponse{
+					Status:           statusError,
+					Error:            validationErrorMessage,
+					ValidationErrors: v.Errors,
+				})
+				return
+			}
+
+			// once the game is under way a redraw could be used to change who is winning
+			if err := grid.LoadBDLEvent(r.Context()); err != nil {
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				return
+			}
+
+			if event := grid.BDLEvent(); event != nil && event.HasStarted() {
+				s.writeErrorResponse(w, http.StatusBadRequest, errors.New("the numbers cannot be redrawn once the game has started"))
+				return
+			}
+
+			if config != model.NumberSetConfigStandard {
+				if err := grid.LoadNumberSets(r.Context()); err != nil {
+					s.writeErrorResponse(w, http.StatusInternalServerError, err)
+					return
+				}
+			}
+
+			if err := grid.Redraw(r.Context(), config, user.ID, reason); err != nil {
+				if errors.Is(err, model.ErrNumbersNotDrawn) {
+					s.writeErrorResponse(w, http.StatusBadRequest, errors.New("the numbers have not been drawn yet"))
+					return
+				}
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				return
+			}
+
+			if config != model.NumberSetConfigStandard {
+				if err := grid.LoadNumberSets(r.Context()); err != nil {
+					s.writeErrorResponse(w, http.StatusInternalServerError, err)
+					return
+				}
+			}
+
+			s.broker.Publish(pool.Token(), PoolEvent{Type: EventGridUpdated})
+
+			s.writeJSONResponse(w, http.StatusOK, grid.JSON())
+			return
+		case "setAutoDraw":
+			if data.Data == nil {
+				s.writeErrorResponse(w, http.StatusBadRequest, errors.New("missing data in payload"))
+				return
+			}
+
+			var autoDraw *model.GridAutoDraw
+			if data.Data.AutoDraw != "" {
+				autoDraw = &model.GridAutoDraw{Trigger: data.Data.AutoDraw}
+
+				v := validator.New()
+				if !data.Data.AutoDraw.IsValid() {
+					v.AddError("autoDraw", "must be a valid auto-draw trigger")
+				}
+
+				if data.Data.AutoDraw == model.AutoDrawTriggerKickoff {
+					autoDraw.MinutesBefore = v.IntInRange("autoDrawMinutes", data.Data.AutoDrawMinutes, 0, model.MaxAutoDrawMinutes+1)
+					if grid.BDLEventID() == nil {
+						v.AddError("autoDraw", "the grid must be linked to an event to draw before kickoff")
+					}
+				}
+
+				if !v.OK() {
+					s.writeJSONResponse(w, http.StatusBadRequest, ErrorResponse{
+						Status:           statusError,
+						Error:            validationErrorMessage,
+						ValidationErrors: v.Errors,
+					})
+					return
+				}
+			}
+
+			if err := grid.SaveAutoDraw(r.Context(), autoDraw); err != nil {
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				return
+			}
+
+			s.broker.Publish(pool.Token(), PoolEvent{Type: EventGridUpdated})
+
+			s.writeJSONResponse(w, http.StatusOK, grid.JSON())
+			return
+		case "save":
+			if data.Data == nil {
+				s.writeErrorResponse(w, http.StatusBadRequest, errors.New("missing data in payload"))
+				return
+			}
+
+			v := validator.New()
+			eventDate := v.Datetime("Event Date", data.Data.EventDate, "00:00", "0", true)
+			label := v.Printable("Label", data.Data.Label, true)
+			homeTeamName := v.Printable("Home Team Name", data.Data.HomeTeamName, true)
+			homeTeamName = v.MaxLength("Home Team Name", homeTeamName, model.TeamNameMaxLength)
+			homeTeamColor1 := v.Color("Home Team Colors", data.Data.HomeTeamColor1, true)
+			homeTeamColor2 := v.Color("Home Team Colors", data.Data.HomeTeamColor2, true)
+			awayTeamName := v.Printable("Away Team Name", data.Data.AwayTeamName, true)
 			awayTeamName = v.MaxLength("Away Team Name", awayTeamName, model.TeamNameMaxLength)
 			awayTeamColor1 := v.Color("Away Team Colors", data.Data.AwayTeamColor1, true)
 			awayTeamColor2 := v.Color("Away Team Colors", data.Data.AwayTeamColor2, true)
@@ -1505,6 +2567,11 @@ func (s *Server) postPoolTokenGridIDEndpoint() http.HandlerFunc {
 				v.AddError("rollover", "Rollover is not valid for this pool type")
 			}
 
+			overtimeMode := model.OvertimeMode(data.Data.OvertimeMode)
+			if overtimeMode != "" && !overtimeMode.IsValid() {
+				v.AddError("overtimeMode", "must be a valid overtime mode")
+			}
+
 			if !v.OK() {
 				s.writeJSONResponse(w, http.StatusBadRequest, ErrorResponse{
 					Status:           statusError,
@@ -1573,6 +2640,9 @@ func (s *Server) postPoolTokenGridIDEndpoint() http.HandlerFunc {
 			grid.SetHomeTeamName(homeTeamName)
 			grid.SetAwayTeamName(awayTeamName)
 			grid.SetRollover(data.Data.Rollover)
+			if overtimeMode != "" {
+				grid.SetOvertimeMode(overtimeMode)
+			}
 
 			// Handle payout config - validate and set if provided
 			if data.Data.PayoutConfig != nil {
@@ -1734,6 +2804,85 @@ func (s *Server) deletePoolTokenGridIDSquareSquareIDAnnotationEndpoint() http.Ha
 	}
 }
 
+func (s *Server) getPoolTokenGridIDDrawsEndpoint() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		grid, ok := gridFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		draws, err := grid.Draws(r.Context())
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		s.writeJSONResponse(w, http.StatusOK, draws)
+	}
+}
+
+func (s *Server) postPoolTokenGridIDDrawEntropyEndpoint() http.HandlerFunc {
+	type payload struct {
+		Entropy string `json:"entropy"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		pool, ok := poolFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+		user, ok := userFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+		grid, ok := gridFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		var payloadData payload
+		if err := json.NewDecoder(r.Body).Decode(&payloadData); err != nil {
+			s.writeErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		v := validator.New()
+		entropy := v.Printable("entropy", payloadData.Entropy)
+		entropy = v.MaxLength("entropy", entropy, model.DrawEntropyMaxLength)
+
+		if !v.OK() {
+			s.writeJSONResponse(w, http.StatusBadRequest, ErrorResponse{
+				Status:           statusError,
+				Error:            validationErrorMessage,
+				ValidationErrors: v.Errors,
+			})
+			return
+		}
+
+		if err := grid.AddDrawEntropy(r.Context(), user.ID, entropy); err != nil {
+			if errors.Is(err, model.ErrDrawEntropyClosed) {
+				s.writeErrorResponse(w, http.StatusBadRequest, err)
+				return
+			}
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		config := pool.NumberSetConfig()
+		proof, err := grid.DrawProof(r.Context(), config)
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		s.writeJSONResponse(w, http.StatusOK, proof)
+	}
+}
+
 func (s *Server) postPoolTokenMemberEndpoint() http.HandlerFunc {
 	type payload struct {
 		Password string `json:"password"`
@@ -1764,17 +2913,16 @@ func (s *Server) postPoolTokenMemberEndpoint() http.HandlerFunc {
 			return
 		}
 
+		var invite *model.PoolInvite
 		if data.Invite != "" {
-			invite, err := s.model.PoolInviteByToken(r.Context(), data.Invite)
+			invite, err = s.model.PoolInviteByToken(r.Context(), data.Invite)
 			if err != nil {
-				s.writeErrorResponse(w, http.StatusBadRequest, errors.New("invalid invite token"))
+				s.writeErrorResponse(w, http.StatusBadRequest, model.ErrInvalidInvite)
 				return
 			}
 
-			if invite.PoolID != pool.ID() ||
-				!pool.CheckIDIsValid(invite.CheckID) ||
-				time.Now().After(invite.ExpiresAt) {
-				s.writeErrorResponse(w, http.StatusBadRequest, errors.New("invalid invite token"))
+			if err := invite.Check(pool, time.Now()); err != nil {
+				s.writeErrorResponse(w, http.StatusBadRequest, err)
 				return
 			}
 		} else if data.JWT != "" {
@@ -1806,7 +2954,23 @@ func (s *Server) postPoolTokenMemberEndpoint() http.HandlerFunc {
 			return
 		}
 
-		if err := user.JoinPool(r.Context(), pool); err != nil {
+		if invite != nil {
+			err = user.JoinPoolWithInvite(r.Context(), pool, invite)
+		} else {
+			err = user.JoinPool(r.Context(), pool)
+		}
+
+		if err != nil {
+			if errors.Is(err, model.ErrBannedFromPool) {
+				s.writeErrorResponse(w, http.StatusForbidden, err)
+				return
+			}
+
+			if errors.Is(err, model.ErrInviteUsedUp) {
+				s.writeErrorResponse(w, http.StatusBadRequest, err)
+				return
+			}
+
 			s.writeErrorResponse(w, http.StatusInternalServerError, err)
 			return
 		}
@@ -1828,6 +2992,10 @@ func (s *Server) postPoolTokenSquaresBulkEndpoint() http.HandlerFunc {
 		Claimant  string                `json:"claimant"`
 		State     model.PoolSquareState `json:"state"`
 		Note      string                `json:"note"`
+
+		PaymentAmount    int64               `json:"paymentAmount"`
+		PaymentMethod    model.PaymentMethod `json:"paymentMethod"`
+		PaymentReference string              `json:"paymentReference"`
 	}
 
 	type response struct {
@@ -1856,6 +3024,7 @@ func (s *Server) postPoolTokenSquaresBulkEndpoint() http.HandlerFunc {
 			return
 		}
 
+		var payment *model.SquarePayment
 		switch req.Action {
 		case "claim":
 			if req.Claimant == "" {
@@ -1869,6 +3038,22 @@ func (s *Server) postPoolTokenSquaresBulkEndpoint() http.HandlerFunc {
 				s.writeErrorResponse(w, http.StatusBadRequest, errors.New("state must be claimed, paid-partial, or paid-full"))
 				return
 			}
+		case "record_payment":
+			if req.State != "" && req.State != model.PoolSquareStateClaimed && req.State != model.PoolSquareStatePaidPartial && req.State != model.PoolSquareStatePaidFull {
+				s.writeErrorResponse(w, http.StatusBadRequest, errors.New("state must be claimed, paid-partial, or paid-full"))
+				return
+			}
+
+			v := validator.New()
+			payment = validatePayment(v, req.PaymentAmount, req.PaymentMethod, req.PaymentReference)
+			if !v.OK() {
+				s.writeJSONResponse(w, http.StatusBadRequest, ErrorResponse{
+					Status:           statusError,
+					Error:            validationErrorMessage,
+					ValidationErrors: v.Errors,
+				})
+				return
+			}
 		default:
 			s.writeErrorResponse(w, http.StatusBadRequest, fmt.Errorf("invalid action: %s", req.Action))
 			return
@@ -1909,7 +3094,7 @@ func (s *Server) postPoolTokenSquaresBulkEndpoint() http.HandlerFunc {
 				continue
 			}
 
-			if req.Action == "set_state" && square.State == model.PoolSquareStateUnclaimed {
+			if (req.Action == "set_state" || req.Action == "record_payment") && square.State == model.PoolSquareStateUnclaimed {
 				results = append(results, squareResult{SquareID: squareID, OK: false, Error: "square must be claimed first"})
 				continue
 			}
@@ -1926,10 +3111,13 @@ func (s *Server) postPoolTokenSquaresBulkEndpoint() http.HandlerFunc {
 				square.SetClaimant(req.Claimant)
 				square.State = model.PoolSquareStateClaimed
 				square.SetUserID(user.ID)
-				saveErr = square.Save(r.Context(), tx, true, model.PoolSquareLog{
-					RemoteAddr: r.RemoteAddr,
-					Note:       "admin: bulk claim",
-				})
+				saveErr = pool.CheckSquareLimits(r.Context(), tx, user.ID, req.Claimant, 1, false)
+				if saveErr == nil {
+					saveErr = square.Save(r.Context(), tx, true, model.PoolSquareLog{
+						RemoteAddr: r.RemoteAddr,
+						Note:       "admin: bulk claim",
+					})
+				}
 			case "unclaim":
 				square.State = model.PoolSquareStateUnclaimed
 				saveErr = square.Save(r.Context(), tx, true, model.PoolSquareLog{
@@ -1963,6 +3151,25 @@ func (s *Server) postPoolTokenSquaresBulkEndpoint() http.HandlerFunc {
 					RemoteAddr: r.RemoteAddr,
 					Note:       setStateNote,
 				})
+			case "record_payment":
+				if req.State != "" && req.State != square.State {
+					square.State = req.State
+					saveErr = square.Save(r.Context(), tx, true, model.PoolSquareLog{
+						RemoteAddr: r.RemoteAddr,
+						Note:       fmt.Sprintf("admin: bulk set state to %s", req.State),
+					})
+				}
+
+				if saveErr == nil {
+					paymentNote := fmt.Sprintf("admin: bulk payment received (%s)", payment.Method)
+					if req.Note != "" {
+						paymentNote = req.Note
+					}
+					saveErr = square.RecordPayment(r.Context(), tx, *payment, model.PoolSquareLog{
+						RemoteAddr: r.RemoteAddr,
+						Note:       paymentNote,
+					})
+				}
 			}
 
 			if saveErr != nil {
@@ -1970,6 +3177,10 @@ func (s *Server) postPoolTokenSquaresBulkEndpoint() http.HandlerFunc {
 				errMsg := "internal error"
 				if saveErr == model.ErrSquareAlreadyClaimed {
 					errMsg = "already claimed"
+				} else if saveErr == model.ErrSquareShared {
+					errMsg = "square is shared"
+				} else if errors.Is(saveErr, model.ErrSquareLimitReached) {
+					errMsg = saveErr.Error()
 				}
 				results = append(results, squareResult{SquareID: squareID, OK: false, Error: errMsg})
 				continue
@@ -1990,7 +3201,820 @@ func (s *Server) postPoolTokenSquaresBulkEndpoint() http.HandlerFunc {
 
 type poolResponse struct {
 	*model.PoolJSON
-	HasManagerVisibility     bool `json:"hasManagerVisibility"`
-	IsPoolManager            bool `json:"isPoolManager"`
-	CanChangeNumberSetConfig bool `json:"canChangeNumberSetConfig,omitempty"`
+	HasManagerVisibility     bool                   `json:"hasManagerVisibility"`
+	IsPoolManager            bool                   `json:"isPoolManager"`
+	Role                     model.PoolRole         `json:"role,omitempty"`
+	CanChangeNumberSetConfig bool                   `json:"canChangeNumberSetConfig,omitempty"`
+	SquareLimits             *model.SquareAllowance `json:"squareLimits,omitempty"`
+	HoldHours                int                    `json:"holdHours,omitempty"`
+	AutoLock                 *model.PoolAutoLock    `json:"autoLock,omitempty"`
+}
+
+func (s *Server) postPoolTokenSquaresQuickPickEndpoint() http.HandlerFunc {
+	type requestPayload struct {
+		Claimant string `json:"claimant"`
+		Count    int    `json:"count"`
+	}
+
+	type response struct {
+		Squares []*model.PoolSquareJSON `json:"squares"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		pool, ok := poolFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+		user, ok := userFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		role, err := user.PoolRole(r.Context(), pool)
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+		isPoolManager := role.Can(model.ActionManagePool)
+
+		if err := checkCanClaim(pool, role); err != nil {
+			s.writeErrorResponse(w, http.StatusForbidden, err)
+			return
+		}
+
+		var req requestPayload
+		if ok := s.parseJSONPayload(w, r, &req); !ok {
+			return
+		}
+
+		v := validator.New()
+		claimant := v.Printable("name", req.Claimant)
+		claimant = v.ContainsWordChar("name", claimant)
+		count := v.IntInRange("count", req.Count, 1, model.QuickPickMax+1)
+
+		if !v.OK() {
+			s.writeJSONResponse(w, http.StatusBadRequest, ErrorResponse{
+				Status:           statusError,
+				Error:            validationErrorMessage,
+				ValidationErrors: v.Errors,
+			})
+			return
+		}
+
+		tx, err := s.model.DB.BeginTx(r.Context(), nil)
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		draft, err := pool.LockDraft(r.Context(), tx)
+		if err != nil {
+			_ = tx.Rollback()
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		if draft != nil {
+			if err := draft.CheckTurn(user.ID); err != nil {
+				_ = tx.Rollback()
+				s.writeErrorResponse(w, http.StatusForbidden, err)
+				return
+			}
+
+			if count != 1 {
+				_ = tx.Rollback()
+				s.writeErrorResponse(w, http.StatusBadRequest, errors.New("only one square may be picked per turn"))
+				return
+			}
+		}
+
+		if err := pool.CheckSquareLimits(r.Context(), tx, user.ID, claimant, count, !isPoolManager); err != nil {
+			_ = tx.Rollback()
+			s.writeSquareLimitError(w, err)
+			return
+		}
+
+		var hold time.Duration
+		if !isPoolManager {
+			hold, err = pool.HoldPeriod(r.Context())
+			if err != nil {
+				_ = tx.Rollback()
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				return
+			}
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"pool":     pool.ID(),
+			"claimant": claimant,
+			"count":    count,
+		}).Info("quick picking squares")
+
+		squares, err := pool.QuickPickSquares(r.Context(), tx, user.ID, claimant, count, hold, model.PoolSquareLog{
+			RemoteAddr: r.RemoteAddr,
+			Note:       "user: quick pick",
+		})
+		if err != nil {
+			_ = tx.Rollback()
+
+			if errors.Is(err, model.ErrNotEnoughSquares) || errors.Is(err, model.ErrSquareAlreadyClaimed) {
+				s.writeErrorResponse(w, http.StatusBadRequest, err)
+			} else {
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		if draft != nil {
+			if err := draft.Advance(r.Context(), tx); err != nil {
+				_ = tx.Rollback()
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				return
+			}
+		}
+
+		if err := tx.Commit(); err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		s.broker.Publish(pool.Token(), PoolEvent{Type: EventSquareUpdated})
+		if draft != nil {
+			s.broker.Publish(pool.Token(), PoolEvent{Type: EventDraftTurn, Draft: draft.JSON()})
+		}
+
+		resp := response{Squares: make([]*model.PoolSquareJSON, len(squares))}
+		for i, square := range squares {
+			resp.Squares[i] = square.JSON()
+		}
+
+		s.writeJSONResponse(w, http.StatusOK, resp)
+	}
+}
+
+// writeSquareLimitError writes a validation error if err is because a square limit was reached, and an internal
+// server error otherwise
+func (s *Server) writeSquareLimitError(w http.ResponseWriter, err error) {
+	var limitErr *model.SquareLimitError
+	if !errors.As(err, &limitErr) {
+		s.writeErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	v := validator.New()
+	v.AddError("claimant", "%s", limitErr.Error())
+	s.writeJSONResponse(w, http.StatusBadRequest, ErrorResponse{
+		Status:           statusError,
+		Error:            validationErrorMessage,
+		ValidationErrors: v.Errors,
+	})
+}
+
+func (s *Server) getPoolTokenDraftEndpoint() http.HandlerFunc {
+	type response struct {
+		Draft *model.PoolDraftJSON `json:"draft"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		pool, ok := poolFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		draft, err := pool.Draft(r.Context())
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		var resp response
+		if draft != nil {
+			resp.Draft = draft.JSON()
+		}
+
+		s.writeJSONResponse(w, http.StatusOK, resp)
+	}
+}
+
+func (s *Server) postPoolTokenDraftEndpoint() http.HandlerFunc {
+	type requestPayload struct {
+		Order       []model.DraftMember `json:"order"`
+		PickSeconds int                 `json:"pickSeconds"`
+	}
+
+	type response struct {
+		Draft *model.PoolDraftJSON `json:"draft"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		pool, ok := poolFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		var req requestPayload
+		if ok := s.parseJSONPayload(w, r, &req); !ok {
+			return
+		}
+
+		v := validator.New()
+		if len(req.Order) == 0 || len(req.Order) > pool.NumberOfSquares() {
+			v.AddError("order", "must have between 1 and %d members", pool.NumberOfSquares())
+		}
+
+		seen := make(map[int64]bool, len(req.Order))
+		members := make([]model.DraftMember, len(req.Order))
+		for i, member := range req.Order {
+			if seen[member.UserID] {
+				v.AddError("order", "member %d is in the order more than once", member.UserID)
+			}
+			seen[member.UserID] = true
+
+			claimant := v.Printable("claimant", member.Claimant)
+			claimant = v.ContainsWordChar("claimant", claimant)
+			members[i] = model.DraftMember{UserID: member.UserID, Claimant: claimant}
+		}
+
+		if req.PickSeconds != 0 {
+			v.IntInRange("pickSeconds", req.PickSeconds, model.DraftMinPickSeconds, model.DraftMaxPickSeconds+1)
+		}
+
+		if !v.OK() {
+			s.writeJSONResponse(w, http.StatusBadRequest, ErrorResponse{
+				Status:           statusError,
+				Error:            validationErrorMessage,
+				ValidationErrors: v.Errors,
+			})
+			return
+		}
+
+		draft := pool.NewDraft(members, req.PickSeconds)
+		if err := draft.Save(r.Context()); err != nil {
+			if errors.Is(err, model.ErrDraftMemberNotFound) {
+				v.AddError("order", "%s", err.Error())
+				s.writeJSONResponse(w, http.StatusBadRequest, ErrorResponse{
+					Status:           statusError,
+					Error:            validationErrorMessage,
+					ValidationErrors: v.Errors,
+				})
+				return
+			}
+
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		s.broker.Publish(pool.Token(), PoolEvent{Type: EventDraftTurn, Draft: draft.JSON()})
+		s.writeJSONResponse(w, http.StatusOK, response{Draft: draft.JSON()})
+	}
+}
+
+func (s *Server) deletePoolTokenDraftEndpoint() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pool, ok := poolFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		if err := pool.DeleteDraft(r.Context()); err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		s.broker.Publish(pool.Token(), PoolEvent{Type: EventDraftTurn})
+		s.writeJSONResponse(w, http.StatusNoContent, nil)
+	}
+}
+
+func (s *Server) getPoolTokenManagersEndpoint() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pool, ok := poolFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		managers, err := pool.Managers(r.Context())
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		s.writeJSONResponse(w, http.StatusOK, managers)
+	}
+}
+
+// postPoolTokenManagersUserIDEndpoint will make a member of the pool a manager
+func (s *Server) postPoolTokenManagersUserIDEndpoint() http.HandlerFunc {
+	return s.setPoolManagerEndpoint(true)
+}
+
+// deletePoolTokenManagersUserIDEndpoint will revoke a manager's status. They remain a member of the pool.
+func (s *Server) deletePoolTokenManagersUserIDEndpoint() http.HandlerFunc {
+	return s.setPoolManagerEndpoint(false)
+}
+
+func (s *Server) setPoolManagerEndpoint(isManager bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pool, ok := poolFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+		user, ok := userFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		userID, err := strconv.ParseInt(mux.Vars(r)["user_id"], 10, 64)
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if userID == pool.UserID() {
+			s.writeErrorResponse(w, http.StatusBadRequest, errors.New("the owner of the pool is always a manager"))
+			return
+		}
+
+		member, err := s.model.GetUserByID(r.Context(), userID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				s.writeErrorResponse(w, http.StatusNotFound, nil)
+				return
+			}
+
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		if err := member.SetManagerOf(r.Context(), pool, isManager, user.ID); err != nil {
+			if errors.Is(err, model.ErrNotPoolMember) {
+				s.writeErrorResponse(w, http.StatusBadRequest, err)
+				return
+			}
+
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		managers, err := pool.Managers(r.Context())
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		s.broker.Publish(pool.Token(), PoolEvent{Type: EventPoolUpdated})
+		s.writeJSONResponse(w, http.StatusOK, managers)
+	}
+}
+
+// postPoolTokenMembersUserIDRoleEndpoint will change the role of a member of the pool
+func (s *Server) postPoolTokenMembersUserIDRoleEndpoint() http.HandlerFunc {
+	type payload struct {
+		Role model.PoolRole `json:"role"`
+	}
+
+	type response struct {
+		UserID int64          `json:"userId"`
+		Role   model.PoolRole `json:"role"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		pool, ok := poolFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+		user, ok := userFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		userID, err := strconv.ParseInt(mux.Vars(r)["user_id"], 10, 64)
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if userID == pool.UserID() {
+			s.writeErrorResponse(w, http.StatusBadRequest, errors.New("the role of the owner of the pool cannot be changed"))
+			return
+		}
+
+		var data payload
+		if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+			s.writeErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if !data.Role.IsValid() {
+			v := validator.New()
+			v.AddError("role", "must be one of spectator, claimer, treasurer, grid-editor or manager")
+			s.writeJSONResponse(w, http.StatusBadRequest, ErrorResponse{
+				Status:           statusError,
+				Error:            validationErrorMessage,
+				ValidationErrors: v.Errors,
+			})
+			return
+		}
+
+		member, err := s.model.GetUserByID(r.Context(), userID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				s.writeErrorResponse(w, http.StatusNotFound, nil)
+				return
+			}
+
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		if err := member.SetPoolRole(r.Context(), pool, data.Role, user.ID); err != nil {
+			if errors.Is(err, model.ErrNotPoolMember) {
+				s.writeErrorResponse(w, http.StatusBadRequest, err)
+				return
+			}
+
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		s.broker.Publish(pool.Token(), PoolEvent{Type: EventPoolUpdated})
+		s.writeJSONResponse(w, http.StatusOK, response{UserID: member.ID, Role: data.Role})
+	}
+}
+
+// getPoolTokenTransferEndpoint returns the pending transfer of ownership. Only the owner and the nominee may see it.
+func (s *Server) getPoolTokenTransferEndpoint() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pool, ok := poolFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+		user, ok := userFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		transfer, err := pool.PendingTransfer(r.Context())
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		if user.ID != pool.UserID() && (transfer == nil || !transfer.IsNominee(user.ID)) {
+			s.writeErrorResponse(w, http.StatusForbidden, nil)
+			return
+		}
+
+		if transfer == nil {
+			s.writeErrorResponse(w, http.StatusNotFound, model.ErrTransferNotFound)
+			return
+		}
+
+		s.writeJSONResponse(w, http.StatusOK, transfer)
+	}
+}
+
+// postPoolTokenTransferEndpoint will nominate a member of the pool to take over its ownership. The transfer happens
+// when they accept it.
+func (s *Server) postPoolTokenTransferEndpoint() http.HandlerFunc {
+	type payload struct {
+		UserID int64 `json:"userId"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		pool, ok := poolFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+		user, ok := userFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		var data payload
+		if ok := s.parseJSONPayload(w, r, &data); !ok {
+			return
+		}
+
+		nominee, ok := s.loadTransferNominee(w, r, data.UserID)
+		if !ok {
+			return
+		}
+
+		transfer, err := pool.NominateOwner(r.Context(), nominee.ID, user.ID)
+		if err != nil {
+			if errors.Is(err, model.ErrNotPoolMember) || errors.Is(err, model.ErrAlreadyOwner) {
+				s.writeErrorResponse(w, http.StatusBadRequest, err)
+				return
+			}
+
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"pool":    pool.ID(),
+			"nominee": nominee.ID,
+		}).Info("nominated new pool owner")
+		s.writeJSONResponse(w, http.StatusCreated, transfer)
+	}
+}
+
+// loadTransferNominee loads the user that ownership of a pool is being transferred to. Only users with an account
+// may own a pool.
+func (s *Server) loadTransferNominee(w http.ResponseWriter, r *http.Request, userID int64) (*model.User, bool) {
+	nominee, err := s.model.GetUserByID(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.writeErrorResponse(w, http.StatusNotFound, nil)
+			return nil, false
+		}
+
+		s.writeErrorResponse(w, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	if !nominee.HasPermission(model.PermissionCreatePool) {
+		s.writeErrorResponse(w, http.StatusBadRequest, errors.New("only users with an account can own a pool"))
+		return nil, false
+	}
+
+	return nominee, true
+}
+
+// deletePoolTokenTransferEndpoint will withdraw the pending transfer of ownership if the user is the owner, or
+// decline it if they are the nominee
+func (s *Server) deletePoolTokenTransferEndpoint() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pool, ok := poolFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+		user, ok := userFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		transfer, err := pool.PendingTransfer(r.Context())
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		var status model.PoolTransferStatus
+		switch {
+		case user.ID == pool.UserID():
+			status = model.PoolTransferStatusCancelled
+		case transfer != nil && transfer.IsNominee(user.ID):
+			status = model.PoolTransferStatusDeclined
+		default:
+			s.writeErrorResponse(w, http.StatusForbidden, nil)
+			return
+		}
+
+		if err := pool.ResolvePendingTransfer(r.Context(), status); err != nil {
+			if errors.Is(err, model.ErrTransferNotFound) {
+				s.writeErrorResponse(w, http.StatusNotFound, err)
+				return
+			}
+
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// postPoolTokenTransferAcceptEndpoint will make the nominee the owner of the pool. The previous owner becomes a
+// manager.
+func (s *Server) postPoolTokenTransferAcceptEndpoint() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pool, ok := poolFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+		user, ok := userFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		previousOwner := pool.UserID()
+		if err := pool.AcceptTransfer(r.Context(), user.ID); err != nil {
+			if errors.Is(err, model.ErrTransferNotFound) {
+				s.writeErrorResponse(w, http.StatusNotFound, err)
+				return
+			}
+
+			if errors.Is(err, model.ErrNotPoolMember) {
+				s.writeErrorResponse(w, http.StatusBadRequest, err)
+				return
+			}
+
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"pool":          pool.ID(),
+			"previousOwner": previousOwner,
+			"owner":         user.ID,
+		}).Info("transferred pool ownership")
+		s.broker.Publish(pool.Token(), PoolEvent{Type: EventPoolUpdated})
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// getPoolTokenTransfersEndpoint returns every transfer of the ownership of the pool, newest first
+func (s *Server) getPoolTokenTransfersEndpoint() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pool, ok := poolFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		transfers, err := pool.Transfers(r.Context())
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		s.writeJSONResponse(w, http.StatusOK, transfers)
+	}
+}
+
+func (s *Server) getPoolTokenMembersEndpoint() http.HandlerFunc {
+	const defaultPerPage = 25
+	const maxPerPage = 100
+
+	type response struct {
+		Members []*model.PoolMember `json:"members"`
+		Total   int64               `json:"total"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		pool, ok := poolFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		offset, _ := strconv.ParseInt(r.FormValue("offset"), 10, 64)
+		if offset < 0 {
+			offset = 0
+		}
+
+		limit, _ := strconv.Atoi(r.FormValue("limit"))
+		if limit <= 0 {
+			limit = defaultPerPage
+		}
+
+		if limit > maxPerPage {
+			s.writeErrorResponse(w, http.StatusBadRequest, fmt.Errorf("limit cannot exceed %d", maxPerPage))
+			return
+		}
+
+		members, err := pool.Members(r.Context(), offset, limit)
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		total, err := pool.MembersCount(r.Context())
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		s.writeJSONResponse(w, http.StatusOK, response{
+			Members: members,
+			Total:   total,
+		})
+	}
+}
+
+// deletePoolTokenMembersUserIDEndpoint will remove a member from the pool, and ban them from rejoining if ban=true.
+// Only the owner may remove another manager.
+func (s *Server) deletePoolTokenMembersUserIDEndpoint() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pool, ok := poolFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+		user, ok := userFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		userID, err := strconv.ParseInt(mux.Vars(r)["user_id"], 10, 64)
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if userID == pool.UserID() {
+			s.writeErrorResponse(w, http.StatusBadRequest, errors.New("the owner of the pool cannot be removed"))
+			return
+		}
+
+		member, err := s.model.GetUserByID(r.Context(), userID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				s.writeErrorResponse(w, http.StatusNotFound, nil)
+				return
+			}
+
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		if user.ID != pool.UserID() {
+			memberRole, err := member.PoolRole(r.Context(), pool)
+			if err != nil {
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				return
+			}
+
+			if memberRole.Can(model.ActionManagePool) {
+				s.writeErrorResponse(w, http.StatusForbidden, errors.New("only the owner of the pool can remove a manager"))
+				return
+			}
+		}
+
+		if err := pool.RemoveMember(r.Context(), member.ID, r.FormValue("ban") == "true", user.ID); err != nil {
+			if errors.Is(err, model.ErrNotPoolMember) {
+				s.writeErrorResponse(w, http.StatusNotFound, err)
+				return
+			}
+
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		s.broker.Publish(pool.Token(), PoolEvent{Type: EventPoolUpdated})
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) getPoolTokenBansEndpoint() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pool, ok := poolFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		bans, err := pool.Bans(r.Context())
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		s.writeJSONResponse(w, http.StatusOK, bans)
+	}
+}
+
+func (s *Server) deletePoolTokenBansUserIDEndpoint() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pool, ok := poolFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		userID, err := strconv.ParseInt(mux.Vars(r)["user_id"], 10, 64)
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := pool.Unban(r.Context(), userID); err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
 }
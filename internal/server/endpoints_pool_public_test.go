@@ -18,6 +18,9 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package server
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -46,6 +49,7 @@ func setupTestServerWithMock(t *testing.T) (*Server, sqlmock.Sqlmock) {
 
 	// Register the public squares endpoint
 	s.Router.Path("/pool/{token:[A-Za-z0-9_-]+}/squares/public").Methods(http.MethodGet).Handler(s.getPoolTokenSquaresPublicEndpoint())
+	s.Router.Path("/pool/{token:[A-Za-z0-9_-]+}/grid/{id:[0-9]+}/draw-proof").Methods(http.MethodGet).Handler(s.getPoolTokenGridIDDrawProofEndpoint())
 
 	return s, mock
 }
@@ -60,7 +64,7 @@ var testPoolColumns = []string{
 // squaresColumns matches the columns returned by Pool.Squares() query
 var testSquaresColumns = []string{
 	"id", "square_id", "parent_id", "user_id", "state", "claimant",
-	"modified", "parent_square_id", "child_square_ids",
+	"modified", "parent_square_id", "child_square_ids", "reserved_until",
 }
 
 func TestGetPoolTokenSquaresPublicEndpoint_PoolNotFound(t *testing.T) {
@@ -114,6 +118,11 @@ func TestGetPoolTokenSquaresPublicEndpoint_NoPasswordRequired(t *testing.T) {
 		WithArgs(1). // pool_id
 		WillReturnRows(squaresRows)
 
+	// Mock shares lookup - no shared squares
+	mock.ExpectQuery("SELECT .+ FROM pool_square_shares").
+		WithArgs(1). // pool_id
+		WillReturnRows(sqlmock.NewRows(shareColumns()))
+
 	req := httptest.NewRequest(http.MethodGet, "/pool/test-token/squares/public", nil)
 	rec := httptest.NewRecorder()
 
@@ -248,11 +257,17 @@ func TestGetPoolTokenSquaresPublicEndpoint_PasswordRequired_CorrectPassword(t *t
 		now,         // modified
 		nil,         // parent_square_id
 		nil,         // child_square_ids
+		nil,         // reserved_until
 	)
 	mock.ExpectQuery("SELECT .+ FROM pool_squares").
 		WithArgs(1). // pool_id
 		WillReturnRows(squaresRows)
 
+	// Mock shares lookup - no shared squares
+	mock.ExpectQuery("SELECT .+ FROM pool_square_shares").
+		WithArgs(1). // pool_id
+		WillReturnRows(sqlmock.NewRows(shareColumns()))
+
 	req := httptest.NewRequest(http.MethodGet, "/pool/test-token/squares/public", nil)
 	req.SetBasicAuth("user", "correct-password")
 	rec := httptest.NewRecorder()
@@ -302,6 +317,11 @@ func TestGetPoolTokenSquaresPublicEndpoint_LockedWithOpenAccess(t *testing.T) {
 		WithArgs(1). // pool_id
 		WillReturnRows(squaresRows)
 
+	// Mock shares lookup - no shared squares
+	mock.ExpectQuery("SELECT .+ FROM pool_square_shares").
+		WithArgs(1). // pool_id
+		WillReturnRows(sqlmock.NewRows(shareColumns()))
+
 	req := httptest.NewRequest(http.MethodGet, "/pool/test-token/squares/public", nil)
 	// Note: no authentication provided
 	rec := httptest.NewRecorder()
@@ -367,3 +387,109 @@ func TestPoolIsLockedBehavior(t *testing.T) {
 	pool.SetLocks(time.Now().Add(-time.Hour))
 	g.Expect(pool.IsLocked()).Should(gomega.BeTrue())
 }
+
+func TestGetPoolTokenGridIDDrawProofEndpoint_BeforeDrawHidesSeed(t *testing.T) {
+	g := gomega.NewWithT(t)
+	s, mock := setupTestServerWithMock(t)
+
+	now := time.Now()
+	seed := make([]byte, model.DrawSeedLength)
+
+	mock.ExpectQuery("SELECT .+ FROM pools WHERE token = \\$1").
+		WithArgs("test-token").
+		WillReturnRows(sqlmock.NewRows(testPoolColumns).
+			AddRow(1, "test-token", 100, "Test Pool", "std100", "standard", "hash", false, false, nil, now, now, 1, false))
+	mock.ExpectQuery("SELECT .+ FROM grids WHERE id = \\$1 AND pool_id = \\$2").
+		WithArgs(int64(7), int64(1)).
+		WillReturnRows(sqlmock.NewRows(gridColumns()).
+			AddRow(int64(7), int64(1), 0, nil, "Home Team", nil, "Away Team", nil, now, false, "active", now, now, false, nil, nil, "include"))
+	// the commitment was made with the grid, so reading the proof does not create one
+	mock.ExpectQuery("SELECT seed, drawn, created, next_seed FROM grid_draw_commitments WHERE grid_id = \\$1").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"seed", "drawn", "created", "next_seed"}).AddRow(seed, nil, now, nil))
+	mock.ExpectQuery("SELECT entropy FROM grid_draw_entropy WHERE grid_id = \\$1").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"entropy"}).AddRow("lucky 7"))
+
+	req := httptest.NewRequest(http.MethodGet, "/pool/test-token/grid/7/draw-proof", nil)
+	rec := httptest.NewRecorder()
+
+	s.Router.ServeHTTP(rec, req)
+
+	g.Expect(rec.Code).Should(gomega.Equal(http.StatusOK))
+
+	var result model.DrawProof
+	g.Expect(json.Unmarshal(rec.Body.Bytes(), &result)).Should(gomega.Succeed())
+
+	sum := sha256.Sum256(seed)
+	g.Expect(result.Commitment).Should(gomega.Equal(hex.EncodeToString(sum[:])))
+	g.Expect(result.Seed).Should(gomega.BeEmpty())
+	g.Expect(result.DrawnAt).Should(gomega.BeNil())
+	g.Expect(result.Entropy).Should(gomega.Equal([]string{"lucky 7"}))
+	g.Expect(result.NumberSets).Should(gomega.BeEmpty())
+	g.Expect(result.Verified).Should(gomega.BeFalse())
+	g.Expect(mock.ExpectationsWereMet()).Should(gomega.Succeed())
+}
+
+func TestGetPoolTokenGridIDDrawProofEndpoint_RevealsSeedAfterDraw(t *testing.T) {
+	g := gomega.NewWithT(t)
+	s, mock := setupTestServerWithMock(t)
+
+	now := time.Now()
+	seed := make([]byte, model.DrawSeedLength)
+	nextSeed := bytes.Repeat([]byte{0x01}, model.DrawSeedLength)
+
+	mock.ExpectQuery("SELECT .+ FROM pools WHERE token = \\$1").
+		WithArgs("test-token").
+		WillReturnRows(sqlmock.NewRows(testPoolColumns).
+			AddRow(1, "test-token", 100, "Test Pool", "std100", "standard", "hash", false, false, now, now, now, 1, false))
+	// the numbers derived from an all-zero seed without entropy
+	mock.ExpectQuery("SELECT .+ FROM grids WHERE id = \\$1 AND pool_id = \\$2").
+		WithArgs(int64(7), int64(1)).
+		WillReturnRows(sqlmock.NewRows(gridColumns()).
+			AddRow(int64(7), int64(1), 0, nil, "Home Team", "{6,0,1,4,9,3,2,8,7,5}", "Away Team", "{0,8,4,9,1,2,6,3,7,5}", now, false, "active", now, now, false, nil, nil, "include"))
+	mock.ExpectQuery("SELECT seed, drawn, created, next_seed FROM grid_draw_commitments WHERE grid_id = \\$1").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"seed", "drawn", "created", "next_seed"}).AddRow(seed, now, now, nextSeed))
+	mock.ExpectQuery("SELECT entropy FROM grid_draw_entropy WHERE grid_id = \\$1").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"entropy"}))
+
+	req := httptest.NewRequest(http.MethodGet, "/pool/test-token/grid/7/draw-proof", nil)
+	rec := httptest.NewRecorder()
+
+	s.Router.ServeHTTP(rec, req)
+
+	g.Expect(rec.Code).Should(gomega.Equal(http.StatusOK))
+
+	var result model.DrawProof
+	g.Expect(json.Unmarshal(rec.Body.Bytes(), &result)).Should(gomega.Succeed())
+	g.Expect(result.Seed).Should(gomega.Equal(hex.EncodeToString(seed)))
+	nextSum := sha256.Sum256(nextSeed)
+	g.Expect(result.RedrawCommitment).Should(gomega.Equal(hex.EncodeToString(nextSum[:])))
+	g.Expect(result.DrawnAt).ShouldNot(gomega.BeNil())
+	g.Expect(result.NumberSets).Should(gomega.HaveKey(model.NumberSetTypeAll))
+	g.Expect(result.NumberSets[model.NumberSetTypeAll].HomeNumbers).Should(gomega.Equal([]int{6, 0, 1, 4, 9, 3, 2, 8, 7, 5}))
+	g.Expect(result.Verified).Should(gomega.BeTrue())
+	g.Expect(mock.ExpectationsWereMet()).Should(gomega.Succeed())
+}
+
+func TestGetPoolTokenGridIDDrawProofEndpoint_PasswordRequired(t *testing.T) {
+	g := gomega.NewWithT(t)
+	s, mock := setupTestServerWithMock(t)
+
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT .+ FROM pools WHERE token = \\$1").
+		WithArgs("test-token").
+		WillReturnRows(sqlmock.NewRows(testPoolColumns).
+			AddRow(1, "test-token", 100, "Test Pool", "std100", "standard", "hash", true, false, nil, now, now, 1, false))
+
+	req := httptest.NewRequest(http.MethodGet, "/pool/test-token/grid/7/draw-proof", nil)
+	rec := httptest.NewRecorder()
+
+	s.Router.ServeHTTP(rec, req)
+
+	g.Expect(rec.Code).Should(gomega.Equal(http.StatusUnauthorized))
+	g.Expect(mock.ExpectationsWereMet()).Should(gomega.Succeed())
+}
@@ -0,0 +1,332 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/sqmgr/sqmgr-api/internal/validator"
+	"github.com/sqmgr/sqmgr-api/pkg/model"
+)
+
+type seriesResponse struct {
+	*model.PoolSeriesJSON
+	Entries []*model.PoolSeriesEntry `json:"entries"`
+	IsOwner bool                     `json:"isOwner"`
+}
+
+// seriesHandler will load the series and ensure the user owns it or belongs to one of its pools
+func (s *Server) seriesHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		series, err := s.model.PoolSeriesByToken(r.Context(), mux.Vars(r)["token"])
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				s.writeErrorResponse(w, http.StatusNotFound, nil)
+				return
+			}
+
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		user, ok := userFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		if !user.IsSiteAdmin {
+			isMember, err := series.HasMember(r.Context(), user.ID)
+			if err != nil {
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				return
+			}
+
+			if !isMember {
+				s.writeErrorResponse(w, http.StatusForbidden, nil)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ctxSeriesKey, series)))
+	})
+}
+
+// seriesOwnerHandler will ensure the user owns the series
+func (s *Server) seriesOwnerHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		series, ok := seriesFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+		user, ok := userFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		if series.UserID() != user.ID {
+			s.writeErrorResponse(w, http.StatusForbidden, nil)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) postSeriesEndpoint() http.HandlerFunc {
+	type payload struct {
+		Name string `json:"name"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := userFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+		if !user.HasPermission(model.PermissionCreatePool) {
+			s.writeErrorResponse(w, http.StatusForbidden, nil)
+			return
+		}
+
+		var data payload
+		if ok := s.parseJSONPayload(w, r, &data); !ok {
+			return
+		}
+
+		v := validator.New()
+		name := v.Printable("Name", data.Name)
+		name = v.MaxLength("Name", name, model.NameMaxLength)
+
+		if !v.OK() {
+			s.writeJSONResponse(w, http.StatusBadRequest, ErrorResponse{
+				Status:           statusError,
+				Error:            validationErrorMessage,
+				ValidationErrors: v.Errors,
+			})
+			return
+		}
+
+		series, err := s.model.NewPoolSeries(r.Context(), user.ID, name)
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		s.writeJSONResponse(w, http.StatusCreated, seriesResponse{
+			PoolSeriesJSON: series.JSON(),
+			Entries:        make([]*model.PoolSeriesEntry, 0),
+			IsOwner:        true,
+		})
+	}
+}
+
+func (s *Server) getSeriesTokenEndpoint() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		series, ok := seriesFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+		user, ok := userFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		entries, err := series.Entries(r.Context())
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		s.writeJSONResponse(w, http.StatusOK, seriesResponse{
+			PoolSeriesJSON: series.JSON(),
+			Entries:        entries,
+			IsOwner:        series.UserID() == user.ID,
+		})
+	}
+}
+
+func (s *Server) postSeriesTokenEndpoint() http.HandlerFunc {
+	type payload struct {
+		Action    string `json:"action"`
+		Name      string `json:"name"`
+		PoolToken string `json:"poolToken"`
+		GridID    *int64 `json:"gridId"`
+		EntryID   int64  `json:"entryId"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		series, ok := seriesFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+		user, ok := userFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		var data payload
+		if ok := s.parseJSONPayload(w, r, &data); !ok {
+			return
+		}
+
+		v := validator.New()
+		switch data.Action {
+		case "rename":
+			name := v.Printable("Name", data.Name)
+			name = v.MaxLength("Name", name, model.NameMaxLength)
+			if !v.OK() {
+				break
+			}
+
+			series.SetName(name)
+			if err := series.Save(r.Context()); err != nil {
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				return
+			}
+		case "addPool":
+			pool, err := s.model.PoolByToken(r.Context(), data.PoolToken)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				return
+			}
+
+			// only pools the user manages may be added, so that a series cannot be used to see into other pools
+			if pool != nil {
+				role, err := user.PoolRole(r.Context(), pool)
+				if err != nil {
+					s.writeErrorResponse(w, http.StatusInternalServerError, err)
+					return
+				}
+
+				if !role.Can(model.ActionManagePool) {
+					pool = nil
+				}
+			}
+
+			if pool == nil {
+				v.AddError("poolToken", "you must manage the pool to add it to a series")
+				break
+			}
+
+			var grid *model.Grid
+			if data.GridID != nil {
+				grid, err = pool.GridByID(r.Context(), *data.GridID)
+				if err != nil {
+					if !errors.Is(err, sql.ErrNoRows) {
+						s.writeErrorResponse(w, http.StatusInternalServerError, err)
+						return
+					}
+
+					v.AddError("gridId", "the grid does not belong to the pool")
+					break
+				}
+			}
+
+			if _, err := series.AddEntry(r.Context(), pool, grid); err != nil {
+				if errors.Is(err, model.ErrSeriesEntryExists) || errors.Is(err, model.ErrSeriesFull) {
+					v.AddError("poolToken", "%s", err.Error())
+					break
+				}
+
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				return
+			}
+		case "removeEntry":
+			if err := series.RemoveEntry(r.Context(), data.EntryID); err != nil {
+				if errors.Is(err, model.ErrSeriesEntryNotFound) {
+					s.writeErrorResponse(w, http.StatusNotFound, err)
+					return
+				}
+
+				s.writeErrorResponse(w, http.StatusInternalServerError, err)
+				return
+			}
+		default:
+			s.writeErrorResponse(w, http.StatusBadRequest, fmt.Errorf("unsupported action %s", data.Action))
+			return
+		}
+
+		if !v.OK() {
+			s.writeJSONResponse(w, http.StatusBadRequest, ErrorResponse{
+				Status:           statusError,
+				Error:            validationErrorMessage,
+				ValidationErrors: v.Errors,
+			})
+			return
+		}
+
+		entries, err := series.Entries(r.Context())
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		s.writeJSONResponse(w, http.StatusOK, seriesResponse{
+			PoolSeriesJSON: series.JSON(),
+			Entries:        entries,
+			IsOwner:        true,
+		})
+	}
+}
+
+func (s *Server) deleteSeriesTokenEndpoint() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		series, ok := seriesFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		if err := series.Delete(r.Context()); err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) getSeriesTokenStandingsEndpoint() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		series, ok := seriesFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		standings, err := series.Standings(r.Context())
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		s.writeJSONResponse(w, http.StatusOK, standings)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+	"github.com/sqmgr/sqmgr-api/pkg/model"
+)
+
+func seriesColumns() []string {
+	return []string{"id", "token", "user_id", "name", "created", "modified"}
+}
+
+func setupTestServerForSeries(t *testing.T) (*Server, sqlmock.Sqlmock, *model.Model) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+
+	m := model.New(db)
+	s := &Server{
+		Router: mux.NewRouter(),
+		model:  m,
+		broker: NewPoolBroker(),
+	}
+
+	seriesRouter := s.Router.NewRoute().Subrouter()
+	seriesRouter.Use(s.seriesHandler)
+	seriesRouter.Path("/series/{token}/standings").Methods(http.MethodGet).Handler(s.getSeriesTokenStandingsEndpoint())
+	ownerRouter := seriesRouter.NewRoute().Subrouter()
+	ownerRouter.Use(s.seriesOwnerHandler)
+	ownerRouter.Path("/series/{token}").Methods(http.MethodPost).Handler(s.postSeriesTokenEndpoint())
+
+	return s, mock, m
+}
+
+func TestSeriesHandler_NonMemberForbidden(t *testing.T) {
+	g := gomega.NewWithT(t)
+	s, mock, m := setupTestServerForSeries(t)
+
+	user := &model.User{Model: m, ID: 200, Store: model.UserStoreAuth0}
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT .+ FROM pool_series WHERE token = \\$1").
+		WithArgs("test-series").
+		WillReturnRows(sqlmock.NewRows(seriesColumns()).AddRow(1, "test-series", int64(100), "2026 Season", now, now))
+	mock.ExpectQuery("SELECT EXISTS").
+		WithArgs(int64(1), int64(200)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	req := httptest.NewRequest(http.MethodGet, "/series/test-series/standings", nil)
+	rec := httptest.NewRecorder()
+
+	s.Router.ServeHTTP(rec, req.WithContext(context.WithValue(req.Context(), ctxUserKey, user)))
+
+	g.Expect(rec.Code).Should(gomega.Equal(http.StatusForbidden))
+	g.Expect(mock.ExpectationsWereMet()).Should(gomega.Succeed())
+}
+
+func TestGetSeriesTokenStandingsEndpoint_Empty(t *testing.T) {
+	g := gomega.NewWithT(t)
+	s, mock, m := setupTestServerForSeries(t)
+
+	user := &model.User{Model: m, ID: 100, Store: model.UserStoreAuth0}
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT .+ FROM pool_series WHERE token = \\$1").
+		WithArgs("test-series").
+		WillReturnRows(sqlmock.NewRows(seriesColumns()).AddRow(1, "test-series", int64(100), "2026 Season", now, now))
+	mock.ExpectQuery("SELECT .+ FROM pool_series_entries").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "pool_id", "token", "name", "grid_id", "created"}))
+
+	req := httptest.NewRequest(http.MethodGet, "/series/test-series/standings", nil)
+	rec := httptest.NewRecorder()
+
+	s.Router.ServeHTTP(rec, req.WithContext(context.WithValue(req.Context(), ctxUserKey, user)))
+
+	g.Expect(rec.Code).Should(gomega.Equal(http.StatusOK))
+
+	var result model.SeriesStandings
+	g.Expect(json.Unmarshal(rec.Body.Bytes(), &result)).Should(gomega.Succeed())
+	g.Expect(result.Standings).Should(gomega.BeEmpty())
+	g.Expect(result.Members).Should(gomega.BeEmpty())
+	g.Expect(mock.ExpectationsWereMet()).Should(gomega.Succeed())
+}
+
+func TestPostSeriesTokenEndpoint_AddPoolRequiresManager(t *testing.T) {
+	g := gomega.NewWithT(t)
+	s, mock, m := setupTestServerForSeries(t)
+
+	user := &model.User{Model: m, ID: 100, Store: model.UserStoreAuth0}
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT .+ FROM pool_series WHERE token = \\$1").
+		WithArgs("test-series").
+		WillReturnRows(sqlmock.NewRows(seriesColumns()).AddRow(1, "test-series", int64(100), "2026 Season", now, now))
+	mock.ExpectQuery("SELECT .+ FROM pools WHERE token = \\$1").
+		WithArgs("other-pool").
+		WillReturnRows(sqlmock.NewRows(poolColumns()).
+			AddRow(2, "other-pool", int64(300), "Other Pool", "std100", "standard", "hash", true, false, nil, now, now, 0, false))
+	mock.ExpectQuery("SELECT role FROM pools_users WHERE pool_id = \\$1 AND user_id = \\$2").
+		WithArgs(int64(2), int64(100)).
+		WillReturnError(sql.ErrNoRows)
+
+	body := `{"action": "addPool", "poolToken": "other-pool"}`
+	req := httptest.NewRequest(http.MethodPost, "/series/test-series", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	s.Router.ServeHTTP(rec, req.WithContext(context.WithValue(req.Context(), ctxUserKey, user)))
+
+	g.Expect(rec.Code).Should(gomega.Equal(http.StatusBadRequest))
+
+	var result ErrorResponse
+	g.Expect(json.Unmarshal(rec.Body.Bytes(), &result)).Should(gomega.Succeed())
+	g.Expect(result.ValidationErrors).Should(gomega.HaveKey("poolToken"))
+	g.Expect(mock.ExpectationsWereMet()).Should(gomega.Succeed())
+}
@@ -125,6 +125,51 @@ func (s *Server) getUserSelfStatsEndpoint() http.HandlerFunc {
 	}
 }
 
+func (s *Server) getUserSelfWinningsEndpoint() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := userFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		winnings, err := s.model.WinningsByUserID(r.Context(), user.ID)
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		s.writeJSONResponse(w, http.StatusOK, winnings)
+	}
+}
+
+func (s *Server) getUserSelfSeriesEndpoint() http.HandlerFunc {
+	type response struct {
+		Series []*model.PoolSeriesJSON `json:"series"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := userFromContext(r.Context())
+		if !ok {
+			s.writeErrorResponse(w, http.StatusInternalServerError, nil)
+			return
+		}
+
+		collection, err := s.model.PoolSeriesByUserID(r.Context(), user.ID)
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		series := make([]*model.PoolSeriesJSON, len(collection))
+		for i, item := range collection {
+			series[i] = item.JSON()
+		}
+
+		s.writeJSONResponse(w, http.StatusOK, response{Series: series})
+	}
+}
+
 func (s *Server) getUserIDPoolMembershipEndpoint() http.HandlerFunc {
 	const defaultPerPage = 10
 	const maxPerPage = 50
@@ -297,7 +342,8 @@ func (s *Server) postUserIDGuestJWT() http.HandlerFunc {
 		}
 
 		for _, pool := range pools {
-			if err := user.JoinPool(r.Context(), pool); err != nil {
+			// pools the user was banned from are left behind
+			if err := user.JoinPool(r.Context(), pool); err != nil && !errors.Is(err, model.ErrBannedFromPool) {
 				s.writeJSONResponse(w, http.StatusInternalServerError, err)
 				return
 			}
@@ -19,6 +19,7 @@ package server
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -419,3 +420,88 @@ func TestGetUserSelfStatsEndpoint_AllPoolsArchived(t *testing.T) {
 
 	g.Expect(mock.ExpectationsWereMet()).Should(gomega.Succeed())
 }
+
+func TestGetUserSelfWinningsEndpoint_IncludesOwnedPools(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	defer db.Close()
+
+	s := &Server{
+		Router: mux.NewRouter(),
+		model:  model.New(db),
+		broker: NewPoolBroker(),
+	}
+	s.Router.Path("/user/self/winnings").Methods(http.MethodGet).Handler(s.getUserSelfWinningsEndpoint())
+
+	user := &model.User{
+		ID:    100,
+		Store: model.UserStoreAuth0,
+	}
+
+	now := time.Now()
+	eventID := int64(401547417)
+
+	// the user owns the pool, so they have no pools_users row
+	mock.ExpectQuery("SELECT .+ FROM pools LEFT JOIN pools_users ON pools.id = pools_users.pool_id AND pools_users.user_id = \\$1 WHERE \\(pools.user_id = \\$1 OR pools_users.user_id IS NOT NULL\\)").
+		WithArgs(int64(100)).
+		WillReturnRows(sqlmock.NewRows(poolColumns()).
+			AddRow(1, "owned-pool", int64(100), "Owned Pool", "std100", "standard", "hash", true, false, nil, now, now, 0, false))
+
+	mock.ExpectQuery("SELECT .+ FROM pool_payout_settings WHERE pool_id = \\$1").
+		WithArgs(int64(1)).
+		WillReturnError(sql.ErrNoRows)
+
+	// the owner holds square 19, which wins a final of 28-21
+	mock.ExpectQuery("SELECT .+ FROM pool_squares ps").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(squareColumns()).
+			AddRow(int64(19), 19, nil, int64(100), "claimed", "Owner", now, nil, nil, nil))
+
+	mock.ExpectQuery("SELECT .+ FROM pool_square_shares INNER JOIN pool_squares").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(shareColumns()))
+
+	mock.ExpectQuery("SELECT .+ FROM grids WHERE pool_id = \\$1 AND state = 'active'").
+		WithArgs(int64(1), int64(0), model.MaxGridsPerPool).
+		WillReturnRows(sqlmock.NewRows(gridColumns()).
+			AddRow(1, int64(1), 0, nil, "Home Team", "{0,1,2,3,4,5,6,7,8,9}", "Away Team", "{0,1,2,3,4,5,6,7,8,9}", now, false, "active", now, now, false, eventID, nil, "include"))
+
+	mock.ExpectQuery("SELECT .+ FROM sports_events WHERE id = \\$1").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows(sportsEventColumns()).
+			AddRow(eventID, "401547417", "nfl", "Chiefs vs Bills", "1", "2", now, 2025, 10, false, "Stadium",
+				"final", "Final", 4, "0:00", 28, 21,
+				7, 7, 7, 7, nil,
+				7, 7, 7, 0, nil,
+				nil, nil,
+				now, now, now))
+	mock.ExpectQuery("SELECT .+ FROM sports_teams WHERE id = \\$1 AND league = \\$2").
+		WithArgs("1", model.SportsLeagueNFL).
+		WillReturnRows(sqlmock.NewRows(sportsTeamColumns()).
+			AddRow("1", "nfl", "Chiefs", "Kansas City Chiefs", "KC", "AFC", "West", "Kansas City", "E31837", "FFB612", now, now))
+	mock.ExpectQuery("SELECT .+ FROM sports_teams WHERE id = \\$1 AND league = \\$2").
+		WithArgs("2", model.SportsLeagueNFL).
+		WillReturnRows(sqlmock.NewRows(sportsTeamColumns()).
+			AddRow("2", "nfl", "Bills", "Buffalo Bills", "BUF", "AFC", "East", "Buffalo", "00338D", "C60C30", now, now))
+
+	req := httptest.NewRequest(http.MethodGet, "/user/self/winnings", nil)
+	rec := httptest.NewRecorder()
+
+	ctx := context.WithValue(req.Context(), ctxUserKey, user)
+
+	s.Router.ServeHTTP(rec, req.WithContext(ctx))
+
+	g.Expect(rec.Code).Should(gomega.Equal(http.StatusOK))
+
+	var result model.UserWinnings
+	g.Expect(json.Unmarshal(rec.Body.Bytes(), &result)).Should(gomega.Succeed())
+	g.Expect(result.Winnings).ShouldNot(gomega.BeEmpty())
+	for _, winning := range result.Winnings {
+		g.Expect(winning.PoolToken).Should(gomega.Equal("owned-pool"))
+		g.Expect(winning.SquareID).Should(gomega.Equal(19))
+	}
+
+	g.Expect(mock.ExpectationsWereMet()).Should(gomega.Succeed())
+}
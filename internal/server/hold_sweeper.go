@@ -0,0 +1,68 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sqmgr/sqmgr-api/pkg/model"
+)
+
+const holdSweepInterval = time.Minute
+
+// HoldSweeper periodically releases reserved squares whose hold expired before they were paid for, and notifies
+// the pools they belong to.
+type HoldSweeper struct {
+	*periodicJob
+	model  *model.Model
+	broker *PoolBroker
+}
+
+// NewHoldSweeper creates a new HoldSweeper that runs every interval
+func NewHoldSweeper(m *model.Model, broker *PoolBroker, interval time.Duration) *HoldSweeper {
+	h := &HoldSweeper{
+		model:  m,
+		broker: broker,
+	}
+	h.periodicJob = newPeriodicJob(interval, h.sweep)
+	return h
+}
+
+func (h *HoldSweeper) sweep(ctx context.Context) {
+	released, err := h.model.ReleaseExpiredHolds(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("hold sweeper: could not release expired holds")
+		return
+	}
+
+	squaresByToken := make(map[string]int)
+	for _, square := range released {
+		squaresByToken[square.PoolToken]++
+	}
+
+	for token, squares := range squaresByToken {
+		h.broker.Publish(token, PoolEvent{Type: EventSquareUpdated})
+
+		logrus.WithFields(logrus.Fields{
+			"pool":    token,
+			"squares": squares,
+		}).Info("hold sweeper: released expired holds")
+	}
+}
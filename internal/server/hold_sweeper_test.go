@@ -0,0 +1,75 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/onsi/gomega"
+	"github.com/sqmgr/sqmgr-api/pkg/model"
+)
+
+func TestHoldSweeperSweep_PublishesOncePerPool(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+	mock.ExpectQuery("SELECT pool_token, released_square_id FROM release_expired_holds\\(\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"pool_token", "released_square_id"}).
+			AddRow("pool-abc", 4).
+			AddRow("pool-abc", 17).
+			AddRow("pool-def", 2))
+
+	broker := NewPoolBroker()
+	abc := broker.Subscribe("pool-abc")
+	defer broker.Unsubscribe("pool-abc", abc)
+	def := broker.Subscribe("pool-def")
+	defer broker.Unsubscribe("pool-def", def)
+
+	sweeper := NewHoldSweeper(model.New(db), broker, time.Minute)
+	sweeper.sweep(context.Background())
+
+	g.Expect(abc).Should(gomega.Receive(gomega.Equal(PoolEvent{Type: EventSquareUpdated})))
+	g.Expect(abc).ShouldNot(gomega.Receive())
+	g.Expect(def).Should(gomega.Receive(gomega.Equal(PoolEvent{Type: EventSquareUpdated})))
+	g.Expect(mock.ExpectationsWereMet()).Should(gomega.Succeed())
+}
+
+func TestHoldSweeperSweep_ErrorDoesNotPublish(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+	mock.ExpectQuery("SELECT pool_token, released_square_id FROM release_expired_holds\\(\\)").
+		WillReturnError(context.DeadlineExceeded)
+
+	broker := NewPoolBroker()
+	ch := broker.Subscribe("pool-abc")
+	defer broker.Unsubscribe("pool-abc", ch)
+
+	sweeper := NewHoldSweeper(model.New(db), broker, time.Minute)
+	sweeper.sweep(context.Background())
+
+	g.Expect(ch).ShouldNot(gomega.Receive())
+	g.Expect(mock.ExpectationsWereMet()).Should(gomega.Succeed())
+}
@@ -0,0 +1,71 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// periodicJob calls fn every interval from a background goroutine until it is closed. The background schedulers
+// embed it and only supply the function that does the work.
+type periodicJob struct {
+	interval time.Duration
+	fn       func(ctx context.Context)
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+func newPeriodicJob(interval time.Duration, fn func(ctx context.Context)) *periodicJob {
+	return &periodicJob{
+		interval: interval,
+		fn:       fn,
+	}
+}
+
+// Start launches the background goroutine.
+func (p *periodicJob) Start(ctx context.Context) {
+	ctx, p.cancel = context.WithCancel(ctx)
+	p.wg.Add(1)
+	go p.run(ctx)
+}
+
+func (p *periodicJob) run(ctx context.Context) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.fn(ctx)
+		}
+	}
+}
+
+// Close stops the job and waits for the background goroutine to finish.
+func (p *periodicJob) Close() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.wg.Wait()
+}
@@ -0,0 +1,49 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func TestPeriodicJob(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	var calls int32
+	job := newPeriodicJob(time.Millisecond, func(ctx context.Context) {
+		atomic.AddInt32(&calls, 1)
+	})
+	job.Start(context.Background())
+
+	g.Eventually(func() int32 { return atomic.LoadInt32(&calls) }).Should(gomega.BeNumerically(">=", 2))
+
+	job.Close()
+	stopped := atomic.LoadInt32(&calls)
+	time.Sleep(10 * time.Millisecond)
+	g.Expect(atomic.LoadInt32(&calls)).Should(gomega.Equal(stopped))
+}
+
+func TestPeriodicJob_CloseWithoutStart(t *testing.T) {
+	job := newPeriodicJob(time.Minute, func(ctx context.Context) {})
+	job.Close()
+}
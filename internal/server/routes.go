@@ -24,6 +24,7 @@ import (
 	"github.com/rs/cors"
 	"github.com/sirupsen/logrus"
 	"github.com/sqmgr/sqmgr-api/internal/config"
+	"github.com/sqmgr/sqmgr-api/pkg/model"
 )
 
 var optionsHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
@@ -38,6 +39,7 @@ func (s *Server) setupRoutes() {
 	s.Router.Path("/").Methods(http.MethodGet).Handler(s.getHealthEndpoint())
 	s.Router.Path("/pool/configuration").Methods(http.MethodGet).Handler(s.getPoolConfiguration())
 	s.Router.Path("/pool/{token:[A-Za-z0-9_-]+}/squares/public").Methods(http.MethodGet).Handler(s.getPoolTokenSquaresPublicEndpoint())
+	s.Router.Path("/pool/{token:[A-Za-z0-9_-]+}/grid/{id:[0-9]+}/draw-proof").Methods(http.MethodGet).Handler(s.getPoolTokenGridIDDrawProofEndpoint())
 	s.Router.Path("/pool/{token:[A-Za-z0-9_-]+}/events").Methods(http.MethodGet).Handler(s.getPoolTokenEventsEndpoint())
 	s.Router.Path("/user/guest").Methods(http.MethodPost).Handler(s.postUserGuestEndpoint())
 
@@ -60,32 +62,82 @@ func (s *Server) setupRoutes() {
 	authRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/member").Methods(http.MethodPost).Handler(s.postPoolTokenMemberEndpoint())
 	authRouter.Path("/user/self").Methods(http.MethodGet).Handler(s.getUserSelfEndpoint())
 	authRouter.Path("/user/self/stats").Methods(http.MethodGet).Handler(s.getUserSelfStatsEndpoint())
+	authRouter.Path("/user/self/winnings").Methods(http.MethodGet).Handler(s.getUserSelfWinningsEndpoint())
+	authRouter.Path("/user/self/series").Methods(http.MethodGet).Handler(s.getUserSelfSeriesEndpoint())
+	authRouter.Path("/series").Methods(http.MethodPost).Handler(s.postSeriesEndpoint())
 
 	authPoolRouter := authRouter.NewRoute().Subrouter()
 	authPoolRouter.Use(s.poolHandler)
 	authPoolRouter.Path("/pool/{token:[A-Za-z0-9_-]+}").Methods(http.MethodGet).Handler(s.getPoolTokenEndpoint())
 	authPoolRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/grid").Methods(http.MethodGet).Handler(s.getPoolTokenGridEndpoint())
-	authPoolRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/grid/{id:[0-9]+}").Methods(http.MethodDelete).Handler(s.deletePoolTokenGridIDEndpoint())
 	authPoolRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/grid/{id:[0-9]+}").Methods(http.MethodGet).Handler(s.getPoolTokenGridIDEndpoint())
+	authPoolRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/payouts").Methods(http.MethodGet).Handler(s.getPoolTokenPayoutsEndpoint())
 	authPoolRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/square").Methods(http.MethodGet).Handler(s.getPoolTokenSquareEndpoint())
 	authPoolRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/square/{id:[0-9]+}").Methods(http.MethodGet).Handler(s.getPoolTokenSquareIDEndpoint())
 	authPoolRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/square/{id:[0-9]+}").Methods(http.MethodPost).Handler(s.postPoolTokenSquareIDEndpoint())
-
-	// Pool manager routes — require pool manager privileges
+	authPoolRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/square/{id:[0-9]+}/share").Methods(http.MethodPost).Handler(s.postPoolTokenSquareIDShareEndpoint())
+	authPoolRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/square/{id:[0-9]+}/share/{share_id:[0-9]+}").Methods(http.MethodPost).Handler(s.postPoolTokenSquareIDShareIDEndpoint())
+	authPoolRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/squares/quick-pick").Methods(http.MethodPost).Handler(s.postPoolTokenSquaresQuickPickEndpoint())
+	authPoolRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/draft").Methods(http.MethodGet).Handler(s.getPoolTokenDraftEndpoint())
+	authPoolRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/transfer").Methods(http.MethodGet).Handler(s.getPoolTokenTransferEndpoint())
+	authPoolRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/transfer").Methods(http.MethodDelete).Handler(s.deletePoolTokenTransferEndpoint())
+	authPoolRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/transfer/accept").Methods(http.MethodPost).Handler(s.postPoolTokenTransferAcceptEndpoint())
+
+	// Pool manager routes — require a role that may manage the pool
 	authPoolManagerRouter := authPoolRouter.NewRoute().Subrouter()
-	authPoolManagerRouter.Use(s.poolManagerHandler)
+	authPoolManagerRouter.Use(s.poolPermissionHandler(model.ActionManagePool))
 	authPoolManagerRouter.Path("/pool/{token:[A-Za-z0-9_-]+}").Methods(http.MethodPost).Handler(s.postPoolTokenEndpoint())
-	authPoolManagerRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/grid/{id:[0-9]+}").Methods(http.MethodPost).Handler(s.postPoolTokenGridIDEndpoint())
 	authPoolManagerRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/invitetoken").Methods(http.MethodGet).Handler(s.getPoolTokenInviteTokenEndpoint())
+	authPoolManagerRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/invites").Methods(http.MethodGet).Handler(s.getPoolTokenInvitesEndpoint())
+	authPoolManagerRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/invites").Methods(http.MethodPost).Handler(s.postPoolTokenInvitesEndpoint())
+	authPoolManagerRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/invites/{invite:[A-Za-z0-9_-]+}").Methods(http.MethodDelete).Handler(s.deletePoolTokenInvitesInviteEndpoint())
 	authPoolManagerRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/log").Methods(http.MethodGet).Handler(s.getPoolTokenLogEndpoint())
 	authPoolManagerRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/squares/bulk").Methods(http.MethodPost).Handler(s.postPoolTokenSquaresBulkEndpoint())
+	authPoolManagerRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/draft").Methods(http.MethodPost).Handler(s.postPoolTokenDraftEndpoint())
+	authPoolManagerRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/draft").Methods(http.MethodDelete).Handler(s.deletePoolTokenDraftEndpoint())
+	authPoolManagerRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/members").Methods(http.MethodGet).Handler(s.getPoolTokenMembersEndpoint())
+	authPoolManagerRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/members/{user_id:[0-9]+}").Methods(http.MethodDelete).Handler(s.deletePoolTokenMembersUserIDEndpoint())
+	authPoolManagerRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/bans").Methods(http.MethodGet).Handler(s.getPoolTokenBansEndpoint())
+	authPoolManagerRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/bans/{user_id:[0-9]+}").Methods(http.MethodDelete).Handler(s.deletePoolTokenBansUserIDEndpoint())
+
+	// Treasurer routes — require a role that may record payments
+	authPoolTreasurerRouter := authPoolRouter.NewRoute().Subrouter()
+	authPoolTreasurerRouter.Use(s.poolPermissionHandler(model.ActionRecordPayments))
+	authPoolTreasurerRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/balances").Methods(http.MethodGet).Handler(s.getPoolTokenBalancesEndpoint())
+
+	// Grid editor routes — require a role that may edit grids
+	authPoolGridEditorRouter := authPoolRouter.NewRoute().Subrouter()
+	authPoolGridEditorRouter.Use(s.poolPermissionHandler(model.ActionEditGrids))
+	authPoolGridEditorRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/grid/{id:[0-9]+}").Methods(http.MethodPost).Handler(s.postPoolTokenGridIDEndpoint())
+	authPoolGridEditorRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/grid/{id:[0-9]+}").Methods(http.MethodDelete).Handler(s.deletePoolTokenGridIDEndpoint())
+
+	// Pool owner routes — only the owner may change the roles of members or hand over the pool
+	authPoolOwnerRouter := authPoolRouter.NewRoute().Subrouter()
+	authPoolOwnerRouter.Use(s.poolPermissionHandler(model.ActionManageRoles))
+	authPoolOwnerRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/managers").Methods(http.MethodGet).Handler(s.getPoolTokenManagersEndpoint())
+	authPoolOwnerRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/managers/{user_id:[0-9]+}").Methods(http.MethodPost).Handler(s.postPoolTokenManagersUserIDEndpoint())
+	authPoolOwnerRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/managers/{user_id:[0-9]+}").Methods(http.MethodDelete).Handler(s.deletePoolTokenManagersUserIDEndpoint())
+	authPoolOwnerRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/members/{user_id:[0-9]+}/role").Methods(http.MethodPost).Handler(s.postPoolTokenMembersUserIDRoleEndpoint())
+	authPoolOwnerRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/transfer").Methods(http.MethodPost).Handler(s.postPoolTokenTransferEndpoint())
+	authPoolOwnerRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/transfers").Methods(http.MethodGet).Handler(s.getPoolTokenTransfersEndpoint())
 
 	authPoolGridRouter := authPoolRouter.NewRoute().Subrouter()
 	authPoolGridRouter.Use(s.poolGridHandler)
-	authPoolGridSquareManagerRouter := authPoolGridRouter.NewRoute().Subrouter()
-	authPoolGridSquareManagerRouter.Use(s.poolGridSquareManagerHandler)
-	authPoolGridSquareManagerRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/grid/{id:[0-9]+}/square/{square_id:[0-9]+}/annotation").Methods(http.MethodPost).Handler(s.postPoolTokenGridIDSquareSquareIDAnnotationEndpoint())
-	authPoolGridSquareManagerRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/grid/{id:[0-9]+}/square/{square_id:[0-9]+}/annotation").Methods(http.MethodDelete).Handler(s.deletePoolTokenGridIDSquareSquareIDAnnotationEndpoint())
+	authPoolGridRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/grid/{id:[0-9]+}/draws").Methods(http.MethodGet).Handler(s.getPoolTokenGridIDDrawsEndpoint())
+	authPoolGridRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/grid/{id:[0-9]+}/draw-entropy").Methods(http.MethodPost).Handler(s.postPoolTokenGridIDDrawEntropyEndpoint())
+	authPoolGridSquareEditorRouter := authPoolGridRouter.NewRoute().Subrouter()
+	authPoolGridSquareEditorRouter.Use(s.poolGridSquareEditorHandler)
+	authPoolGridSquareEditorRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/grid/{id:[0-9]+}/square/{square_id:[0-9]+}/annotation").Methods(http.MethodPost).Handler(s.postPoolTokenGridIDSquareSquareIDAnnotationEndpoint())
+	authPoolGridSquareEditorRouter.Path("/pool/{token:[A-Za-z0-9_-]+}/grid/{id:[0-9]+}/square/{square_id:[0-9]+}/annotation").Methods(http.MethodDelete).Handler(s.deletePoolTokenGridIDSquareSquareIDAnnotationEndpoint())
+
+	authSeriesRouter := authRouter.NewRoute().Subrouter()
+	authSeriesRouter.Use(s.seriesHandler)
+	authSeriesRouter.Path("/series/{token:[A-Za-z0-9_-]+}").Methods(http.MethodGet).Handler(s.getSeriesTokenEndpoint())
+	authSeriesRouter.Path("/series/{token:[A-Za-z0-9_-]+}/standings").Methods(http.MethodGet).Handler(s.getSeriesTokenStandingsEndpoint())
+	authSeriesOwnerRouter := authSeriesRouter.NewRoute().Subrouter()
+	authSeriesOwnerRouter.Use(s.seriesOwnerHandler)
+	authSeriesOwnerRouter.Path("/series/{token:[A-Za-z0-9_-]+}").Methods(http.MethodPost).Handler(s.postSeriesTokenEndpoint())
+	authSeriesOwnerRouter.Path("/series/{token:[A-Za-z0-9_-]+}").Methods(http.MethodDelete).Handler(s.deleteSeriesTokenEndpoint())
 
 	authUserRouter := authRouter.NewRoute().Subrouter()
 	authUserRouter.Use(s.userHandler)
@@ -100,6 +152,7 @@ func (s *Server) setupRoutes() {
 	adminRouter.Path("/admin/pools").Methods(http.MethodGet).Handler(s.getAdminPoolsEndpoint())
 	adminRouter.Path("/admin/users").Methods(http.MethodGet).Handler(s.getAdminUsersEndpoint())
 	adminRouter.Path("/admin/pool/{token:[A-Za-z0-9_-]+}/join").Methods(http.MethodPost).Handler(s.postAdminPoolJoinEndpoint())
+	adminRouter.Path("/admin/pool/{token:[A-Za-z0-9_-]+}/transfer").Methods(http.MethodPost).Handler(s.postAdminPoolTransferEndpoint())
 	adminRouter.Path("/admin/user/{id:[0-9]+}").Methods(http.MethodGet).Handler(s.getAdminUserEndpoint())
 	adminRouter.Path("/admin/user/{id:[0-9]+}/pools").Methods(http.MethodGet).Handler(s.getAdminUserPoolsEndpoint())
 	adminRouter.Path("/admin/events").Methods(http.MethodGet).Handler(s.getAdminEventsEndpoint())
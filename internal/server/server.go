@@ -42,6 +42,10 @@ type Server struct {
 	auth0Client     *auth0.Client
 	broker          *PoolBroker
 	pgListener      *PGListener
+	holdSweeper     *HoldSweeper
+	draftTimer      *DraftTimer
+	autoDraw        *AutoDrawScheduler
+	autoLock        *AutoLockScheduler
 }
 
 // New returns a new server object
@@ -90,11 +94,39 @@ func New(version string, db *sql.DB) *Server {
 		s.pgListener = pgListener
 	}
 
+	// Release reserved squares that were not paid for before their hold expired
+	s.holdSweeper = NewHoldSweeper(s.model, s.broker, holdSweepInterval)
+	s.holdSweeper.Start(context.Background())
+
+	// Pick at random for draft members whose turn has timed out
+	s.draftTimer = NewDraftTimer(s.model, s.broker, draftTimerInterval)
+	s.draftTimer.Start(context.Background())
+
+	// Draw the numbers of grids that are scheduled to draw at lock or before kickoff
+	s.autoDraw = NewAutoDrawScheduler(s.model, s.broker, autoDrawInterval)
+	s.autoDraw.Start(context.Background())
+
+	// Lock pools at the kickoff of their linked events
+	s.autoLock = NewAutoLockScheduler(s.model, s.broker, autoLockInterval)
+	s.autoLock.Start(context.Background())
+
 	return s
 }
 
 // Shutdown will handle any cleanup
 func (s *Server) Shutdown() error {
+	if s.holdSweeper != nil {
+		s.holdSweeper.Close()
+	}
+	if s.draftTimer != nil {
+		s.draftTimer.Close()
+	}
+	if s.autoDraw != nil {
+		s.autoDraw.Close()
+	}
+	if s.autoLock != nil {
+		s.autoLock.Close()
+	}
 	if s.pgListener != nil {
 		if err := s.pgListener.Close(); err != nil {
 			logrus.WithError(err).Error("could not close pg listener")
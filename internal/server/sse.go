@@ -74,6 +74,11 @@ func (s *Server) getPoolTokenEventsEndpoint() http.HandlerFunc {
 				// Check if auto-join is possible
 				if (pool.IsLocked() && pool.OpenAccessOnLock()) || !pool.PasswordRequired() {
 					if err := user.JoinPool(r.Context(), pool); err != nil {
+						if errors.Is(err, model.ErrBannedFromPool) {
+							s.writeErrorResponse(w, http.StatusForbidden, err)
+							return
+						}
+
 						s.writeErrorResponse(w, http.StatusInternalServerError, err)
 						return
 					}
@@ -0,0 +1,51 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"fmt"
+)
+
+// keys of the advisory locks taken by background jobs. Each job has its own key so that they do not block each other.
+const (
+	AdvisoryLockAutoDraw int64 = iota + 1
+	AdvisoryLockAutoLock
+)
+
+// WithAdvisoryLock will run fn while holding a Postgres advisory lock so that a job which runs on every API server is
+// only run by one of them at a time. If another server holds the lock, fn is not run and false is returned.
+func (m *Model) WithAdvisoryLock(ctx context.Context, key int64, fn func(ctx context.Context) error) (bool, error) {
+	// the lock belongs to the transaction, so it is released when the transaction ends even if the connection is lost
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var ok bool
+	if err := tx.QueryRowContext(ctx, "SELECT pg_try_advisory_xact_lock($1)", key).Scan(&ok); err != nil {
+		return false, fmt.Errorf("taking advisory lock: %w", err)
+	}
+
+	if !ok {
+		return false, nil
+	}
+
+	return true, fn(ctx)
+}
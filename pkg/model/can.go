@@ -29,9 +29,15 @@ const maxPoolsPerDay = 10
 // Action is a type of action that a user wants to take
 type Action int
 
-// Action constants
+// Action constants. Actions after ActionCreatePool are taken within a pool, and are checked against the user's role
+// in it with CanInPool.
 const (
 	ActionCreatePool Action = iota
+	ActionClaimSquares
+	ActionRecordPayments
+	ActionEditGrids
+	ActionManagePool
+	ActionManageRoles
 )
 
 // ActionError provides a reason why a user cannot perform an action
@@ -0,0 +1,383 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// bounds for the per-pick timer of a draft. A timer of 0 means turns never time out.
+const (
+	DraftMinPickSeconds = 30
+	DraftMaxPickSeconds = 60 * 60 * 24
+)
+
+// ErrNotYourTurn is an error when a member tries to claim a square while another member is on the clock
+var ErrNotYourTurn = errors.New("it is not your turn to pick")
+
+// ErrDraftMemberNotFound is an error when the draft order contains a user who does not belong to the pool
+var ErrDraftMemberNotFound = errors.New("every member of the draft order must belong to the pool")
+
+// DraftMember is a member of the draft order. Claimant is the name used when a pick is made for them because their
+// turn timed out.
+type DraftMember struct {
+	UserID   int64  `json:"userId"`
+	Claimant string `json:"claimant"`
+}
+
+// PoolDraft is a snake draft of a pool's squares. Members pick one square at a time in order, and the order reverses
+// every round so that the member who picks last in one round picks first in the next.
+type PoolDraft struct {
+	model       *Model
+	poolID      int64
+	members     []DraftMember
+	pickSeconds int
+	pickNumber  int
+	turnStarted time.Time
+}
+
+// PoolDraftJSON represents a draft that can be sent to the front-end
+type PoolDraftJSON struct {
+	Order       []DraftMember `json:"order"`
+	PickSeconds int           `json:"pickSeconds"`
+	// Pick and Round start at 1
+	Pick        int         `json:"pick"`
+	Round       int         `json:"round"`
+	OnTheClock  DraftMember `json:"onTheClock"`
+	TurnStarted time.Time   `json:"turnStarted"`
+	TurnEnds    *time.Time  `json:"turnEnds,omitempty"`
+}
+
+// NewDraft returns a draft of the pool's squares that starts with the first member of the order. It is not stored
+// until it is saved.
+func (p *Pool) NewDraft(members []DraftMember, pickSeconds int) *PoolDraft {
+	return &PoolDraft{
+		model:       p.model,
+		poolID:      p.id,
+		members:     members,
+		pickSeconds: pickSeconds,
+		turnStarted: time.Now(),
+	}
+}
+
+// Members returns the draft order
+func (d *PoolDraft) Members() []DraftMember {
+	return d.members
+}
+
+// PickSeconds is a getter for pickSeconds
+func (d *PoolDraft) PickSeconds() int {
+	return d.pickSeconds
+}
+
+// PickNumber returns how many picks have been made
+func (d *PoolDraft) PickNumber() int {
+	return d.pickNumber
+}
+
+// Round returns the current round, starting at 1
+func (d *PoolDraft) Round() int {
+	return d.pickNumber/len(d.members) + 1
+}
+
+// OnTheClock returns the member whose turn it is
+func (d *PoolDraft) OnTheClock() DraftMember {
+	n := len(d.members)
+	i := d.pickNumber % n
+	if (d.pickNumber/n)%2 == 1 {
+		i = n - 1 - i
+	}
+
+	return d.members[i]
+}
+
+// TurnEnds returns when the current turn times out, or nil if the draft has no timer
+func (d *PoolDraft) TurnEnds() *time.Time {
+	if d.pickSeconds == 0 {
+		return nil
+	}
+
+	ends := d.turnStarted.Add(time.Duration(d.pickSeconds) * time.Second)
+	return &ends
+}
+
+// CheckTurn returns ErrNotYourTurn unless the user is on the clock
+func (d *PoolDraft) CheckTurn(userID int64) error {
+	if d.OnTheClock().UserID != userID {
+		return ErrNotYourTurn
+	}
+
+	return nil
+}
+
+// JSON returns the draft that can be sent to the front-end
+func (d *PoolDraft) JSON() *PoolDraftJSON {
+	return &PoolDraftJSON{
+		Order:       d.members,
+		PickSeconds: d.pickSeconds,
+		Pick:        d.pickNumber + 1,
+		Round:       d.Round(),
+		OnTheClock:  d.OnTheClock(),
+		TurnStarted: d.turnStarted,
+		TurnEnds:    d.TurnEnds(),
+	}
+}
+
+// Save will start the draft, replacing any draft the pool already has. ErrDraftMemberNotFound is returned if any
+// member of the order does not belong to the pool.
+func (d *PoolDraft) Save(ctx context.Context) error {
+	userIDs := make([]int64, len(d.members))
+	claimants := make([]string, len(d.members))
+	for i, member := range d.members {
+		userIDs[i] = member.UserID
+		claimants[i] = member.Claimant
+	}
+
+	const membersQuery = `
+		SELECT COUNT(DISTINCT user_id)
+		FROM (
+			SELECT user_id FROM pools WHERE id = $1
+			UNION ALL
+			SELECT user_id FROM pools_users WHERE pool_id = $1
+		) members
+		WHERE user_id = ANY($2)`
+
+	var found int
+	if err := d.model.DB.QueryRowContext(ctx, membersQuery, d.poolID, pq.Array(userIDs)).Scan(&found); err != nil {
+		return fmt.Errorf("checking draft members: %w", err)
+	}
+
+	if found != len(d.members) {
+		return ErrDraftMemberNotFound
+	}
+
+	const query = `
+		INSERT INTO pool_drafts (pool_id, user_ids, claimants, pick_seconds)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (pool_id) DO UPDATE
+		SET user_ids = EXCLUDED.user_ids,
+		    claimants = EXCLUDED.claimants,
+		    pick_seconds = EXCLUDED.pick_seconds,
+		    pick_number = 0,
+		    turn_started = (NOW() AT TIME ZONE 'utc'),
+		    created = (NOW() AT TIME ZONE 'utc')
+		RETURNING turn_started`
+	row := d.model.DB.QueryRowContext(ctx, query, d.poolID, pq.Array(userIDs), pq.Array(claimants), d.pickSeconds)
+	if err := row.Scan(&d.turnStarted); err != nil {
+		return fmt.Errorf("saving draft: %w", err)
+	}
+
+	d.pickNumber = 0
+	d.turnStarted = d.turnStarted.In(locationNewYork)
+	return nil
+}
+
+// Advance will pass the turn to the next member. It should be called in the same transaction as the pick.
+func (d *PoolDraft) Advance(ctx context.Context, q Queryable) error {
+	const query = `
+		UPDATE pool_drafts
+		SET pick_number = pick_number + 1,
+		    turn_started = (NOW() AT TIME ZONE 'utc')
+		WHERE pool_id = $1
+		RETURNING pick_number, turn_started`
+	if err := q.QueryRowContext(ctx, query, d.poolID).Scan(&d.pickNumber, &d.turnStarted); err != nil {
+		return fmt.Errorf("advancing draft: %w", err)
+	}
+
+	d.turnStarted = d.turnStarted.In(locationNewYork)
+	return nil
+}
+
+// Draft returns the pool's draft, or nil if squares are first-come-first-served
+func (p *Pool) Draft(ctx context.Context) (*PoolDraft, error) {
+	return p.draft(ctx, p.model.DB, false)
+}
+
+// LockDraft returns the pool's draft, or nil if it has none, and locks it until the transaction ends so that only
+// one pick can be made per turn
+func (p *Pool) LockDraft(ctx context.Context, tx *sql.Tx) (*PoolDraft, error) {
+	return p.draft(ctx, tx, true)
+}
+
+func (p *Pool) draft(ctx context.Context, q Queryable, lock bool) (*PoolDraft, error) {
+	query := "SELECT user_ids, claimants, pick_seconds, pick_number, turn_started FROM pool_drafts WHERE pool_id = $1"
+	if lock {
+		query += " FOR UPDATE"
+	}
+
+	d := &PoolDraft{
+		model:  p.model,
+		poolID: p.id,
+	}
+
+	var userIDs []int64
+	var claimants []string
+	row := q.QueryRowContext(ctx, query, p.id)
+	if err := row.Scan(pq.Array(&userIDs), pq.Array(&claimants), &d.pickSeconds, &d.pickNumber, &d.turnStarted); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("loading draft: %w", err)
+	}
+
+	if len(userIDs) == 0 || len(userIDs) != len(claimants) {
+		return nil, fmt.Errorf("draft of pool %d has an invalid order", p.id)
+	}
+
+	d.members = make([]DraftMember, len(userIDs))
+	for i, userID := range userIDs {
+		d.members[i] = DraftMember{UserID: userID, Claimant: claimants[i]}
+	}
+
+	d.turnStarted = d.turnStarted.In(locationNewYork)
+	return d, nil
+}
+
+// DeleteDraft will end the pool's draft, after which squares are first-come-first-served
+func (p *Pool) DeleteDraft(ctx context.Context) error {
+	if _, err := p.model.DB.ExecContext(ctx, "DELETE FROM pool_drafts WHERE pool_id = $1", p.id); err != nil {
+		return fmt.Errorf("deleting draft: %w", err)
+	}
+
+	return nil
+}
+
+// AutoPickDraftTurn will claim a random square for the member on the clock if their turn has timed out, and pass the
+// turn on. The pick follows the same rules as a pick made by the member, so it counts towards the square limits and is
+// reserved if the pool has a hold period. A member who can no longer claim squares, such as one who was removed from
+// the pool, and a member who has reached a square limit, is passed over without a pick.
+//
+// It returns the draft after the turn, and whether the turn was passed on. The draft is over and is deleted when no
+// squares are left or the pool is locked or archived, in which case the draft returned is nil.
+func (p *Pool) AutoPickDraftTurn(ctx context.Context) (*PoolDraft, bool, error) {
+	tx, err := p.model.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	draft, err := p.LockDraft(ctx, tx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// the member may have picked, or the draft may have ended, since the turn was found to have timed out
+	if draft == nil || draft.TurnEnds() == nil || draft.TurnEnds().After(time.Now()) {
+		return draft, false, nil
+	}
+
+	if p.IsLocked() || p.archived {
+		return nil, false, p.endDraft(ctx, tx)
+	}
+
+	member := draft.OnTheClock()
+	role, err := (&User{Model: p.model, ID: member.UserID}).PoolRole(ctx, p)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if role.Can(ActionClaimSquares) {
+		err := p.autoPick(ctx, tx, draft, member, role)
+		if errors.Is(err, ErrNotEnoughSquares) {
+			return nil, false, p.endDraft(ctx, tx)
+		}
+		if err != nil && !errors.Is(err, ErrSquareLimitReached) {
+			return nil, false, err
+		}
+	}
+
+	if err := draft.Advance(ctx, tx); err != nil {
+		return nil, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return draft, true, nil
+}
+
+// autoPick claims a random square for the member. A *SquareLimitError is returned without a pick if it would take
+// them over one of the pool's square limits.
+func (p *Pool) autoPick(ctx context.Context, tx *sql.Tx, draft *PoolDraft, member DraftMember, role PoolRole) error {
+	isManager := role.Can(ActionManagePool)
+	if err := p.CheckSquareLimits(ctx, tx, member.UserID, member.Claimant, 1, !isManager); err != nil {
+		return err
+	}
+
+	var hold time.Duration
+	if !isManager {
+		var err error
+		if hold, err = p.HoldPeriod(ctx); err != nil {
+			return err
+		}
+	}
+
+	_, err := p.QuickPickSquares(ctx, tx, member.UserID, member.Claimant, 1, hold, PoolSquareLog{
+		Note: fmt.Sprintf("system: draft pick %d timed out, picked at random", draft.pickNumber+1),
+	})
+	return err
+}
+
+// endDraft deletes the pool's draft and commits the transaction
+func (p *Pool) endDraft(ctx context.Context, tx *sql.Tx) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM pool_drafts WHERE pool_id = $1", p.id); err != nil {
+		return fmt.Errorf("ending draft: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return nil
+}
+
+// TimedOutDraftPoolTokens returns the tokens of pools whose draft has a member on the clock whose turn has timed out
+func (m *Model) TimedOutDraftPoolTokens(ctx context.Context) ([]string, error) {
+	const query = `
+		SELECT pools.token
+		FROM pool_drafts
+		INNER JOIN pools ON pool_drafts.pool_id = pools.id
+		WHERE pool_drafts.pick_seconds > 0 AND
+		      pool_drafts.turn_started + make_interval(secs => pool_drafts.pick_seconds) <= (NOW() AT TIME ZONE 'utc')
+		ORDER BY pool_drafts.turn_started`
+	rows, err := m.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("loading timed out drafts: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := make([]string, 0)
+	for rows.Next() {
+		var token string
+		if err := rows.Scan(&token); err != nil {
+			return nil, fmt.Errorf("scanning pool token: %w", err)
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, rows.Err()
+}
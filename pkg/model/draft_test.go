@@ -0,0 +1,155 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/onsi/gomega"
+)
+
+func TestPoolDraftSnakeOrder(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	pool := &Pool{id: 1}
+	draft := pool.NewDraft([]DraftMember{
+		{UserID: 1, Claimant: "Alice"},
+		{UserID: 2, Claimant: "Bob"},
+		{UserID: 3, Claimant: "Carol"},
+	}, 0)
+
+	expected := []int64{1, 2, 3, 3, 2, 1, 1, 2, 3}
+	for pick, userID := range expected {
+		draft.pickNumber = pick
+		g.Expect(draft.OnTheClock().UserID).Should(gomega.Equal(userID), "pick %d", pick+1)
+		g.Expect(draft.Round()).Should(gomega.Equal(pick/3 + 1))
+	}
+
+	draft.pickNumber = 3
+	g.Expect(draft.CheckTurn(3)).Should(gomega.Succeed())
+	g.Expect(draft.CheckTurn(1)).Should(gomega.MatchError(ErrNotYourTurn))
+}
+
+func TestPoolDraftTurnEnds(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	pool := &Pool{id: 1}
+	members := []DraftMember{{UserID: 1, Claimant: "Alice"}}
+
+	untimed := pool.NewDraft(members, 0)
+	g.Expect(untimed.TurnEnds()).Should(gomega.BeNil())
+	g.Expect(untimed.JSON().TurnEnds).Should(gomega.BeNil())
+
+	timed := pool.NewDraft(members, 90)
+	g.Expect(*timed.TurnEnds()).Should(gomega.Equal(timed.turnStarted.Add(90 * time.Second)))
+
+	json := timed.JSON()
+	g.Expect(json.Pick).Should(gomega.Equal(1))
+	g.Expect(json.Round).Should(gomega.Equal(1))
+	g.Expect(json.OnTheClock).Should(gomega.Equal(members[0]))
+}
+
+// expectTimedOutDraft expects the draft of pool 1 to be locked, with Alice (user 10) on the clock and her turn over
+func expectTimedOutDraft(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT user_ids, claimants, pick_seconds, pick_number, turn_started FROM pool_drafts WHERE pool_id = \\$1 FOR UPDATE").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"user_ids", "claimants", "pick_seconds", "pick_number", "turn_started"}).
+			AddRow("{10,20}", "{Alice,Bob}", 60, 0, time.Now().Add(-2*time.Minute)))
+}
+
+func TestPoolAutoPickDraftTurnEndsDraftWhenPoolIsLocked(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	defer db.Close()
+
+	pool := &Pool{model: New(db), id: 1, userID: 1, locks: time.Now().Add(-time.Minute)}
+
+	expectTimedOutDraft(mock)
+	mock.ExpectExec("DELETE FROM pool_drafts WHERE pool_id = \\$1").
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	draft, passed, err := pool.AutoPickDraftTurn(context.Background())
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(draft).Should(gomega.BeNil())
+	g.Expect(passed).Should(gomega.BeFalse())
+	g.Expect(mock.ExpectationsWereMet()).Should(gomega.Succeed())
+}
+
+func TestPoolAutoPickDraftTurnPassesOverRemovedMember(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	defer db.Close()
+
+	pool := &Pool{model: New(db), id: 1, userID: 1}
+
+	expectTimedOutDraft(mock)
+	mock.ExpectQuery("SELECT role FROM pools_users WHERE pool_id = \\$1 AND user_id = \\$2").
+		WithArgs(int64(1), int64(10)).
+		WillReturnRows(sqlmock.NewRows([]string{"role"}))
+	mock.ExpectQuery("UPDATE pool_drafts SET pick_number = pick_number \\+ 1").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"pick_number", "turn_started"}).AddRow(1, time.Now()))
+	mock.ExpectCommit()
+
+	draft, passed, err := pool.AutoPickDraftTurn(context.Background())
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(passed).Should(gomega.BeTrue())
+	g.Expect(draft.OnTheClock().Claimant).Should(gomega.Equal("Bob"))
+	g.Expect(mock.ExpectationsWereMet()).Should(gomega.Succeed())
+}
+
+func TestPoolAutoPickDraftTurnPassesOverMemberAtSquareLimit(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	defer db.Close()
+
+	pool := &Pool{model: New(db), id: 1, userID: 1}
+
+	expectTimedOutDraft(mock)
+	mock.ExpectQuery("SELECT role FROM pools_users WHERE pool_id = \\$1 AND user_id = \\$2").
+		WithArgs(int64(1), int64(10)).
+		WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow("claimer"))
+	mock.ExpectQuery("SELECT max_per_user, max_per_claimant, modified FROM pool_square_limits WHERE pool_id = \\$1 FOR UPDATE").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"max_per_user", "max_per_claimant", "modified"}).AddRow(1, 0, time.Now()))
+	mock.ExpectQuery("WITH holdings AS").
+		WithArgs(int64(1), int64(10)).
+		WillReturnRows(sqlmock.NewRows([]string{"min", "count", "count"}).AddRow("Alice", 1, 1))
+	mock.ExpectQuery("UPDATE pool_drafts SET pick_number = pick_number \\+ 1").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"pick_number", "turn_started"}).AddRow(1, time.Now()))
+	mock.ExpectCommit()
+
+	draft, passed, err := pool.AutoPickDraftTurn(context.Background())
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(passed).Should(gomega.BeTrue())
+	g.Expect(draft.OnTheClock().Claimant).Should(gomega.Equal("Bob"))
+	g.Expect(mock.ExpectationsWereMet()).Should(gomega.Succeed())
+}
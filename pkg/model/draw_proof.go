@@ -0,0 +1,398 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DrawSeedLength is the number of bytes in the secret seed of a draw
+const DrawSeedLength = 32
+
+// DrawEntropyMaxLength is the maximum length of the entropy a member can contribute to a draw
+const DrawEntropyMaxLength = 100
+
+// DrawAlgorithm describes how the numbers are derived from the seed so that the draw can be verified independently
+const DrawAlgorithm = `commitment = hex(SHA-256(seed)). ` +
+	`entropyHash = hex(SHA-256(entropy joined by "\n")), or "" if no entropy was contributed. ` +
+	`For each set type and team ("home" then "away"), a byte stream is made by concatenating ` +
+	`HMAC-SHA256(key = seed, message = "<setType>:<team>:<entropyHash>:<block>") for block = 0, 1, 2, ... ` +
+	`Starting from [0,1,2,3,4,5,6,7,8,9], for i from 9 down to 1: read bytes b from the stream until ` +
+	`b < 256 - (256 mod (i+1)), then swap the numbers at positions i and (b mod (i+1)).`
+
+// ErrDrawEntropyClosed is an error when a member tries to contribute entropy after the numbers have been drawn
+var ErrDrawEntropyClosed = errors.New("entropy can only be contributed before the numbers are drawn")
+
+// drawTeams are the teams numbers are derived for, in the order they are drawn
+var drawTeams = []string{"home", "away"}
+
+// DrawCommitment is the secret seed the numbers of a grid are derived from, along with the entropy contributed
+// by members
+type DrawCommitment struct {
+	seed    []byte
+	entropy []string
+	drawn   *time.Time
+	created time.Time
+}
+
+// DrawNumbers are the home and away numbers of a number set
+type DrawNumbers struct {
+	HomeNumbers []int `json:"homeNumbers"`
+	AwayNumbers []int `json:"awayNumbers"`
+}
+
+// DrawProof is everything needed to check that the numbers of a grid were derived from the seed that was committed
+// to before the draw. The seed is only revealed once the numbers are drawn.
+type DrawProof struct {
+	GridID      int64      `json:"gridId"`
+	Commitment  string     `json:"commitment,omitempty"`
+	CommittedAt *time.Time `json:"committedAt,omitempty"`
+	// RedrawCommitment is the commitment to the seed a redraw will use, made when the numbers were drawn
+	RedrawCommitment string                         `json:"redrawCommitment,omitempty"`
+	Seed             string                         `json:"seed,omitempty"`
+	Entropy          []string                       `json:"entropy"`
+	EntropyHash      string                         `json:"entropyHash"`
+	DrawnAt          *time.Time                     `json:"drawnAt,omitempty"`
+	Algorithm        string                         `json:"algorithm"`
+	NumberSets       map[NumberSetType]*DrawNumbers `json:"numberSets,omitempty"`
+	// Verified is true if the numbers derived from the revealed seed match the grid's numbers
+	Verified bool `json:"verified"`
+}
+
+// Commitment returns the hash of the seed that is published before the draw
+func (c *DrawCommitment) Commitment() string {
+	sum := sha256.Sum256(c.seed)
+	return hex.EncodeToString(sum[:])
+}
+
+// EntropyHash returns the hash of the contributed entropy, or an empty string if there is none
+func (c *DrawCommitment) EntropyHash() string {
+	if len(c.entropy) == 0 {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(c.entropy, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Numbers returns the home and away numbers derived for the set type
+func (c *DrawCommitment) Numbers(setType NumberSetType) (homeNumbers, awayNumbers []int) {
+	entropyHash := c.EntropyHash()
+	return deriveDrawNumbers(c.seed, string(setType), drawTeams[0], entropyHash),
+		deriveDrawNumbers(c.seed, string(setType), drawTeams[1], entropyHash)
+}
+
+// drawStream is the byte stream of HMAC-SHA256 blocks used to shuffle a team's numbers
+type drawStream struct {
+	seed   []byte
+	prefix string
+	block  int
+	buf    []byte
+}
+
+func (s *drawStream) next() byte {
+	if len(s.buf) == 0 {
+		mac := hmac.New(sha256.New, s.seed)
+		mac.Write([]byte(s.prefix + strconv.Itoa(s.block)))
+		s.buf = mac.Sum(nil)
+		s.block++
+	}
+
+	b := s.buf[0]
+	s.buf = s.buf[1:]
+	return b
+}
+
+// deriveDrawNumbers shuffles 0-9 with a Fisher-Yates shuffle driven by the stream, rejecting bytes that would bias
+// the result
+func deriveDrawNumbers(seed []byte, setType, team, entropyHash string) []int {
+	stream := &drawStream{
+		seed:   seed,
+		prefix: fmt.Sprintf("%s:%s:%s:", setType, team, entropyHash),
+	}
+
+	nums := make([]int, 10)
+	for i := range nums {
+		nums[i] = i
+	}
+
+	for i := len(nums) - 1; i > 0; i-- {
+		n := i + 1
+		limit := 256 - 256%n
+
+		b := int(stream.next())
+		for b >= limit {
+			b = int(stream.next())
+		}
+
+		j := b % n
+		nums[i], nums[j] = nums[j], nums[i]
+	}
+
+	return nums
+}
+
+// lockDrawCommitment returns the grid's commitment, which is made when the grid is created. The commitment is locked
+// until the transaction ends so that no entropy can be added while the numbers are drawn.
+func (g *Grid) lockDrawCommitment(ctx context.Context, tx *sql.Tx) (*DrawCommitment, error) {
+	const query = "SELECT seed, drawn, created FROM grid_draw_commitments WHERE grid_id = $1 FOR UPDATE"
+
+	c := &DrawCommitment{}
+	if err := tx.QueryRowContext(ctx, query, g.id).Scan(&c.seed, &c.drawn, &c.created); err != nil {
+		return nil, fmt.Errorf("loading draw commitment: %w", err)
+	}
+
+	entropy, err := g.drawEntropy(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.entropy = entropy
+	return c, nil
+}
+
+// DrawCommitment returns the loaded commitment to the grid's draw, or an empty string if it has none
+func (g *Grid) DrawCommitment() string {
+	return g.drawCommitment
+}
+
+// RedrawCommitment returns the loaded commitment to the seed a redraw will use, or an empty string if the numbers
+// have not been drawn
+func (g *Grid) RedrawCommitment() string {
+	return g.redrawCommitment
+}
+
+// LoadDrawCommitment will load the commitments to the grid's draw and redraw so that they are published with the grid
+func (g *Grid) LoadDrawCommitment(ctx context.Context) error {
+	var seed, nextSeed []byte
+	row := g.model.DB.QueryRowContext(ctx, "SELECT seed, next_seed FROM grid_draw_commitments WHERE grid_id = $1", g.id)
+	if err := row.Scan(&seed, &nextSeed); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			g.drawCommitment, g.redrawCommitment = "", ""
+			return nil
+		}
+
+		return fmt.Errorf("loading draw commitment: %w", err)
+	}
+
+	g.drawCommitment = (&DrawCommitment{seed: seed}).Commitment()
+	g.redrawCommitment = ""
+	if nextSeed != nil {
+		g.redrawCommitment = (&DrawCommitment{seed: nextSeed}).Commitment()
+	}
+
+	return nil
+}
+
+// drawEntropy returns the entropy contributed to the grid's draw, in the order it was first contributed
+func (g *Grid) drawEntropy(ctx context.Context, q Queryable) ([]string, error) {
+	rows, err := q.QueryContext(ctx, "SELECT entropy FROM grid_draw_entropy WHERE grid_id = $1 ORDER BY id", g.id)
+	if err != nil {
+		return nil, fmt.Errorf("loading draw entropy: %w", err)
+	}
+	defer rows.Close()
+
+	entropy := make([]string, 0)
+	for rows.Next() {
+		var e string
+		if err := rows.Scan(&e); err != nil {
+			return nil, fmt.Errorf("scanning draw entropy: %w", err)
+		}
+
+		entropy = append(entropy, e)
+	}
+
+	return entropy, rows.Err()
+}
+
+// commitDraw returns the commitment the numbers are derived from and records when they were drawn. The seed is
+// revealed from then on, so no more entropy can be contributed. The seed a redraw will use is committed to at the
+// same time.
+func (g *Grid) commitDraw(ctx context.Context, tx *sql.Tx) (*DrawCommitment, error) {
+	c, err := g.lockDrawCommitment(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	nextSeed := make([]byte, DrawSeedLength)
+	if _, err := rand.Read(nextSeed); err != nil {
+		return nil, fmt.Errorf("generating seed: %w", err)
+	}
+
+	const query = `
+		UPDATE grid_draw_commitments
+		SET drawn = COALESCE(drawn, (NOW() AT TIME ZONE 'utc')),
+		    next_seed = COALESCE(next_seed, $2),
+		    next_created = COALESCE(next_created, (NOW() AT TIME ZONE 'utc'))
+		WHERE grid_id = $1
+		RETURNING drawn`
+	if err := tx.QueryRowContext(ctx, query, g.id, nextSeed).Scan(&c.drawn); err != nil {
+		return nil, fmt.Errorf("recording draw: %w", err)
+	}
+
+	return c, nil
+}
+
+// nextDrawCommitment replaces a revealed commitment with the one its draw committed to, so that a redraw uses a
+// seed that was published before it. A commitment that was never revealed, because the numbers were entered by
+// hand, is kept.
+func (g *Grid) nextDrawCommitment(ctx context.Context, tx *sql.Tx) error {
+	const query = `
+		UPDATE grid_draw_commitments
+		SET seed = next_seed,
+		    created = next_created,
+		    drawn = NULL,
+		    next_seed = NULL,
+		    next_created = NULL
+		WHERE grid_id = $1 AND drawn IS NOT NULL`
+	if _, err := tx.ExecContext(ctx, query, g.id); err != nil {
+		return fmt.Errorf("replacing draw commitment: %w", err)
+	}
+
+	return nil
+}
+
+// AddDrawEntropy will mix the member's entropy into the grid's draw. Each member has one contribution, which
+// replaces any they made before. ErrDrawEntropyClosed is returned once the numbers have been drawn.
+func (g *Grid) AddDrawEntropy(ctx context.Context, userID int64, entropy string) error {
+	tx, err := g.model.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	c, err := g.lockDrawCommitment(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	if c.drawn != nil {
+		return ErrDrawEntropyClosed
+	}
+
+	const query = `
+		INSERT INTO grid_draw_entropy (grid_id, user_id, entropy)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (grid_id, user_id) DO UPDATE
+		SET entropy = EXCLUDED.entropy,
+		    modified = (NOW() AT TIME ZONE 'utc')`
+	if _, err := tx.ExecContext(ctx, query, g.id, userID, entropy); err != nil {
+		return fmt.Errorf("saving draw entropy: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return nil
+}
+
+// DrawProof returns the proof of the grid's draw for the config. Before the draw it only contains the commitment.
+// Grids whose numbers were drawn by hand, or before draws were committed to, cannot be verified. Number sets must be
+// loaded for multi-set configs.
+func (g *Grid) DrawProof(ctx context.Context, config NumberSetConfig) (*DrawProof, error) {
+	setTypes := GetSetTypes(config)
+	if setTypes == nil {
+		return nil, fmt.Errorf("invalid number set config: %s", config)
+	}
+
+	proof := &DrawProof{
+		GridID:    g.id,
+		Entropy:   make([]string, 0),
+		Algorithm: DrawAlgorithm,
+	}
+
+	drawn := g.NumbersAreDrawn(config)
+
+	c := &DrawCommitment{}
+	var nextSeed []byte
+	row := g.model.DB.QueryRowContext(ctx, "SELECT seed, drawn, created, next_seed FROM grid_draw_commitments WHERE grid_id = $1", g.id)
+	if err := row.Scan(&c.seed, &c.drawn, &c.created, &nextSeed); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return proof, nil
+		}
+
+		return nil, fmt.Errorf("loading draw commitment: %w", err)
+	}
+
+	entropy, err := g.drawEntropy(ctx, g.model.DB)
+	if err != nil {
+		return nil, err
+	}
+	c.entropy = entropy
+
+	committedAt := c.created.In(locationNewYork)
+	proof.Commitment = c.Commitment()
+	proof.CommittedAt = &committedAt
+	proof.Entropy = c.entropy
+	proof.EntropyHash = c.EntropyHash()
+
+	// the numbers were set by hand after the commitment was made
+	if !drawn || c.drawn == nil {
+		return proof, nil
+	}
+
+	drawnAt := c.drawn.In(locationNewYork)
+	proof.Seed = hex.EncodeToString(c.seed)
+	if nextSeed != nil {
+		proof.RedrawCommitment = (&DrawCommitment{seed: nextSeed}).Commitment()
+	}
+	proof.DrawnAt = &drawnAt
+	proof.NumberSets = make(map[NumberSetType]*DrawNumbers, len(setTypes))
+	proof.Verified = true
+	for _, setType := range setTypes {
+		home, away := c.Numbers(setType)
+		proof.NumberSets[setType] = &DrawNumbers{HomeNumbers: home, AwayNumbers: away}
+
+		gridHome, gridAway := g.homeNumbers, g.awayNumbers
+		if config != NumberSetConfigStandard {
+			ns := g.numberSets[setType]
+			gridHome, gridAway = ns.homeNumbers, ns.awayNumbers
+		}
+
+		if !intsEqual(home, gridHome) || !intsEqual(away, gridAway) {
+			proof.Verified = false
+		}
+	}
+
+	return proof, nil
+}
+
+func intsEqual(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,137 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/onsi/gomega"
+)
+
+// verifyDrawNumbers follows DrawAlgorithm step by step, the way someone checking a draw would
+func verifyDrawNumbers(seed []byte, setType, team, entropyHash string) []int {
+	var stream bytes.Buffer
+	for block := 0; stream.Len() < 1024; block++ {
+		mac := hmac.New(sha256.New, seed)
+		mac.Write([]byte(fmt.Sprintf("%s:%s:%s:%d", setType, team, entropyHash, block)))
+		stream.Write(mac.Sum(nil))
+	}
+
+	nums := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	for i := 9; i > 0; i-- {
+		b, _ := stream.ReadByte()
+		for int(b) >= 256-256%(i+1) {
+			b, _ = stream.ReadByte()
+		}
+
+		j := int(b) % (i + 1)
+		nums[i], nums[j] = nums[j], nums[i]
+	}
+
+	return nums
+}
+
+func TestDrawCommitmentNumbers(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	seed := make([]byte, DrawSeedLength)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+
+	c := &DrawCommitment{seed: seed}
+	sum := sha256.Sum256(seed)
+	g.Expect(c.Commitment()).Should(gomega.Equal(hex.EncodeToString(sum[:])))
+	g.Expect(c.EntropyHash()).Should(gomega.Equal(""))
+
+	home, away := c.Numbers(NumberSetTypeAll)
+	g.Expect(home).Should(gomega.Equal(verifyDrawNumbers(seed, "all", "home", "")))
+	g.Expect(away).Should(gomega.Equal(verifyDrawNumbers(seed, "all", "away", "")))
+	g.Expect(home).ShouldNot(gomega.Equal(away))
+
+	for _, nums := range [][]int{home, away} {
+		sorted := append([]int(nil), nums...)
+		sort.Ints(sorted)
+		g.Expect(sorted).Should(gomega.Equal([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}))
+	}
+
+	// the same seed always gives the same numbers
+	again, _ := (&DrawCommitment{seed: seed}).Numbers(NumberSetTypeAll)
+	g.Expect(again).Should(gomega.Equal(home))
+}
+
+func TestDrawCommitmentNumbersWithEntropy(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	seed := bytes.Repeat([]byte{0xab}, DrawSeedLength)
+	plain := &DrawCommitment{seed: seed}
+	mixed := &DrawCommitment{seed: seed, entropy: []string{"lucky 7", "go birds"}}
+
+	sum := sha256.Sum256([]byte("lucky 7\ngo birds"))
+	entropyHash := hex.EncodeToString(sum[:])
+	g.Expect(mixed.EntropyHash()).Should(gomega.Equal(entropyHash))
+
+	home, away := mixed.Numbers(NumberSetTypeQ1)
+	g.Expect(home).Should(gomega.Equal(verifyDrawNumbers(seed, "q1", "home", entropyHash)))
+	g.Expect(away).Should(gomega.Equal(verifyDrawNumbers(seed, "q1", "away", entropyHash)))
+
+	plainHome, _ := plain.Numbers(NumberSetTypeQ1)
+	g.Expect(plainHome).ShouldNot(gomega.Equal(home))
+
+	otherSetHome, _ := mixed.Numbers(NumberSetTypeQ2)
+	g.Expect(otherSetHome).ShouldNot(gomega.Equal(home))
+}
+
+func TestGridLoadDrawCommitment(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	defer db.Close()
+
+	grid := &Grid{model: New(db), id: 7}
+
+	seed := bytes.Repeat([]byte{0xab}, DrawSeedLength)
+	nextSeed := bytes.Repeat([]byte{0xcd}, DrawSeedLength)
+	mock.ExpectQuery("SELECT seed, next_seed FROM grid_draw_commitments WHERE grid_id = \\$1").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"seed", "next_seed"}).AddRow(seed, nil))
+	mock.ExpectQuery("SELECT seed, next_seed FROM grid_draw_commitments WHERE grid_id = \\$1").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"seed", "next_seed"}).AddRow(seed, nextSeed))
+
+	// only the hash of the seed is published with the grid
+	g.Expect(grid.LoadDrawCommitment(context.Background())).Should(gomega.Succeed())
+	sum := sha256.Sum256(seed)
+	g.Expect(grid.JSON().Commitment).Should(gomega.Equal(hex.EncodeToString(sum[:])))
+	g.Expect(grid.JSON().RedrawCommitment).Should(gomega.BeEmpty())
+
+	// once the numbers are drawn, the seed of a redraw is committed to as well
+	g.Expect(grid.LoadDrawCommitment(context.Background())).Should(gomega.Succeed())
+	nextSum := sha256.Sum256(nextSeed)
+	g.Expect(grid.JSON().RedrawCommitment).Should(gomega.Equal(hex.EncodeToString(nextSum[:])))
+	g.Expect(mock.ExpectationsWereMet()).Should(gomega.Succeed())
+}
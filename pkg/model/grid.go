@@ -41,6 +41,9 @@ const (
 // ErrNumbersAlreadyDrawn happens when SelectRandomNumbers() is called multiple times
 var ErrNumbersAlreadyDrawn = errors.New("error: numbers have already been drawn")
 
+// ErrNumbersNotDrawn happens when numbers are redrawn before they have been drawn
+var ErrNumbersNotDrawn = errors.New("error: numbers have not been drawn")
+
 // ErrNumbersAreInvalid happens when the user submits manual numbers and they are invalid
 var ErrNumbersAreInvalid = errors.New("error: numbers supplied are invalid")
 
@@ -70,11 +73,15 @@ type Grid struct {
 	modified     time.Time
 	bdlEventID   *int64
 	payoutConfig *NumberSetConfig
+	overtimeMode OvertimeMode
 
-	settings    *GridSettings
-	annotations map[int]*GridAnnotation
-	numberSets  map[NumberSetType]*GridNumberSet
-	bdlEvent    *BDLEvent
+	settings         *GridSettings
+	annotations      map[int]*GridAnnotation
+	numberSets       map[NumberSetType]*GridNumberSet
+	bdlEvent         *BDLEvent
+	autoDraw         *GridAutoDraw
+	drawCommitment   string
+	redrawCommitment string
 }
 
 // GridJSON represents grid metadata that can be sent to the front-end
@@ -98,29 +105,41 @@ type GridJSON struct {
 	BDLEventID     *int64                               `json:"bdlEventId,omitempty"`
 	BDLEvent       *BDLEventJSON                        `json:"bdlEvent,omitempty"`
 	WinningSquares map[NumberSetType]int                `json:"winningSquares,omitempty"`
+	Rollovers      []RolloverInfo                       `json:"rollovers,omitempty"`
+	ScoreChanges   []ScoreChangeWinner                  `json:"scoreChanges,omitempty"`
 	PayoutConfig   *NumberSetConfig                     `json:"payoutConfig,omitempty"`
+	OvertimeMode   OvertimeMode                         `json:"overtimeMode"`
+	Payouts        []*Payout                            `json:"payouts,omitempty"`
+	AutoDraw       *GridAutoDraw                        `json:"autoDraw,omitempty"`
+	Commitment     string                               `json:"commitment,omitempty"`
+	// RedrawCommitment is published once the numbers are drawn, before it is used by a redraw
+	RedrawCommitment string `json:"redrawCommitment,omitempty"`
 }
 
 // JSON will marshal the JSON using a custom marshaller
 func (g *Grid) JSON() *GridJSON {
 	json := &GridJSON{
-		ID:           g.ID(),
-		Name:         g.Name(),
-		Label:        g.Label(),
-		HomeTeamName: g.HomeTeamName(),
-		HomeNumbers:  g.HomeNumbers(),
-		AwayTeamName: g.AwayTeamName(),
-		AwayNumbers:  g.AwayNumbers(),
-		ManualDraw:   g.manualDraw,
-		EventDate:    g.EventDate(),
-		Rollover:     g.Rollover(),
-		State:        g.State(),
-		Created:      g.Created(),
-		Modified:     g.modified,
-		Settings:     g.settings,
-		Annotations:  g.annotations,
-		BDLEventID:   g.bdlEventID,
-		PayoutConfig: g.payoutConfig,
+		ID:               g.ID(),
+		Name:             g.Name(),
+		Label:            g.Label(),
+		HomeTeamName:     g.HomeTeamName(),
+		HomeNumbers:      g.HomeNumbers(),
+		AwayTeamName:     g.AwayTeamName(),
+		AwayNumbers:      g.AwayNumbers(),
+		ManualDraw:       g.manualDraw,
+		EventDate:        g.EventDate(),
+		Rollover:         g.Rollover(),
+		State:            g.State(),
+		Created:          g.Created(),
+		Modified:         g.modified,
+		Settings:         g.settings,
+		Annotations:      g.annotations,
+		BDLEventID:       g.bdlEventID,
+		PayoutConfig:     g.payoutConfig,
+		OvertimeMode:     g.OvertimeMode(),
+		AutoDraw:         g.autoDraw,
+		Commitment:       g.drawCommitment,
+		RedrawCommitment: g.redrawCommitment,
 	}
 
 	if len(g.numberSets) > 0 {
@@ -190,6 +209,31 @@ func (g *Grid) SetRollover(rollover bool) {
 	g.rollover = rollover
 }
 
+// OvertimeMode returns how the grid treats points scored in overtime
+func (g *Grid) OvertimeMode() OvertimeMode {
+	if g.overtimeMode == "" {
+		return OvertimeModeInclude
+	}
+
+	return g.overtimeMode
+}
+
+// SetOvertimeMode sets how the grid treats points scored in overtime
+func (g *Grid) SetOvertimeMode(overtimeMode OvertimeMode) {
+	g.overtimeMode = overtimeMode
+}
+
+// PeriodSetTypes returns the periods the grid pays out for the config, in the order they are played. Overtime is
+// included when it is paid separately, unless the linked event finished without going to overtime.
+func (g *Grid) PeriodSetTypes(config NumberSetConfig) []NumberSetType {
+	setTypes := SetTypesWithOvertime(config, g.OvertimeMode())
+	if n := len(setTypes); n > 0 && setTypes[n-1] == NumberSetTypeOT && g.bdlEvent != nil && g.bdlEvent.EndedInRegulation() {
+		return setTypes[:n-1]
+	}
+
+	return setTypes
+}
+
 // SetAwayTeamName is the setter for the away team name
 func (g *Grid) SetAwayTeamName(awayTeamName string) {
 	if awayTeamName == "" {
@@ -287,6 +331,15 @@ func (g *Grid) SetPayoutConfig(config *NumberSetConfig) {
 	g.payoutConfig = config
 }
 
+// EffectiveNumberSetConfig returns the grid's payout config if set, otherwise the pool's number set config
+func (g *Grid) EffectiveNumberSetConfig(poolConfig NumberSetConfig) NumberSetConfig {
+	if g.payoutConfig != nil {
+		return *g.payoutConfig
+	}
+
+	return poolConfig
+}
+
 // Label returns the label of the grid.
 func (g *Grid) Label() string {
 	if g.label == nil {
@@ -313,19 +366,27 @@ func (g *Grid) Save(ctx context.Context) error {
 		return err
 	}
 
+	if err := g.save(ctx, tx); err != nil {
+		if err2 := tx.Rollback(); err2 != nil {
+			return fmt.Errorf("error found: %#v. Another error found when trying to rollback: %#v", err, err2)
+		}
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (g *Grid) save(ctx context.Context, q Queryable) error {
 	if g.id == 0 {
 		const query = `
 SELECT ` + gridColumns + `
 FROM
 	new_grid($1, $2)
 `
-		row := tx.QueryRowContext(ctx, query, g.poolID, MaxGridsPerPool)
+		row := q.QueryRowContext(ctx, query, g.poolID, MaxGridsPerPool)
 		newGrid, err := g.model.gridByRow(row.Scan)
 		if err != nil {
-			if err2 := tx.Rollback(); err2 != nil {
-				return fmt.Errorf("error found: %#v. Another error found when trying to rollback: %#v", err, err2)
-			}
-
 			if err.Error() == "pq: limit reached" {
 				return ErrGridLimit
 			}
@@ -344,11 +405,7 @@ FROM
 	}
 
 	if g.settings != nil {
-		if err := g.settings.Save(ctx, tx); err != nil {
-			if err2 := tx.Rollback(); err2 != nil {
-				return fmt.Errorf("error found: %#v. Another error found when trying to rollback: %#v", err, err2)
-			}
-
+		if err := g.settings.Save(ctx, q); err != nil {
 			return err
 		}
 	}
@@ -372,19 +429,13 @@ FROM
 		    label = $10,
 		    sports_event_id = $11,
 		    payout_config = $12,
+		    overtime_mode = $13,
 			modified = (now() at time zone 'utc')
-		WHERE id = $13
+		WHERE id = $14
 	`
 
-	if _, err := tx.ExecContext(ctx, query, g.ord, g.homeTeamName, pq.Array(g.homeNumbers), g.awayTeamName, pq.Array(g.awayNumbers), g.manualDraw, eventDate, g.rollover, g.state, g.label, g.bdlEventID, g.payoutConfig, g.id); err != nil {
-		if err2 := tx.Rollback(); err2 != nil {
-			return fmt.Errorf("error found: %#v. Another error found when trying to rollback: %#v", err, err2)
-		}
-
-		return err
-	}
-
-	return tx.Commit()
+	_, err := q.ExecContext(ctx, query, g.ord, g.homeTeamName, pq.Array(g.homeNumbers), g.awayTeamName, pq.Array(g.awayNumbers), g.manualDraw, eventDate, g.rollover, g.state, g.label, g.bdlEventID, g.payoutConfig, g.OvertimeMode(), g.id)
+	return err
 }
 
 // Settings will return the settings
@@ -546,6 +597,24 @@ func (g *Grid) LoadBDLEvent(ctx context.Context) error {
 	return nil
 }
 
+// loadWinnerData loads the number sets and score history needed to calculate the grid's winners for the config.
+// The sports event must already be loaded.
+func (g *Grid) loadWinnerData(ctx context.Context, config NumberSetConfig) error {
+	if config != NumberSetConfigStandard {
+		if err := g.LoadNumberSets(ctx); err != nil {
+			return err
+		}
+	}
+
+	if config == NumberSetConfigEvery && g.bdlEvent != nil {
+		if err := g.bdlEvent.LoadScoreHistory(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // NumbersAreDrawn checks if ALL required sets have numbers for the given config
 func (g *Grid) NumbersAreDrawn(config NumberSetConfig) bool {
 	setTypes := GetSetTypes(config)
@@ -573,71 +642,125 @@ func (g *Grid) NumbersAreDrawn(config NumberSetConfig) bool {
 	return true
 }
 
-// DrawAllNumbersRandom atomically draws random numbers for all required sets
-func (g *Grid) DrawAllNumbersRandom(ctx context.Context, config NumberSetConfig) error {
-	setTypes := GetSetTypes(config)
-	if setTypes == nil {
+// DrawAllNumbersRandom atomically draws and saves the numbers for all required sets, and adds them to the grid's draw
+// history. The numbers are derived from the grid's draw commitment, so that they can be verified once the seed is
+// revealed. A userID of 0 records the draw as automatic. ErrNumbersAlreadyDrawn is returned if the numbers were drawn
+// in the meantime.
+func (g *Grid) DrawAllNumbersRandom(ctx context.Context, config NumberSetConfig, userID int64) error {
+	if GetSetTypes(config) == nil {
 		return fmt.Errorf("invalid number set config: %s", config)
 	}
 
-	// For "standard" config, use legacy behavior
-	if config == NumberSetConfigStandard {
-		return g.SelectRandomNumbers()
+	if config == NumberSetConfigStandard && (g.homeNumbers != nil || g.awayNumbers != nil) {
+		return ErrNumbersAlreadyDrawn
 	}
 
 	tx, err := g.model.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("beginning transaction: %w", err)
 	}
+	defer func() { _ = tx.Rollback() }()
 
-	defer func() {
-		if err != nil {
-			_ = tx.Rollback()
-		}
-	}()
+	drawn, err := g.lockNumbersDrawn(ctx, tx, config)
+	if err != nil {
+		return err
+	}
 
-	newSets := make(map[NumberSetType]*GridNumberSet)
-	for _, setType := range setTypes {
-		ns := g.model.NewGridNumberSet(g.id, setType)
-		if err := ns.SelectRandomNumbers(); err != nil {
-			return fmt.Errorf("selecting random numbers for %s: %w", setType, err)
-		}
-		if err := ns.Save(ctx, tx); err != nil {
-			return fmt.Errorf("saving number set %s: %w", setType, err)
-		}
-		newSets[setType] = ns
+	if drawn {
+		return ErrNumbersAlreadyDrawn
+	}
+
+	if err := g.drawNumbersRandom(ctx, tx, config, userID, ""); err != nil {
+		return err
 	}
 
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("committing transaction: %w", err)
 	}
 
-	g.numberSets = newSets
 	return nil
 }
 
+// lockNumbersDrawn locks the grid row until tx ends and returns whether its numbers are drawn for the config. Draws
+// lock the row first so that when two race, e.g. a manager and the auto-draw scheduler, the second one sees the
+// numbers of the first instead of overwriting them.
+func (g *Grid) lockNumbersDrawn(ctx context.Context, tx *sql.Tx, config NumberSetConfig) (bool, error) {
+	const query = `
+		SELECT home_numbers IS NOT NULL OR away_numbers IS NOT NULL,
+		       EXISTS (SELECT 1 FROM grid_number_sets WHERE grid_id = grids.id)
+		FROM grids
+		WHERE id = $1
+		FOR UPDATE`
+	var hasNumbers, hasNumberSets bool
+	if err := tx.QueryRowContext(ctx, query, g.id).Scan(&hasNumbers, &hasNumberSets); err != nil {
+		return false, fmt.Errorf("locking grid: %w", err)
+	}
+
+	if config == NumberSetConfigStandard {
+		return hasNumbers, nil
+	}
+
+	return hasNumberSets, nil
+}
+
+// drawNumbersRandom draws and stores the grid's numbers within tx, and records the draw along with the seed it was
+// derived from. The grid row must be locked and its numbers not yet drawn.
+func (g *Grid) drawNumbersRandom(ctx context.Context, tx *sql.Tx, config NumberSetConfig, userID int64, reason string) error {
+	setTypes := GetSetTypes(config)
+
+	commitment, err := g.commitDraw(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	// For "standard" config, use the legacy homeNumbers/awayNumbers
+	if config == NumberSetConfigStandard {
+		home, away := commitment.Numbers(NumberSetTypeAll)
+
+		const query = `
+			UPDATE grids
+			SET home_numbers = $1,
+			    away_numbers = $2,
+			    manual_draw = false,
+			    modified = (now() at time zone 'utc')
+			WHERE id = $3`
+		if _, err := tx.ExecContext(ctx, query, pq.Array(home), pq.Array(away), g.id); err != nil {
+			return fmt.Errorf("saving numbers: %w", err)
+		}
+
+		g.homeNumbers, g.awayNumbers = home, away
+		g.manualDraw = false
+	} else {
+		newSets := make(map[NumberSetType]*GridNumberSet)
+		for _, setType := range setTypes {
+			ns := g.model.NewGridNumberSet(g.id, setType)
+			ns.homeNumbers, ns.awayNumbers = commitment.Numbers(setType)
+			if err := ns.Save(ctx, tx); err != nil {
+				return fmt.Errorf("saving number set %s: %w", setType, err)
+			}
+			newSets[setType] = ns
+		}
+
+		g.numberSets = newSets
+	}
+
+	return g.recordDraw(ctx, tx, config, userID, GridDrawMethodRandom, reason, commitment.seed)
+}
+
 // NumberSetInput represents input for a single number set
 type NumberSetInput struct {
 	HomeNumbers []int `json:"homeTeamNumbers"`
 	AwayNumbers []int `json:"awayTeamNumbers"`
 }
 
-// DrawAllNumbersManual atomically sets manual numbers for all required sets
-func (g *Grid) DrawAllNumbersManual(ctx context.Context, config NumberSetConfig, numberSets map[NumberSetType]NumberSetInput) error {
+// DrawAllNumbersManual atomically sets manual numbers for all required sets, and adds them to the grid's draw history
+// as drawn by the user. ErrNumbersAlreadyDrawn is returned if the numbers were drawn in the meantime.
+func (g *Grid) DrawAllNumbersManual(ctx context.Context, config NumberSetConfig, numberSets map[NumberSetType]NumberSetInput, userID int64) error {
 	setTypes := GetSetTypes(config)
 	if setTypes == nil {
 		return fmt.Errorf("invalid number set config: %s", config)
 	}
 
-	// For "standard" config, use legacy behavior with "all" set
-	if config == NumberSetConfigStandard {
-		input, ok := numberSets[NumberSetTypeAll]
-		if !ok {
-			return fmt.Errorf("missing 'all' number set for 'standard' config")
-		}
-		return g.SetManualNumbers(input.HomeNumbers, input.AwayNumbers)
-	}
-
 	// Validate all required sets are provided
 	for _, setType := range setTypes {
 		if _, ok := numberSets[setType]; !ok {
@@ -649,31 +772,59 @@ func (g *Grid) DrawAllNumbersManual(ctx context.Context, config NumberSetConfig,
 	if err != nil {
 		return fmt.Errorf("beginning transaction: %w", err)
 	}
+	defer func() { _ = tx.Rollback() }()
 
-	defer func() {
-		if err != nil {
-			_ = tx.Rollback()
+	drawn, err := g.lockNumbersDrawn(ctx, tx, config)
+	if err != nil {
+		return err
+	}
+
+	if drawn {
+		return ErrNumbersAlreadyDrawn
+	}
+
+	// For "standard" config, use legacy behavior with "all" set
+	if config == NumberSetConfigStandard {
+		input := numberSets[NumberSetTypeAll]
+		if err := g.SetManualNumbers(input.HomeNumbers, input.AwayNumbers); err != nil {
+			return err
 		}
-	}()
 
-	newSets := make(map[NumberSetType]*GridNumberSet)
-	for _, setType := range setTypes {
-		input := numberSets[setType]
-		ns := g.model.NewGridNumberSet(g.id, setType)
-		if err := ns.SetNumbers(input.HomeNumbers, input.AwayNumbers); err != nil {
-			return fmt.Errorf("setting numbers for %s: %w", setType, err)
+		const query = `
+			UPDATE grids
+			SET home_numbers = $1,
+			    away_numbers = $2,
+			    manual_draw = true,
+			    modified = (now() at time zone 'utc')
+			WHERE id = $3`
+		if _, err := tx.ExecContext(ctx, query, pq.Array(g.homeNumbers), pq.Array(g.awayNumbers), g.id); err != nil {
+			return fmt.Errorf("saving numbers: %w", err)
 		}
-		if err := ns.Save(ctx, tx); err != nil {
-			return fmt.Errorf("saving number set %s: %w", setType, err)
+	} else {
+		newSets := make(map[NumberSetType]*GridNumberSet)
+		for _, setType := range setTypes {
+			input := numberSets[setType]
+			ns := g.model.NewGridNumberSet(g.id, setType)
+			if err := ns.SetNumbers(input.HomeNumbers, input.AwayNumbers); err != nil {
+				return fmt.Errorf("setting numbers for %s: %w", setType, err)
+			}
+			if err := ns.Save(ctx, tx); err != nil {
+				return fmt.Errorf("saving number set %s: %w", setType, err)
+			}
+			newSets[setType] = ns
 		}
-		newSets[setType] = ns
+
+		g.numberSets = newSets
+	}
+
+	if err := g.recordDraw(ctx, tx, config, userID, GridDrawMethodManual, "", nil); err != nil {
+		return err
 	}
 
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("committing transaction: %w", err)
 	}
 
-	g.numberSets = newSets
 	return nil
 }
 
@@ -703,7 +854,7 @@ func (m *Model) gridByRow(scan scanFunc) (*Grid, error) {
 	var eventDate *time.Time
 	var payoutConfig *string
 
-	if err := scan(&grid.id, &grid.poolID, &grid.ord, &grid.label, &grid.homeTeamName, pq.Array(&homeNumbers), &grid.awayTeamName, pq.Array(&awayNumbers), &eventDate, &grid.rollover, &grid.state, &grid.created, &grid.modified, &grid.manualDraw, &grid.bdlEventID, &payoutConfig); err != nil {
+	if err := scan(&grid.id, &grid.poolID, &grid.ord, &grid.label, &grid.homeTeamName, pq.Array(&homeNumbers), &grid.awayTeamName, pq.Array(&awayNumbers), &eventDate, &grid.rollover, &grid.state, &grid.created, &grid.modified, &grid.manualDraw, &grid.bdlEventID, &payoutConfig, &grid.overtimeMode); err != nil {
 		return nil, err
 	}
 
@@ -752,4 +903,5 @@ const gridColumns = `
 	modified,
 	manual_draw,
 	sports_event_id,
-	payout_config`
+	payout_config,
+	overtime_mode`
@@ -0,0 +1,151 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// MaxAutoDrawMinutes is the furthest ahead of the event's start that numbers can be drawn automatically
+const MaxAutoDrawMinutes = 60 * 24
+
+// AutoDrawTrigger is when a grid's numbers are drawn automatically
+type AutoDrawTrigger string
+
+// AutoDrawTrigger constants
+const (
+	// AutoDrawTriggerLock draws the numbers once the pool locks
+	AutoDrawTriggerLock AutoDrawTrigger = "lock"
+	// AutoDrawTriggerKickoff draws the numbers a number of minutes before the linked event starts
+	AutoDrawTriggerKickoff AutoDrawTrigger = "kickoff"
+)
+
+// IsValid returns true if the trigger is known
+func (t AutoDrawTrigger) IsValid() bool {
+	return t == AutoDrawTriggerLock || t == AutoDrawTriggerKickoff
+}
+
+// GridAutoDraw is a schedule for drawing a grid's numbers without the manager having to
+type GridAutoDraw struct {
+	Trigger AutoDrawTrigger `json:"trigger"`
+	// MinutesBefore is how long before the event starts the numbers are drawn. It is only used by AutoDrawTriggerKickoff.
+	MinutesBefore int `json:"minutesBefore"`
+}
+
+// DueAutoDraw is a grid whose numbers are due to be drawn automatically
+type DueAutoDraw struct {
+	PoolToken string
+	GridID    int64
+}
+
+// AutoDraw returns the loaded auto-draw schedule, or nil if the numbers are drawn by the manager
+func (g *Grid) AutoDraw() *GridAutoDraw {
+	return g.autoDraw
+}
+
+// LoadAutoDraw will load the auto-draw schedule
+func (g *Grid) LoadAutoDraw(ctx context.Context) error {
+	autoDraw := &GridAutoDraw{}
+	row := g.model.DB.QueryRowContext(ctx, "SELECT draw_on, minutes_before FROM grid_auto_draws WHERE grid_id = $1", g.id)
+	if err := row.Scan(&autoDraw.Trigger, &autoDraw.MinutesBefore); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			g.autoDraw = nil
+			return nil
+		}
+
+		return fmt.Errorf("loading auto-draw: %w", err)
+	}
+
+	g.autoDraw = autoDraw
+	return nil
+}
+
+// SaveAutoDraw will schedule the grid's numbers to be drawn automatically. A nil schedule leaves the draw to the
+// manager.
+func (g *Grid) SaveAutoDraw(ctx context.Context, autoDraw *GridAutoDraw) error {
+	if autoDraw == nil {
+		if _, err := g.model.DB.ExecContext(ctx, "DELETE FROM grid_auto_draws WHERE grid_id = $1", g.id); err != nil {
+			return fmt.Errorf("removing auto-draw: %w", err)
+		}
+
+		g.autoDraw = nil
+		return nil
+	}
+
+	if !autoDraw.Trigger.IsValid() {
+		return fmt.Errorf("invalid auto-draw trigger: %s", autoDraw.Trigger)
+	}
+
+	if autoDraw.MinutesBefore < 0 || autoDraw.MinutesBefore > MaxAutoDrawMinutes {
+		return fmt.Errorf("auto-draw must be between 0 and %d minutes before the event", MaxAutoDrawMinutes)
+	}
+
+	const query = `
+		INSERT INTO grid_auto_draws (grid_id, draw_on, minutes_before)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (grid_id) DO UPDATE
+		SET draw_on = EXCLUDED.draw_on,
+		    minutes_before = EXCLUDED.minutes_before,
+		    modified = (NOW() AT TIME ZONE 'utc')`
+	if _, err := g.model.DB.ExecContext(ctx, query, g.id, autoDraw.Trigger, autoDraw.MinutesBefore); err != nil {
+		return fmt.Errorf("saving auto-draw: %w", err)
+	}
+
+	g.autoDraw = autoDraw
+	return nil
+}
+
+// DueAutoDraws returns the grids whose numbers have not been drawn and whose auto-draw time has passed. Grids scheduled
+// to draw before kickoff are skipped if they are not linked to an event.
+func (m *Model) DueAutoDraws(ctx context.Context) ([]DueAutoDraw, error) {
+	const query = `
+		SELECT pools.token, grids.id
+		FROM grid_auto_draws
+		INNER JOIN grids ON grid_auto_draws.grid_id = grids.id
+		INNER JOIN pools ON grids.pool_id = pools.id
+		LEFT JOIN sports_events ON grids.sports_event_id = sports_events.id
+		WHERE grids.state = 'active' AND
+		      NOT pools.archived AND
+		      grids.home_numbers IS NULL AND
+		      NOT EXISTS (SELECT 1 FROM grid_number_sets WHERE grid_number_sets.grid_id = grids.id) AND
+		      CASE grid_auto_draws.draw_on
+		          WHEN 'lock' THEN pools.locks <= (NOW() AT TIME ZONE 'utc')
+		          ELSE sports_events.event_date - make_interval(mins => grid_auto_draws.minutes_before) <= (NOW() AT TIME ZONE 'utc')
+		      END
+		ORDER BY grids.id`
+	rows, err := m.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("loading due auto-draws: %w", err)
+	}
+	defer rows.Close()
+
+	due := make([]DueAutoDraw, 0)
+	for rows.Next() {
+		var d DueAutoDraw
+		if err := rows.Scan(&d.PoolToken, &d.GridID); err != nil {
+			return nil, fmt.Errorf("scanning due auto-draw: %w", err)
+		}
+
+		due = append(due, d)
+	}
+
+	return due, rows.Err()
+}
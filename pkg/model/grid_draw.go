@@ -0,0 +1,201 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// RedrawReasonMaxLength is the maximum length of the reason a manager must give for redrawing numbers
+const RedrawReasonMaxLength = 200
+
+// GridDrawMethod is how the numbers of a draw were chosen
+type GridDrawMethod string
+
+// methods of drawing numbers
+const (
+	GridDrawMethodRandom GridDrawMethod = "random"
+	GridDrawMethodManual GridDrawMethod = "manual"
+)
+
+// GridDraw is a record of the numbers drawn for a grid
+type GridDraw struct {
+	ID int64 `json:"id"`
+	// UserID is who drew the numbers. It is nil if they were drawn automatically.
+	UserID *int64         `json:"userId"`
+	Method GridDrawMethod `json:"method"`
+	// Reason is why the numbers were redrawn. It is empty for the first draw.
+	Reason     string                         `json:"reason,omitempty"`
+	NumberSets map[NumberSetType]*DrawNumbers `json:"numberSets"`
+	// Seed is the revealed seed of a random draw, which can be checked against its numbers
+	Seed    string    `json:"seed,omitempty"`
+	Created time.Time `json:"created"`
+}
+
+// drawnNumbers returns the grid's current numbers for each set type of the config
+func (g *Grid) drawnNumbers(config NumberSetConfig) map[NumberSetType]*DrawNumbers {
+	if config == NumberSetConfigStandard {
+		return map[NumberSetType]*DrawNumbers{
+			NumberSetTypeAll: {HomeNumbers: g.homeNumbers, AwayNumbers: g.awayNumbers},
+		}
+	}
+
+	numbers := make(map[NumberSetType]*DrawNumbers)
+	for _, setType := range GetSetTypes(config) {
+		if ns, ok := g.numberSets[setType]; ok && ns.HasNumbers() {
+			numbers[setType] = &DrawNumbers{HomeNumbers: ns.homeNumbers, AwayNumbers: ns.awayNumbers}
+		}
+	}
+
+	return numbers
+}
+
+// recordDraw will add the grid's current numbers to its draw history. It must be called in the transaction that saved
+// the numbers, so that numbers are never drawn without a record of them. The seed is the one a random draw was derived
+// from, and is nil for numbers entered by hand. A userID of 0 records the draw as automatic, and the reason should only
+// be given for redraws.
+func (g *Grid) recordDraw(ctx context.Context, tx *sql.Tx, config NumberSetConfig, userID int64, method GridDrawMethod, reason string, seed []byte) error {
+	var userIDPtr *int64
+	if userID > 0 {
+		userIDPtr = &userID
+	}
+
+	var reasonPtr *string
+	if reason != "" {
+		reasonPtr = &reason
+	}
+
+	// a nil slice is not sent as NULL
+	var seedValue interface{}
+	if seed != nil {
+		seedValue = seed
+	}
+
+	numberSets, err := json.Marshal(g.drawnNumbers(config))
+	if err != nil {
+		return fmt.Errorf("encoding number sets: %w", err)
+	}
+
+	const query = `
+		INSERT INTO grid_draws (grid_id, user_id, method, reason, number_sets, seed)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+	if _, err := tx.ExecContext(ctx, query, g.id, userIDPtr, method, reasonPtr, numberSets, seedValue); err != nil {
+		return fmt.Errorf("recording draw: %w", err)
+	}
+
+	return nil
+}
+
+// Draws returns the draw history of the grid, oldest first
+func (g *Grid) Draws(ctx context.Context) ([]*GridDraw, error) {
+	const query = `
+		SELECT id, user_id, method, reason, number_sets, seed, created
+		FROM grid_draws
+		WHERE grid_id = $1
+		ORDER BY id`
+	rows, err := g.model.DB.QueryContext(ctx, query, g.id)
+	if err != nil {
+		return nil, fmt.Errorf("loading draws: %w", err)
+	}
+	defer rows.Close()
+
+	draws := make([]*GridDraw, 0)
+	for rows.Next() {
+		draw := &GridDraw{}
+		var reason *string
+		var numberSets, seed []byte
+		if err := rows.Scan(&draw.ID, &draw.UserID, &draw.Method, &reason, &numberSets, &seed, &draw.Created); err != nil {
+			return nil, fmt.Errorf("scanning draw: %w", err)
+		}
+
+		if err := json.Unmarshal(numberSets, &draw.NumberSets); err != nil {
+			return nil, fmt.Errorf("decoding number sets of draw %d: %w", draw.ID, err)
+		}
+
+		if reason != nil {
+			draw.Reason = *reason
+		}
+
+		if seed != nil {
+			draw.Seed = hex.EncodeToString(seed)
+		}
+
+		draw.Created = draw.Created.In(locationNewYork)
+		draws = append(draws, draw)
+	}
+
+	return draws, rows.Err()
+}
+
+// Redraw will clear the grid's numbers and draw them again at random. The numbers are derived from the seed the
+// earlier draw committed to, mixed with the same entropy, so the redraw can be verified like the first draw. The
+// redraw is added to the grid's history with the user who made it and their reason, after the earlier draw and its
+// revealed seed.
+func (g *Grid) Redraw(ctx context.Context, config NumberSetConfig, userID int64, reason string) error {
+	if GetSetTypes(config) == nil {
+		return fmt.Errorf("invalid number set config: %s", config)
+	}
+
+	tx, err := g.model.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	drawn, err := g.lockNumbersDrawn(ctx, tx, config)
+	if err != nil {
+		return err
+	}
+
+	if !drawn {
+		return ErrNumbersNotDrawn
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM grid_number_sets WHERE grid_id = $1", g.id); err != nil {
+		return fmt.Errorf("clearing number sets: %w", err)
+	}
+
+	const query = "UPDATE grids SET home_numbers = NULL, away_numbers = NULL, manual_draw = false WHERE id = $1"
+	if _, err := tx.ExecContext(ctx, query, g.id); err != nil {
+		return fmt.Errorf("clearing numbers: %w", err)
+	}
+
+	if err := g.nextDrawCommitment(ctx, tx); err != nil {
+		return err
+	}
+
+	g.homeNumbers = nil
+	g.awayNumbers = nil
+	g.manualDraw = false
+	g.numberSets = make(map[NumberSetType]*GridNumberSet)
+
+	if err := g.drawNumbersRandom(ctx, tx, config, userID, reason); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return nil
+}
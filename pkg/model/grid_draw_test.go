@@ -0,0 +1,45 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestGridDrawnNumbers(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	home := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	away := []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}
+
+	standard := &Grid{homeNumbers: home, awayNumbers: away}
+	g.Expect(standard.drawnNumbers(NumberSetConfigStandard)).Should(gomega.Equal(map[NumberSetType]*DrawNumbers{
+		NumberSetTypeAll: {HomeNumbers: home, AwayNumbers: away},
+	}))
+
+	// sets without numbers are left out
+	multi := &Grid{numberSets: map[NumberSetType]*GridNumberSet{
+		NumberSetTypeQ1: {setType: NumberSetTypeQ1, homeNumbers: home, awayNumbers: away},
+		NumberSetTypeQ2: {setType: NumberSetTypeQ2},
+	}}
+	g.Expect(multi.drawnNumbers(NumberSetConfig1234)).Should(gomega.Equal(map[NumberSetType]*DrawNumbers{
+		NumberSetTypeQ1: {HomeNumbers: home, AwayNumbers: away},
+	}))
+}
@@ -27,30 +27,86 @@ const (
 	GridTypeStd100  GridType = "std100"
 	GridTypeStd50   GridType = "std50"
 	GridTypeStd25   GridType = "std25"
+	GridTypeStd20   GridType = "std20"
+	GridTypeStd16   GridType = "std16"
+	GridTypeStd10   GridType = "std10"
 	GridTypeRoll100 GridType = "roll100"
 )
 
-var validGridTypes = map[GridType]bool{
-	GridTypeStd100:  true,
-	GridTypeStd50:   true,
-	GridTypeStd25:   true,
-	GridTypeRoll100: true,
+// scoreDigits is the number of digits a score can end in. Every board is drawn with ten numbers per team,
+// which are spread across the columns and rows of the board.
+const scoreDigits = 10
+
+// GridTypeInfo describes the layout of a board. Columns are the home team numbers across the top and rows are the
+// away team numbers down the side. HomeCells and AwayCells map each of the ten drawn number positions to the column
+// or row that covers it.
+type GridTypeInfo struct {
+	Key         GridType `json:"key"`
+	Description string   `json:"description"`
+	Columns     int      `json:"columns"`
+	Rows        int      `json:"rows"`
+	HomeCells   []int    `json:"homeCells"`
+	AwayCells   []int    `json:"awayCells"`
+}
+
+// newGridTypeInfo returns the info for a board with the given dimensions. The number positions are spread as evenly
+// as possible, so a board with four columns has them covering 3, 2, 3 and 2 numbers.
+func newGridTypeInfo(key GridType, description string, columns, rows int) GridTypeInfo {
+	return GridTypeInfo{
+		Key:         key,
+		Description: description,
+		Columns:     columns,
+		Rows:        rows,
+		HomeCells:   digitCells(columns),
+		AwayCells:   digitCells(rows),
+	}
+}
+
+// digitCells maps each number position to one of n cells
+func digitCells(n int) []int {
+	cells := make([]int, scoreDigits)
+	for pos := range cells {
+		cells[pos] = pos * n / scoreDigits
+	}
+
+	return cells
+}
+
+// gridTypeInfos are the boards that can be chosen, in the order they are offered
+var gridTypeInfos = []GridTypeInfo{
+	newGridTypeInfo(GridTypeStd100, "Standard, 100 squares", 10, 10),
+	newGridTypeInfo(GridTypeStd50, "Standard, 50 squares", 5, 10),
+	newGridTypeInfo(GridTypeStd25, "Standard, 25 squares", 5, 5),
+	newGridTypeInfo(GridTypeStd20, "Standard, 20 squares", 5, 4),
+	newGridTypeInfo(GridTypeStd16, "Standard, 16 squares", 4, 4),
+	newGridTypeInfo(GridTypeStd10, "Standard, 10 squares", 5, 2),
+	newGridTypeInfo(GridTypeRoll100, "Rollover, 100 squares", 10, 10),
+}
+
+var gridTypeInfoByKey = map[GridType]*GridTypeInfo{}
+
+func init() {
+	for i := range gridTypeInfos {
+		gridTypeInfoByKey[gridTypeInfos[i].Key] = &gridTypeInfos[i]
+	}
 }
 
 // ErrInvalidGridType is an error when a string has been typecast to a grid type that does not exist
 var ErrInvalidGridType = errors.New("internal/model: invalid grid type")
 
+// Info returns the layout of the grid type. Unknown grid types are treated as a standard 100 square board.
+func (g GridType) Info() GridTypeInfo {
+	if info, ok := gridTypeInfoByKey[g]; ok {
+		return *info
+	}
+
+	return *gridTypeInfoByKey[GridTypeStd100]
+}
+
 // Description returns a human friendly notes of the grid type
 func (g GridType) Description() string {
-	switch g {
-	case GridTypeStd100:
-		return "Standard, 100 squares"
-	case GridTypeStd50:
-		return "Standard, 50 squares"
-	case GridTypeStd25:
-		return "Standard, 25 squares"
-	case GridTypeRoll100:
-		return "Rollover, 100 squares"
+	if info, ok := gridTypeInfoByKey[g]; ok {
+		return info.Description
 	}
 
 	return string(g)
@@ -58,19 +114,24 @@ func (g GridType) Description() string {
 
 // Squares will return the number of squares in a grid
 func (g GridType) Squares() int {
-	switch g {
-	case GridTypeStd25:
-		return 25
-	case GridTypeStd50:
-		return 50
-	default:
-		return 100
+	info := g.Info()
+	return info.Columns * info.Rows
+}
+
+// SquareID returns the square covering the home and away number positions, or 0 if either position is not on the
+// board. Squares are numbered from 1, left to right and then top to bottom.
+func (g GridType) SquareID(homePos, awayPos int) int {
+	if homePos < 0 || homePos >= scoreDigits || awayPos < 0 || awayPos >= scoreDigits {
+		return 0
 	}
+
+	info := g.Info()
+	return info.AwayCells[awayPos]*info.Columns + info.HomeCells[homePos] + 1
 }
 
 // IsValidGridType will check to see if the string is a valid grid type. If it's valid, nil is returned.
 func IsValidGridType(val string) error {
-	if _, ok := validGridTypes[GridType(val)]; !ok {
+	if _, ok := gridTypeInfoByKey[GridType(val)]; !ok {
 		return ErrInvalidGridType
 	}
 
@@ -79,5 +140,17 @@ func IsValidGridType(val string) error {
 
 // GridTypes returns a list of allowed grid types
 func GridTypes() []GridType {
-	return []GridType{GridTypeStd100, GridTypeStd50, GridTypeStd25, GridTypeRoll100}
+	gridTypes := make([]GridType, len(gridTypeInfos))
+	for i, info := range gridTypeInfos {
+		gridTypes[i] = info.Key
+	}
+
+	return gridTypes
+}
+
+// GridTypeInfos returns the layout of every allowed grid type
+func GridTypeInfos() []GridTypeInfo {
+	infos := make([]GridTypeInfo, len(gridTypeInfos))
+	copy(infos, gridTypeInfos)
+	return infos
 }
@@ -0,0 +1,75 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestGridTypeSquares(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Expect(GridTypeStd100.Squares()).Should(gomega.Equal(100))
+	g.Expect(GridTypeStd50.Squares()).Should(gomega.Equal(50))
+	g.Expect(GridTypeStd25.Squares()).Should(gomega.Equal(25))
+	g.Expect(GridTypeStd20.Squares()).Should(gomega.Equal(20))
+	g.Expect(GridTypeStd16.Squares()).Should(gomega.Equal(16))
+	g.Expect(GridTypeStd10.Squares()).Should(gomega.Equal(10))
+	g.Expect(GridTypeRoll100.Squares()).Should(gomega.Equal(100))
+	g.Expect(GridType("bogus").Squares()).Should(gomega.Equal(100))
+}
+
+func TestGridTypeInfo(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	info := GridTypeStd16.Info()
+	g.Expect(info.Columns).Should(gomega.Equal(4))
+	g.Expect(info.Rows).Should(gomega.Equal(4))
+	g.Expect(info.HomeCells).Should(gomega.Equal([]int{0, 0, 0, 1, 1, 2, 2, 2, 3, 3}))
+
+	info = GridTypeStd50.Info()
+	g.Expect(info.HomeCells).Should(gomega.Equal([]int{0, 0, 1, 1, 2, 2, 3, 3, 4, 4}))
+	g.Expect(info.AwayCells).Should(gomega.Equal([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}))
+
+	g.Expect(GridTypeStd20.Description()).Should(gomega.Equal("Standard, 20 squares"))
+	g.Expect(GridType("bogus").Description()).Should(gomega.Equal("bogus"))
+}
+
+func TestGridTypeSquareID(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Expect(GridTypeStd100.SquareID(0, 0)).Should(gomega.Equal(1))
+	g.Expect(GridTypeStd100.SquareID(9, 9)).Should(gomega.Equal(100))
+	g.Expect(GridTypeStd20.SquareID(9, 9)).Should(gomega.Equal(20))
+	g.Expect(GridTypeStd20.SquareID(0, 5)).Should(gomega.Equal(11))
+	g.Expect(GridTypeStd20.SquareID(10, 0)).Should(gomega.Equal(0))
+	g.Expect(GridTypeStd20.SquareID(0, -1)).Should(gomega.Equal(0))
+}
+
+func TestIsValidGridType(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	for _, gridType := range GridTypes() {
+		g.Expect(IsValidGridType(string(gridType))).Should(gomega.Succeed())
+	}
+
+	g.Expect(GridTypes()).Should(gomega.HaveLen(len(GridTypeInfos())))
+	g.Expect(IsValidGridType("std99")).Should(gomega.MatchError(ErrInvalidGridType))
+}
@@ -36,6 +36,13 @@ const (
 	NumberSetConfigHF NumberSetConfig = "hf"
 	// NumberSetConfigH4 means Half, 4th
 	NumberSetConfigH4 NumberSetConfig = "h4"
+	// NumberSetConfigEvery means one set of numbers with a winner on every score change. It is only valid for leagues
+	// with scoring plays, see SportsLeague.HasScoringPlays.
+	NumberSetConfigEvery NumberSetConfig = "every"
+	// NumberSetConfig357F means through the 3rd, 5th and 7th innings, Final
+	NumberSetConfig357F NumberSetConfig = "357f"
+	// NumberSetConfig5F means through the 5th inning, Final
+	NumberSetConfig5F NumberSetConfig = "5f"
 )
 
 // NumberSetType represents an individual number set identifier
@@ -56,6 +63,14 @@ const (
 	NumberSetTypeHalf NumberSetType = "half"
 	// NumberSetTypeFinal is for final score
 	NumberSetTypeFinal NumberSetType = "final"
+	// NumberSetTypeOT is for the score at the end of overtime, when overtime is paid separately
+	NumberSetTypeOT NumberSetType = "ot"
+	// NumberSetTypeI3 is for the score through the 3rd inning
+	NumberSetTypeI3 NumberSetType = "i3"
+	// NumberSetTypeI5 is for the score through the 5th inning
+	NumberSetTypeI5 NumberSetType = "i5"
+	// NumberSetTypeI7 is for the score through the 7th inning
+	NumberSetTypeI7 NumberSetType = "i7"
 )
 
 // NumberSetConfigInfo contains metadata for a number set configuration
@@ -84,23 +99,36 @@ var validNumberSetConfigs = []NumberSetConfigInfo{
 		Label:    "1st, 2nd, 3rd, Final",
 		SetTypes: []NumberSetType{NumberSetTypeQ1, NumberSetTypeQ2, NumberSetTypeQ3, NumberSetTypeFinal},
 	},
+	{
+		Key:      NumberSetConfig1234,
+		Label:    "1st, 2nd, 3rd, 4th",
+		SetTypes: []NumberSetType{NumberSetTypeQ1, NumberSetTypeQ2, NumberSetTypeQ3, NumberSetTypeQ4},
+	},
 	{
 		Key:      NumberSetConfigHF,
 		Label:    "Half, Final",
 		SetTypes: []NumberSetType{NumberSetTypeHalf, NumberSetTypeFinal},
 	},
-	/*
-		{
-			Key:      NumberSetConfigQ1234,
-			Label:    "1st, 2nd, 3rd, 4th",
-			SetTypes: []NumberSetType{NumberSetTypeQ1, NumberSetTypeQ2, NumberSetTypeQ3, NumberSetTypeQ4},
-		},
-		{
-			Key:      NumberSetConfigH4,
-			Label:    "Half, 4th",
-			SetTypes: []NumberSetType{NumberSetTypeHalf, NumberSetTypeQ4},
-		},
-	*/
+	{
+		Key:      NumberSetConfigH4,
+		Label:    "Half, 4th",
+		SetTypes: []NumberSetType{NumberSetTypeHalf, NumberSetTypeQ4},
+	},
+	{
+		Key:      NumberSetConfigEvery,
+		Label:    "Every Score",
+		SetTypes: []NumberSetType{NumberSetTypeAll},
+	},
+	{
+		Key:      NumberSetConfig357F,
+		Label:    "3rd, 5th, 7th, Final",
+		SetTypes: []NumberSetType{NumberSetTypeI3, NumberSetTypeI5, NumberSetTypeI7, NumberSetTypeFinal},
+	},
+	{
+		Key:      NumberSetConfig5F,
+		Label:    "5th, Final",
+		SetTypes: []NumberSetType{NumberSetTypeI5, NumberSetTypeFinal},
+	},
 }
 
 // numberSetTypeInfos contains metadata for all number set types
@@ -112,6 +140,10 @@ var numberSetTypeInfos = map[NumberSetType]NumberSetTypeInfo{
 	NumberSetTypeQ4:    {Key: NumberSetTypeQ4, Label: "4th", LongLabel: "4th Quarter"},
 	NumberSetTypeHalf:  {Key: NumberSetTypeHalf, Label: "Half", LongLabel: "Halftime"},
 	NumberSetTypeFinal: {Key: NumberSetTypeFinal, Label: "Final", LongLabel: "Final"},
+	NumberSetTypeOT:    {Key: NumberSetTypeOT, Label: "OT", LongLabel: "Overtime"},
+	NumberSetTypeI3:    {Key: NumberSetTypeI3, Label: "3rd", LongLabel: "3rd Inning"},
+	NumberSetTypeI5:    {Key: NumberSetTypeI5, Label: "5th", LongLabel: "5th Inning"},
+	NumberSetTypeI7:    {Key: NumberSetTypeI7, Label: "7th", LongLabel: "7th Inning"},
 }
 
 // ValidNumberSetConfigs returns all valid number set configurations with metadata
@@ -152,10 +184,18 @@ func IsValidNumberSetType(setType string) bool {
 
 // IsValidNumberSetConfigForLeague returns true if the config is valid for the given sports league
 func IsValidNumberSetConfigForLeague(config NumberSetConfig, league SportsLeague) bool {
-	// NCAAB uses halves, not quarters, so "1st, 2nd, 3rd, Final" is not valid
-	if league == SportsLeagueNCAAB && config == NumberSetConfig123F {
+	// paying out on every score needs every score, which is only known from the league's scoring plays
+	if config == NumberSetConfigEvery && !league.HasScoringPlays() {
 		return false
 	}
+
+	// a config is only valid if the league plays every period it pays out on, e.g. leagues that play halves
+	// do not have quarters and only baseball has innings
+	for _, setType := range GetSetTypes(config) {
+		if !league.HasPeriod(setType) {
+			return false
+		}
+	}
 	return IsValidNumberSetConfig(string(config))
 }
 
@@ -170,6 +210,30 @@ func ValidNumberSetConfigsForLeague(league SportsLeague) []NumberSetConfigInfo {
 	return configs
 }
 
+// UsesQuarters returns true if any of the config's periods is an individual quarter
+func (n NumberSetConfig) UsesQuarters() bool {
+	for _, setType := range GetSetTypes(n) {
+		switch setType {
+		case NumberSetTypeQ1, NumberSetTypeQ2, NumberSetTypeQ3, NumberSetTypeQ4:
+			return true
+		}
+	}
+	return false
+}
+
+// Inning returns the inning a baseball checkpoint is scored through, or 0 if the set type is not an inning
+func (n NumberSetType) Inning() int {
+	switch n {
+	case NumberSetTypeI3:
+		return 3
+	case NumberSetTypeI5:
+		return 5
+	case NumberSetTypeI7:
+		return 7
+	}
+	return 0
+}
+
 // LongLabel returns the long descriptive label for a number set type
 func (n NumberSetType) LongLabel() string {
 	if info, ok := numberSetTypeInfos[n]; ok {
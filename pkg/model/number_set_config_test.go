@@ -31,6 +31,10 @@ func TestIsValidNumberSetConfig(t *testing.T) {
 	g.Expect(IsValidNumberSetConfig("123f")).Should(gomega.BeTrue())
 	g.Expect(IsValidNumberSetConfig("1234f")).Should(gomega.BeFalse())
 	g.Expect(IsValidNumberSetConfig("hf")).Should(gomega.BeTrue())
+	g.Expect(IsValidNumberSetConfig("every")).Should(gomega.BeTrue())
+	g.Expect(IsValidNumberSetConfig("1234")).Should(gomega.BeTrue())
+	g.Expect(IsValidNumberSetConfig("h4")).Should(gomega.BeTrue())
+	g.Expect(IsValidNumberSetConfig("q1234")).Should(gomega.BeFalse())
 
 	// Invalid configs
 	g.Expect(IsValidNumberSetConfig("")).Should(gomega.BeFalse())
@@ -71,6 +75,18 @@ func TestGetSetTypes(t *testing.T) {
 	setTypes = GetSetTypes(NumberSetConfigHF)
 	g.Expect(setTypes).Should(gomega.Equal([]NumberSetType{NumberSetTypeHalf, NumberSetTypeFinal}))
 
+	// 1234 config returns all 4 quarters
+	setTypes = GetSetTypes(NumberSetConfig1234)
+	g.Expect(setTypes).Should(gomega.Equal([]NumberSetType{NumberSetTypeQ1, NumberSetTypeQ2, NumberSetTypeQ3, NumberSetTypeQ4}))
+
+	// h4 config returns half + 4th
+	setTypes = GetSetTypes(NumberSetConfigH4)
+	g.Expect(setTypes).Should(gomega.Equal([]NumberSetType{NumberSetTypeHalf, NumberSetTypeQ4}))
+
+	// every config uses a single set of numbers
+	setTypes = GetSetTypes(NumberSetConfigEvery)
+	g.Expect(setTypes).Should(gomega.Equal([]NumberSetType{NumberSetTypeAll}))
+
 	// Invalid config returns nil
 	setTypes = GetSetTypes(NumberSetConfig("invalid"))
 	g.Expect(setTypes).Should(gomega.BeNil())
@@ -80,7 +96,7 @@ func TestValidNumberSetConfigs(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
 	configs := ValidNumberSetConfigs()
-	g.Expect(len(configs)).Should(gomega.Equal(3))
+	g.Expect(len(configs)).Should(gomega.Equal(8))
 
 	// Check first config is "standard"
 	g.Expect(configs[0].Key).Should(gomega.Equal(NumberSetConfigStandard))
@@ -92,7 +108,7 @@ func TestNumberSetTypeInfos(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
 	infos := NumberSetTypeInfos()
-	g.Expect(len(infos)).Should(gomega.Equal(7))
+	g.Expect(len(infos)).Should(gomega.Equal(11))
 
 	// Check q1 info
 	q1Info := infos[NumberSetTypeQ1]
@@ -112,6 +128,7 @@ func TestNumberSetTypeLongLabel(t *testing.T) {
 	g.Expect(NumberSetTypeHalf.LongLabel()).Should(gomega.Equal("Halftime"))
 	g.Expect(NumberSetTypeFinal.LongLabel()).Should(gomega.Equal("Final"))
 	g.Expect(NumberSetTypeAll.LongLabel()).Should(gomega.Equal("Final"))
+	g.Expect(NumberSetTypeOT.LongLabel()).Should(gomega.Equal("Overtime"))
 
 	// Unknown type returns the type string as fallback
 	unknown := NumberSetType("unknown")
@@ -178,6 +195,28 @@ func TestIsValidNumberSetConfigForLeague(t *testing.T) {
 	g.Expect(IsValidNumberSetConfigForLeague(NumberSetConfig123F, SportsLeagueNBA)).Should(gomega.BeTrue())
 	g.Expect(IsValidNumberSetConfigForLeague(NumberSetConfigHF, SportsLeagueNBA)).Should(gomega.BeTrue())
 
+	// NHL plays three periods, so there is no 4th or halftime
+	g.Expect(IsValidNumberSetConfigForLeague(NumberSetConfig123F, SportsLeagueNHL)).Should(gomega.BeTrue())
+	g.Expect(IsValidNumberSetConfigForLeague(NumberSetConfig1234, SportsLeagueNHL)).Should(gomega.BeFalse())
+	g.Expect(IsValidNumberSetConfigForLeague(NumberSetConfigHF, SportsLeagueNHL)).Should(gomega.BeFalse())
+
+	// MLB is only scored by inning, and only MLB is
+	g.Expect(IsValidNumberSetConfigForLeague(NumberSetConfig357F, SportsLeagueMLB)).Should(gomega.BeTrue())
+	g.Expect(IsValidNumberSetConfigForLeague(NumberSetConfig5F, SportsLeagueMLB)).Should(gomega.BeTrue())
+	g.Expect(IsValidNumberSetConfigForLeague(NumberSetConfigStandard, SportsLeagueMLB)).Should(gomega.BeTrue())
+	g.Expect(IsValidNumberSetConfigForLeague(NumberSetConfig123F, SportsLeagueMLB)).Should(gomega.BeFalse())
+	g.Expect(IsValidNumberSetConfigForLeague(NumberSetConfig357F, SportsLeagueNFL)).Should(gomega.BeFalse())
+
+	// MLS plays halves
+	g.Expect(IsValidNumberSetConfigForLeague(NumberSetConfigHF, SportsLeagueMLS)).Should(gomega.BeTrue())
+	g.Expect(IsValidNumberSetConfigForLeague(NumberSetConfig123F, SportsLeagueMLS)).Should(gomega.BeFalse())
+
+	// every score is only paid out in leagues with scoring plays
+	g.Expect(IsValidNumberSetConfigForLeague(NumberSetConfigEvery, SportsLeagueNFL)).Should(gomega.BeTrue())
+	g.Expect(IsValidNumberSetConfigForLeague(NumberSetConfigEvery, SportsLeagueNCAAF)).Should(gomega.BeTrue())
+	g.Expect(IsValidNumberSetConfigForLeague(NumberSetConfigEvery, SportsLeagueNBA)).Should(gomega.BeFalse())
+	g.Expect(IsValidNumberSetConfigForLeague(NumberSetConfigEvery, SportsLeagueMLB)).Should(gomega.BeFalse())
+
 	// Invalid config should return false for any league
 	g.Expect(IsValidNumberSetConfigForLeague(NumberSetConfig("invalid"), SportsLeagueNFL)).Should(gomega.BeFalse())
 	g.Expect(IsValidNumberSetConfigForLeague(NumberSetConfig("invalid"), SportsLeagueNCAAB)).Should(gomega.BeFalse())
@@ -186,17 +225,19 @@ func TestIsValidNumberSetConfigForLeague(t *testing.T) {
 func TestValidNumberSetConfigsForLeague(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
-	// NFL should get all 3 valid configs
+	// NFL should get the 6 configs that aren't scored by inning
 	nflConfigs := ValidNumberSetConfigsForLeague(SportsLeagueNFL)
-	g.Expect(len(nflConfigs)).Should(gomega.Equal(3))
+	g.Expect(len(nflConfigs)).Should(gomega.Equal(6))
 
-	// NCAAB should only get 2 configs (standard and hf, not 123f)
+	// NCAAB should only get 2 configs (standard and hf, none of the quarter configs)
 	ncaabConfigs := ValidNumberSetConfigsForLeague(SportsLeagueNCAAB)
 	g.Expect(len(ncaabConfigs)).Should(gomega.Equal(2))
 
-	// Verify NCAAB configs don't include 123f
+	// Verify NCAAB configs don't include any quarter configs
 	for _, config := range ncaabConfigs {
 		g.Expect(config.Key).ShouldNot(gomega.Equal(NumberSetConfig123F))
+		g.Expect(config.Key).ShouldNot(gomega.Equal(NumberSetConfig1234))
+		g.Expect(config.Key).ShouldNot(gomega.Equal(NumberSetConfigH4))
 	}
 
 	// Verify NCAAB configs do include standard and hf
@@ -207,7 +248,43 @@ func TestValidNumberSetConfigsForLeague(t *testing.T) {
 	g.Expect(keys).Should(gomega.ContainElement(NumberSetConfigStandard))
 	g.Expect(keys).Should(gomega.ContainElement(NumberSetConfigHF))
 
-	// NBA should get all 3 valid configs
+	// NCAAB has no scoring plays to pay out every score from
+	g.Expect(keys).ShouldNot(gomega.ContainElement(NumberSetConfigEvery))
+
+	// NBA should get the 5 configs that aren't scored by inning or every score
 	nbaConfigs := ValidNumberSetConfigsForLeague(SportsLeagueNBA)
-	g.Expect(len(nbaConfigs)).Should(gomega.Equal(3))
+	g.Expect(len(nbaConfigs)).Should(gomega.Equal(5))
+
+	// MLB gets standard, 357f and 5f
+	mlbConfigs := ValidNumberSetConfigsForLeague(SportsLeagueMLB)
+	g.Expect(len(mlbConfigs)).Should(gomega.Equal(3))
+
+	// NHL gets standard and 123f
+	nhlConfigs := ValidNumberSetConfigsForLeague(SportsLeagueNHL)
+	g.Expect(len(nhlConfigs)).Should(gomega.Equal(2))
+}
+
+func TestNumberSetTypeInning(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	g.Expect(NumberSetTypeI3.Inning()).Should(gomega.Equal(3))
+	g.Expect(NumberSetTypeI5.Inning()).Should(gomega.Equal(5))
+	g.Expect(NumberSetTypeI7.Inning()).Should(gomega.Equal(7))
+	g.Expect(NumberSetTypeQ3.Inning()).Should(gomega.Equal(0))
+}
+
+func TestNumberSetConfigUsesQuarters(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	g.Expect(NumberSetConfig1234.UsesQuarters()).Should(gomega.BeTrue())
+	g.Expect(NumberSetConfig123F.UsesQuarters()).Should(gomega.BeTrue())
+	g.Expect(NumberSetConfigH4.UsesQuarters()).Should(gomega.BeTrue())
+	g.Expect(NumberSetConfigHF.UsesQuarters()).Should(gomega.BeFalse())
+	g.Expect(NumberSetConfigStandard.UsesQuarters()).Should(gomega.BeFalse())
+	g.Expect(NumberSetConfigEvery.UsesQuarters()).Should(gomega.BeFalse())
+
+	g.Expect(IsValidNumberSetConfigForLeague(NumberSetConfig1234, SportsLeagueNFL)).Should(gomega.BeTrue())
+	g.Expect(IsValidNumberSetConfigForLeague(NumberSetConfigH4, SportsLeagueWNBA)).Should(gomega.BeTrue())
+	g.Expect(IsValidNumberSetConfigForLeague(NumberSetConfig1234, SportsLeagueNCAAB)).Should(gomega.BeFalse())
+	g.Expect(IsValidNumberSetConfigForLeague(NumberSetConfigH4, SportsLeagueNCAAB)).Should(gomega.BeFalse())
 }
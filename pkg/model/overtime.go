@@ -0,0 +1,80 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// OvertimeMode determines how a grid treats points scored in overtime
+type OvertimeMode string
+
+// constants for OvertimeMode
+const (
+	// OvertimeModeInclude folds overtime into the final score. There is no separate overtime payout.
+	OvertimeModeInclude OvertimeMode = "include"
+	// OvertimeModeSeparate scores the final period at the end of regulation and pays overtime as its own
+	// period, scored on the score at the end of the game
+	OvertimeModeSeparate OvertimeMode = "separate"
+)
+
+// OvertimeModes are the valid overtime modes
+var OvertimeModes = []OvertimeMode{
+	OvertimeModeInclude,
+	OvertimeModeSeparate,
+}
+
+// IsValid returns true if the overtime mode is valid
+func (o OvertimeMode) IsValid() bool {
+	return o == OvertimeModeInclude || o == OvertimeModeSeparate
+}
+
+// SetTypesWithOvertime returns the set types for the config, followed by the overtime period if overtime is
+// paid separately. Every score config already pays the scores of overtime, so it never has an overtime period.
+func SetTypesWithOvertime(config NumberSetConfig, overtime OvertimeMode) []NumberSetType {
+	setTypes := GetSetTypes(config)
+	if setTypes == nil || overtime != OvertimeModeSeparate || config == NumberSetConfigEvery {
+		return setTypes
+	}
+
+	withOvertime := make([]NumberSetType, 0, len(setTypes)+1)
+	withOvertime = append(withOvertime, setTypes...)
+	return append(withOvertime, NumberSetTypeOT)
+}
+
+// overtimeScore sums the points scored across every overtime period. ESPN reports each overtime as its own period
+// after regulation, so overtime is stored in whichever quarter columns follow the league's regulation periods: leagues
+// that play halves store their 1st and 2nd overtime in the 3rd and 4th quarter columns, and hockey stores its 1st
+// overtime in the 4th. Any later overtime periods, including a shootout, are summed into the OT column.
+func overtimeScore(league SportsLeague, quarters []*int, ot *int) *int {
+	var periods []*int
+	if regulation := league.RegulationPeriods(); regulation < len(quarters) {
+		periods = append(periods, quarters[regulation:]...)
+	}
+	periods = append(periods, ot)
+
+	var sum *int
+	for _, score := range periods {
+		if score == nil {
+			continue
+		}
+
+		if sum == nil {
+			sum = new(int)
+		}
+		*sum += *score
+	}
+
+	return sum
+}
@@ -0,0 +1,62 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestOvertimeModeIsValid(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Expect(OvertimeModeInclude.IsValid()).Should(gomega.BeTrue())
+	g.Expect(OvertimeModeSeparate.IsValid()).Should(gomega.BeTrue())
+	g.Expect(OvertimeMode("").IsValid()).Should(gomega.BeFalse())
+	g.Expect(OvertimeMode("bogus").IsValid()).Should(gomega.BeFalse())
+}
+
+func TestSetTypesWithOvertime(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Expect(SetTypesWithOvertime(NumberSetConfigHF, OvertimeModeInclude)).Should(gomega.Equal([]NumberSetType{NumberSetTypeHalf, NumberSetTypeFinal}))
+	g.Expect(SetTypesWithOvertime(NumberSetConfigHF, OvertimeModeSeparate)).Should(gomega.Equal([]NumberSetType{NumberSetTypeHalf, NumberSetTypeFinal, NumberSetTypeOT}))
+	g.Expect(SetTypesWithOvertime(NumberSetConfigStandard, OvertimeModeSeparate)).Should(gomega.Equal([]NumberSetType{NumberSetTypeAll, NumberSetTypeOT}))
+	g.Expect(SetTypesWithOvertime(NumberSetConfigEvery, OvertimeModeSeparate)).Should(gomega.Equal([]NumberSetType{NumberSetTypeAll}))
+	g.Expect(SetTypesWithOvertime(NumberSetConfig("bogus"), OvertimeModeSeparate)).Should(gomega.BeNil())
+
+	// the config's own set types must not be modified
+	g.Expect(GetSetTypes(NumberSetConfigHF)).Should(gomega.HaveLen(2))
+}
+
+func TestGridPeriodSetTypes(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	grid := &Grid{}
+	g.Expect(grid.OvertimeMode()).Should(gomega.Equal(OvertimeModeInclude))
+	g.Expect(grid.PeriodSetTypes(NumberSetConfigHF)).Should(gomega.Equal([]NumberSetType{NumberSetTypeHalf, NumberSetTypeFinal}))
+
+	grid.SetOvertimeMode(OvertimeModeSeparate)
+	grid.SetBDLEvent(&BDLEvent{League: SportsLeagueNFL, Status: BDLEventStatusInProgress, Period: intPtr(4)})
+	g.Expect(grid.PeriodSetTypes(NumberSetConfigHF)).Should(gomega.Equal([]NumberSetType{NumberSetTypeHalf, NumberSetTypeFinal, NumberSetTypeOT}))
+
+	// once the game ends in regulation, there is no overtime period
+	grid.BDLEvent().Status = BDLEventStatusFinal
+	g.Expect(grid.PeriodSetTypes(NumberSetConfigHF)).Should(gomega.Equal([]NumberSetType{NumberSetTypeHalf, NumberSetTypeFinal}))
+}
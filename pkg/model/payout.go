@@ -0,0 +1,629 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// PercentBasisPoints is the value of 100% when a payout is expressed as a percentage
+const PercentBasisPoints = 10000
+
+// PayoutType determines how the per-period payout values are interpreted
+type PayoutType string
+
+// constants for PayoutType
+const (
+	// PayoutTypeAmount means each period pays a fixed amount (in cents)
+	PayoutTypeAmount PayoutType = "amount"
+	// PayoutTypePercent means each period pays a percentage of the pot (in basis points)
+	PayoutTypePercent PayoutType = "percent"
+)
+
+// IsValid returns true if the payout type is valid
+func (p PayoutType) IsValid() bool {
+	return p == PayoutTypeAmount || p == PayoutTypePercent
+}
+
+// RolloverFinalRule determines what happens to the winnings of a rollover grid when the final period is not won
+type RolloverFinalRule string
+
+// constants for RolloverFinalRule
+const (
+	// RolloverFinalRuleSplit splits the remaining amount evenly between the grid's other winners
+	RolloverFinalRuleSplit RolloverFinalRule = "split"
+	// RolloverFinalRuleRefund refunds the remaining amount evenly to every claimed square
+	RolloverFinalRuleRefund RolloverFinalRule = "refund"
+)
+
+// IsValid returns true if the rule is valid
+func (r RolloverFinalRule) IsValid() bool {
+	return r == RolloverFinalRuleSplit || r == RolloverFinalRuleRefund
+}
+
+// PoolPayoutSettings contains the square price and the payouts for each period of a pool.
+// All monetary values are in cents.
+// This object uses getters and setters to help guard against user input.
+type PoolPayoutSettings struct {
+	model             *Model
+	poolID            int64
+	squarePrice       int64
+	payoutType        PayoutType
+	rolloverFinalRule RolloverFinalRule
+	periods           map[NumberSetType]int64
+	modified          *time.Time
+}
+
+// PoolPayoutSettingsJSON represents the payout settings that can be sent to the front-end
+type PoolPayoutSettingsJSON struct {
+	SquarePrice       int64                   `json:"squarePrice"`
+	PayoutType        PayoutType              `json:"payoutType"`
+	RolloverFinalRule RolloverFinalRule       `json:"rolloverFinalRule"`
+	Periods           map[NumberSetType]int64 `json:"periods"`
+}
+
+// Payout is the amount a single square won for a period of a grid
+type Payout struct {
+	WinningPeriodInfo
+	GridID   int64  `json:"gridId"`
+	SquareID int    `json:"squareId"`
+	Claimant string `json:"claimant"`
+	UserID   int64  `json:"userId"`
+	Amount   int64  `json:"amount"`
+	// Rollover is the portion of Amount that was carried forward from earlier periods
+	Rollover int64 `json:"rollover,omitempty"`
+	// Refund is true when the amount is a refund of an unwon rollover grid
+	Refund bool `json:"refund,omitempty"`
+	// Shares is how the amount is split between the co-owners of a shared square
+	Shares []*PayoutShare `json:"shares,omitempty"`
+}
+
+// PayoutShare is the portion of a payout won by one co-owner of a shared square
+type PayoutShare struct {
+	Claimant string `json:"claimant"`
+	UserID   int64  `json:"userId"`
+	// Share is the portion of the square owned, in basis points
+	Share  int   `json:"share"`
+	Amount int64 `json:"amount"`
+}
+
+// SquarePrice returns the price of a single square in cents
+func (p *PoolPayoutSettings) SquarePrice() int64 {
+	return p.squarePrice
+}
+
+// SetSquarePrice sets the price of a single square in cents
+func (p *PoolPayoutSettings) SetSquarePrice(price int64) {
+	if price < 0 {
+		price = 0
+	}
+
+	p.squarePrice = price
+}
+
+// PayoutType returns how the period payouts are interpreted
+func (p *PoolPayoutSettings) PayoutType() PayoutType {
+	return p.payoutType
+}
+
+// SetPayoutType sets how the period payouts are interpreted
+func (p *PoolPayoutSettings) SetPayoutType(payoutType PayoutType) {
+	p.payoutType = payoutType
+}
+
+// RolloverFinalRule returns how an unwon final period of a rollover grid is paid out
+func (p *PoolPayoutSettings) RolloverFinalRule() RolloverFinalRule {
+	return p.rolloverFinalRule
+}
+
+// SetRolloverFinalRule sets how an unwon final period of a rollover grid is paid out
+func (p *PoolPayoutSettings) SetRolloverFinalRule(rule RolloverFinalRule) {
+	p.rolloverFinalRule = rule
+}
+
+// Periods returns the configured payout value of each period
+func (p *PoolPayoutSettings) Periods() map[NumberSetType]int64 {
+	return p.periods
+}
+
+// SetPeriods sets the payout value of each period. Cents for PayoutTypeAmount and basis points for PayoutTypePercent.
+func (p *PoolPayoutSettings) SetPeriods(periods map[NumberSetType]int64) {
+	p.periods = make(map[NumberSetType]int64)
+	for period, value := range periods {
+		if value > 0 {
+			p.periods[period] = value
+		}
+	}
+}
+
+// IsConfigured returns true if a square price or any period payout has been set
+func (p *PoolPayoutSettings) IsConfigured() bool {
+	return p.squarePrice > 0 || len(p.periods) > 0
+}
+
+// JSON returns the payout settings that can be sent to the front-end
+func (p *PoolPayoutSettings) JSON() *PoolPayoutSettingsJSON {
+	periods := p.periods
+	if periods == nil {
+		periods = make(map[NumberSetType]int64)
+	}
+
+	return &PoolPayoutSettingsJSON{
+		SquarePrice:       p.squarePrice,
+		PayoutType:        p.payoutType,
+		RolloverFinalRule: p.rolloverFinalRule,
+		Periods:           periods,
+	}
+}
+
+// Pot returns the total amount collected for the pool, which is the square price multiplied
+// by the number of claimed squares. Secondary squares (i.e., roll100 children) are not counted.
+// Only the claimed share of a shared square is counted.
+func (p *PoolPayoutSettings) Pot(squares map[int]*PoolSquare) int64 {
+	var sold int64
+	for _, square := range squares {
+		if square.State != PoolSquareStateUnclaimed && square.ParentID == 0 {
+			sold += int64(square.OwnedShare())
+		}
+	}
+
+	return p.squarePrice * sold / ShareWhole
+}
+
+// GridPot returns the portion of the pot that is paid out by each grid. The pot is
+// split evenly between all active grids in the pool.
+func (p *PoolPayoutSettings) GridPot(squares map[int]*PoolSquare, numGrids int64) int64 {
+	if numGrids < 1 {
+		numGrids = 1
+	}
+
+	return p.Pot(squares) / numGrids
+}
+
+// AmountForPeriod returns the amount in cents a period pays out for a grid
+func (p *PoolPayoutSettings) AmountForPeriod(period NumberSetType, gridPot int64) int64 {
+	value := p.periods[period]
+	if p.payoutType == PayoutTypePercent {
+		return gridPot * value / PercentBasisPoints
+	}
+
+	return value
+}
+
+// GridPayouts calculates the payout for each winning square of the grid. The grid must have its
+// sports event (and number sets, if required) loaded. Results are ordered by period.
+//
+// If the grid has rollover enabled, the payout of a completed period without a claimed winner is carried
+// forward to the next period. If the final period is not won, the remaining amount is handled by the
+// RolloverFinalRule.
+//
+// If the grid pays overtime separately and the game ends in regulation, the overtime amount is paid to the
+// winner of the last period of regulation.
+//
+// The payout of a shared square is split between its co-owners in proportion to their shares.
+func (p *PoolPayoutSettings) GridPayouts(grid *Grid, config NumberSetConfig, gridType GridType, squares map[int]*PoolSquare, gridPot int64) []*Payout {
+	event := grid.BDLEvent()
+	if event == nil {
+		return nil
+	}
+
+	if config == NumberSetConfigEvery {
+		return p.scoreChangePayouts(grid, gridType, squares, gridPot)
+	}
+
+	setTypes := grid.PeriodSetTypes(config)
+	winningSquares := grid.GetGridWinningSquares(event, config, gridType)
+	if grid.Rollover() {
+		winningSquares.ApplyRollover(event, setTypes, squares)
+	}
+
+	// collect the scores for each winning period
+	periodInfos := make(map[NumberSetType]WinningPeriodInfo)
+	for _, squareID := range winningSquares.Squares {
+		for _, info := range GetWinningPeriodsForSquare(squareID, winningSquares, event, grid.HomeTeamName(), grid.AwayTeamName()) {
+			periodInfos[info.Period] = info
+		}
+	}
+
+	// when overtime is paid separately but never played, its amount goes to the last period of regulation
+	var overtimeAmount int64
+	if grid.OvertimeMode() == OvertimeModeSeparate && event.EndedInRegulation() {
+		overtimeAmount = p.AmountForPeriod(NumberSetTypeOT, gridPot)
+	}
+
+	payouts := make([]*Payout, 0)
+	var carry int64
+	for i, setType := range setTypes {
+		if !event.IsPeriodCompleteWithOvertime(setType, grid.OvertimeMode()) {
+			break
+		}
+
+		amount := p.AmountForPeriod(setType, gridPot)
+		if i == len(setTypes)-1 {
+			amount += overtimeAmount
+		}
+		if winningSquares.IsRolledOver(setType) {
+			carry += amount
+			continue
+		}
+
+		info, ok := periodInfos[setType]
+		if !ok {
+			continue
+		}
+
+		squareID := winningSquares.Squares[setType]
+		payout := &Payout{
+			WinningPeriodInfo: info,
+			GridID:            grid.ID(),
+			SquareID:          squareID,
+			Amount:            amount + carry,
+			Rollover:          carry,
+		}
+
+		if square, ok := squares[squareID]; ok && square.State != PoolSquareStateUnclaimed {
+			payout.Claimant = square.Claimant()
+			payout.UserID = square.UserID()
+		}
+
+		payouts = append(payouts, payout)
+		carry = 0
+	}
+
+	if carry > 0 && len(setTypes) > 0 && event.IsPeriodCompleteWithOvertime(setTypes[len(setTypes)-1], grid.OvertimeMode()) {
+		payouts = p.distributeFinalRollover(grid, payouts, squares, setTypes[len(setTypes)-1], carry)
+	}
+
+	splitPayoutShares(payouts, squares)
+	return payouts
+}
+
+// scoreChangePayouts pays the "all" period amount to the winning square of every score change of the grid's event.
+// The event must have its score history loaded.
+func (p *PoolPayoutSettings) scoreChangePayouts(grid *Grid, gridType GridType, squares map[int]*PoolSquare, gridPot int64) []*Payout {
+	amount := p.AmountForPeriod(NumberSetTypeAll, gridPot)
+	payouts := make([]*Payout, 0)
+	for _, winner := range grid.GetGridScoreChangeWinners(grid.BDLEvent(), gridType) {
+		payout := &Payout{
+			WinningPeriodInfo: WinningPeriodInfo{
+				Period:       NumberSetTypeAll,
+				Label:        scoreChangeLabel,
+				HomeScore:    winner.HomeScore,
+				AwayScore:    winner.AwayScore,
+				HomeTeamName: grid.HomeTeamName(),
+				AwayTeamName: grid.AwayTeamName(),
+			},
+			GridID:   grid.ID(),
+			SquareID: winner.SquareID,
+			Amount:   amount,
+		}
+
+		if square, ok := squares[winner.SquareID]; ok && square.State != PoolSquareStateUnclaimed {
+			payout.Claimant = square.Claimant()
+			payout.UserID = square.UserID()
+		}
+
+		payouts = append(payouts, payout)
+	}
+
+	splitPayoutShares(payouts, squares)
+	return payouts
+}
+
+// splitPayoutShares splits the payouts of shared squares between their co-owners
+func splitPayoutShares(payouts []*Payout, squares map[int]*PoolSquare) {
+	for _, payout := range payouts {
+		square, ok := squares[payout.SquareID]
+		if !ok || !square.IsShared() || payout.Claimant == "" {
+			continue
+		}
+
+		amounts := splitByShare(payout.Amount, square.Shares())
+		payout.Shares = make([]*PayoutShare, len(amounts))
+		for i, share := range square.Shares() {
+			payout.Shares[i] = &PayoutShare{
+				Claimant: share.Claimant(),
+				UserID:   share.UserID(),
+				Share:    share.Share(),
+				Amount:   amounts[i],
+			}
+		}
+	}
+}
+
+// distributeFinalRollover handles the amount left over when the final period of a grid was not won.
+// The amount is either split evenly between the grid's other winners or refunded evenly to every claimed square.
+// Any remaining cents are given to the first recipients.
+func (p *PoolPayoutSettings) distributeFinalRollover(grid *Grid, payouts []*Payout, squares map[int]*PoolSquare, finalPeriod NumberSetType, amount int64) []*Payout {
+	if p.rolloverFinalRule != RolloverFinalRuleRefund && len(payouts) > 0 {
+		shares := splitEvenly(amount, len(payouts))
+		for i, payout := range payouts {
+			payout.Amount += shares[i]
+			payout.Rollover += shares[i]
+		}
+
+		return payouts
+	}
+
+	squareIDs := make([]int, 0)
+	for squareID, square := range squares {
+		if square.State != PoolSquareStateUnclaimed && square.ParentID == 0 {
+			squareIDs = append(squareIDs, squareID)
+		}
+	}
+
+	if len(squareIDs) == 0 {
+		return payouts
+	}
+
+	sort.Ints(squareIDs)
+	shares := splitEvenly(amount, len(squareIDs))
+	for i, squareID := range squareIDs {
+		square := squares[squareID]
+		payouts = append(payouts, &Payout{
+			WinningPeriodInfo: WinningPeriodInfo{
+				Period:       finalPeriod,
+				Label:        "Refund",
+				HomeTeamName: grid.HomeTeamName(),
+				AwayTeamName: grid.AwayTeamName(),
+			},
+			GridID:   grid.ID(),
+			SquareID: squareID,
+			Claimant: square.Claimant(),
+			UserID:   square.UserID(),
+			Amount:   shares[i],
+			Rollover: shares[i],
+			Refund:   true,
+		})
+	}
+
+	return payouts
+}
+
+// splitEvenly divides the amount into n shares. Any remainder is added one cent at a time to the first shares.
+func splitEvenly(amount int64, n int) []int64 {
+	shares := make([]int64, n)
+	if n == 0 {
+		return shares
+	}
+
+	each := amount / int64(n)
+	remainder := amount % int64(n)
+	for i := range shares {
+		shares[i] = each
+		if int64(i) < remainder {
+			shares[i]++
+		}
+	}
+
+	return shares
+}
+
+// splitByShare divides the amount between the shares in proportion to their size. Any remainder is added
+// one cent at a time to the first shares.
+func splitByShare(amount int64, shares []*PoolSquareShare) []int64 {
+	amounts := make([]int64, len(shares))
+	var total int64
+	for _, share := range shares {
+		total += int64(share.share)
+	}
+
+	if total == 0 {
+		return amounts
+	}
+
+	remainder := amount
+	for i, share := range shares {
+		amounts[i] = amount * int64(share.share) / total
+		remainder -= amounts[i]
+	}
+
+	for i := 0; remainder > 0; i = (i + 1) % len(amounts) {
+		amounts[i]++
+		remainder--
+	}
+
+	return amounts
+}
+
+// PayoutSettings returns the payout settings for the pool. If none have been saved, empty settings are returned.
+func (p *Pool) PayoutSettings(ctx context.Context) (*PoolPayoutSettings, error) {
+	settings := &PoolPayoutSettings{
+		model:             p.model,
+		poolID:            p.id,
+		payoutType:        PayoutTypeAmount,
+		rolloverFinalRule: RolloverFinalRuleSplit,
+		periods:           make(map[NumberSetType]int64),
+	}
+
+	row := p.model.DB.QueryRowContext(ctx, "SELECT square_price, payout_type, rollover_final_rule, modified FROM pool_payout_settings WHERE pool_id = $1", p.id)
+	if err := row.Scan(&settings.squarePrice, &settings.payoutType, &settings.rolloverFinalRule, &settings.modified); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return settings, nil
+		}
+
+		return nil, fmt.Errorf("loading payout settings: %w", err)
+	}
+
+	rows, err := p.model.DB.QueryContext(ctx, "SELECT period, value FROM pool_period_payouts WHERE pool_id = $1", p.id)
+	if err != nil {
+		return nil, fmt.Errorf("loading period payouts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var period NumberSetType
+		var value int64
+		if err := rows.Scan(&period, &value); err != nil {
+			return nil, fmt.Errorf("scanning period payout: %w", err)
+		}
+
+		settings.periods[period] = value
+	}
+
+	return settings, rows.Err()
+}
+
+// Save will save the payout settings and replace all period payouts
+func (p *PoolPayoutSettings) Save(ctx context.Context) error {
+	tx, err := p.model.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	const query = `
+		INSERT INTO pool_payout_settings (pool_id, square_price, payout_type, rollover_final_rule)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (pool_id) DO UPDATE
+		SET square_price = EXCLUDED.square_price,
+		    payout_type = EXCLUDED.payout_type,
+		    rollover_final_rule = EXCLUDED.rollover_final_rule,
+		    modified = (NOW() AT TIME ZONE 'utc')`
+
+	if _, err = tx.ExecContext(ctx, query, p.poolID, p.squarePrice, p.payoutType, p.rolloverFinalRule); err != nil {
+		return fmt.Errorf("saving payout settings: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM pool_period_payouts WHERE pool_id = $1", p.poolID); err != nil {
+		return fmt.Errorf("removing period payouts: %w", err)
+	}
+
+	for period, value := range p.periods {
+		if _, err = tx.ExecContext(ctx, "INSERT INTO pool_period_payouts (pool_id, period, value) VALUES ($1, $2, $3)", p.poolID, period, value); err != nil {
+			return fmt.Errorf("saving period payout %s: %w", period, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return nil
+}
+
+// PoolPayouts contains the computed payouts for every grid in a pool
+type PoolPayouts struct {
+	Settings *PoolPayoutSettingsJSON `json:"settings"`
+	Pot      int64                   `json:"pot"`
+	GridPot  int64                   `json:"gridPot"`
+	Payouts  []*Payout               `json:"payouts"`
+	Total    int64                   `json:"total"`
+}
+
+// GridPayouts returns the payouts for a single grid in the pool. The grid must have its
+// sports event (and number sets, if required) loaded.
+func (p *Pool) GridPayouts(ctx context.Context, grid *Grid) ([]*Payout, error) {
+	if grid.BDLEvent() == nil {
+		return nil, nil
+	}
+
+	settings, err := p.PayoutSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !settings.IsConfigured() {
+		return nil, nil
+	}
+
+	squares, err := p.Squares()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.LoadSquareShares(ctx); err != nil {
+		return nil, err
+	}
+
+	numGrids, err := p.GridsCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	config := grid.EffectiveNumberSetConfig(p.numberSetConfig)
+	if config == NumberSetConfigEvery && grid.BDLEvent().ScoreHistory() == nil {
+		if err := grid.BDLEvent().LoadScoreHistory(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return settings.GridPayouts(grid, config, p.gridType, squares, settings.GridPot(squares, numGrids)), nil
+}
+
+// Payouts calculates the payouts for every active grid in the pool that is linked to a sports event
+func (p *Pool) Payouts(ctx context.Context) (*PoolPayouts, error) {
+	settings, err := p.PayoutSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	squares, err := p.Squares()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.LoadSquareShares(ctx); err != nil {
+		return nil, err
+	}
+
+	grids, err := p.Grids(ctx, 0, MaxGridsPerPool)
+	if err != nil {
+		return nil, err
+	}
+
+	gridPot := settings.GridPot(squares, int64(len(grids)))
+	result := &PoolPayouts{
+		Settings: settings.JSON(),
+		Pot:      settings.Pot(squares),
+		GridPot:  gridPot,
+		Payouts:  make([]*Payout, 0),
+	}
+
+	for _, grid := range grids {
+		if err := grid.LoadBDLEvent(ctx); err != nil {
+			return nil, err
+		}
+
+		if grid.BDLEvent() == nil {
+			continue
+		}
+
+		config := grid.EffectiveNumberSetConfig(p.numberSetConfig)
+		if err := grid.loadWinnerData(ctx, config); err != nil {
+			return nil, err
+		}
+
+		for _, payout := range settings.GridPayouts(grid, config, p.gridType, squares, gridPot) {
+			result.Payouts = append(result.Payouts, payout)
+			result.Total += payout.Amount
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,431 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func payoutTestGrid() *Grid {
+	homeQ1 := 7
+	awayQ1 := 3
+	homeQ2 := 7 // Half = 14
+	awayQ2 := 7 // Half = 10
+	homeScore := 28
+	awayScore := 24
+
+	nums := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+	grid := &Grid{
+		id: 5,
+		numberSets: map[NumberSetType]*GridNumberSet{
+			NumberSetTypeHalf:  {homeNumbers: nums, awayNumbers: nums},
+			NumberSetTypeFinal: {homeNumbers: nums, awayNumbers: nums},
+		},
+	}
+	grid.SetBDLEvent(&BDLEvent{
+		ID:        1,
+		Status:    BDLEventStatusFinal,
+		HomeQ1:    &homeQ1,
+		AwayQ1:    &awayQ1,
+		HomeQ2:    &homeQ2,
+		AwayQ2:    &awayQ2,
+		HomeScore: &homeScore,
+		AwayScore: &awayScore,
+	})
+
+	return grid
+}
+
+func payoutTestSquares() map[int]*PoolSquare {
+	squares := make(map[int]*PoolSquare)
+	for i := 1; i <= 100; i++ {
+		squares[i] = &PoolSquare{SquareID: i, State: PoolSquareStateUnclaimed}
+	}
+
+	// half winner
+	squares[5].State = PoolSquareStatePaidFull
+	squares[5].claimant = "Alice"
+	squares[5].userID = 10
+
+	// final winner
+	squares[49].State = PoolSquareStateClaimed
+	squares[49].claimant = "Bob"
+	squares[49].userID = 20
+
+	squares[50].State = PoolSquareStateClaimed
+	squares[50].claimant = "Carol"
+
+	// secondary square should not count toward the pot
+	squares[51].State = PoolSquareStateClaimed
+	squares[51].ParentID = 50
+
+	return squares
+}
+
+func TestPoolPayoutSettingsPot(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	settings := &PoolPayoutSettings{}
+	settings.SetSquarePrice(1000)
+
+	squares := payoutTestSquares()
+	g.Expect(settings.Pot(squares)).Should(gomega.Equal(int64(3000)))
+	g.Expect(settings.GridPot(squares, 2)).Should(gomega.Equal(int64(1500)))
+	g.Expect(settings.GridPot(squares, 0)).Should(gomega.Equal(int64(3000)))
+
+	settings.SetSquarePrice(-5)
+	g.Expect(settings.SquarePrice()).Should(gomega.Equal(int64(0)))
+}
+
+func TestPoolPayoutSettingsGridPayoutsAmount(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	settings := &PoolPayoutSettings{}
+	settings.SetPayoutType(PayoutTypeAmount)
+	settings.SetPeriods(map[NumberSetType]int64{
+		NumberSetTypeHalf:  2500,
+		NumberSetTypeFinal: 7500,
+		NumberSetTypeQ1:    0, // zero values are dropped
+	})
+	g.Expect(settings.Periods()).Should(gomega.HaveLen(2))
+
+	payouts := settings.GridPayouts(payoutTestGrid(), NumberSetConfigHF, GridTypeStd100, payoutTestSquares(), 0)
+	g.Expect(payouts).Should(gomega.HaveLen(2))
+
+	g.Expect(payouts[0].Period).Should(gomega.Equal(NumberSetTypeHalf))
+	g.Expect(payouts[0].SquareID).Should(gomega.Equal(5))
+	g.Expect(payouts[0].Claimant).Should(gomega.Equal("Alice"))
+	g.Expect(payouts[0].UserID).Should(gomega.Equal(int64(10)))
+	g.Expect(payouts[0].GridID).Should(gomega.Equal(int64(5)))
+	g.Expect(payouts[0].HomeScore).Should(gomega.Equal(14))
+	g.Expect(payouts[0].AwayScore).Should(gomega.Equal(10))
+	g.Expect(payouts[0].Amount).Should(gomega.Equal(int64(2500)))
+
+	g.Expect(payouts[1].Period).Should(gomega.Equal(NumberSetTypeFinal))
+	g.Expect(payouts[1].SquareID).Should(gomega.Equal(49))
+	g.Expect(payouts[1].Claimant).Should(gomega.Equal("Bob"))
+	g.Expect(payouts[1].Amount).Should(gomega.Equal(int64(7500)))
+}
+
+func TestPoolPayoutSettingsGridPayoutsPercent(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	settings := &PoolPayoutSettings{}
+	settings.SetSquarePrice(1000)
+	settings.SetPayoutType(PayoutTypePercent)
+	settings.SetPeriods(map[NumberSetType]int64{
+		NumberSetTypeHalf:  4000,
+		NumberSetTypeFinal: 6000,
+	})
+
+	squares := payoutTestSquares()
+	payouts := settings.GridPayouts(payoutTestGrid(), NumberSetConfigHF, GridTypeStd100, squares, settings.GridPot(squares, 1))
+	g.Expect(payouts).Should(gomega.HaveLen(2))
+	g.Expect(payouts[0].Amount).Should(gomega.Equal(int64(1200)))
+	g.Expect(payouts[1].Amount).Should(gomega.Equal(int64(1800)))
+}
+
+func TestPoolPayoutSettingsGridPayoutsNoEvent(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	settings := &PoolPayoutSettings{}
+	settings.SetSquarePrice(1000)
+
+	g.Expect(settings.GridPayouts(&Grid{}, NumberSetConfigStandard, GridTypeStd100, payoutTestSquares(), 1000)).Should(gomega.BeNil())
+}
+
+func TestPayoutTypeIsValid(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	g.Expect(PayoutTypeAmount.IsValid()).Should(gomega.BeTrue())
+	g.Expect(PayoutTypePercent.IsValid()).Should(gomega.BeTrue())
+	g.Expect(PayoutType("bogus").IsValid()).Should(gomega.BeFalse())
+}
+
+func TestPoolPayoutSettingsGridPayoutsRolloverUnclaimed(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	settings := &PoolPayoutSettings{}
+	settings.SetPeriods(map[NumberSetType]int64{
+		NumberSetTypeHalf:  2500,
+		NumberSetTypeFinal: 7500,
+	})
+
+	grid := payoutTestGrid()
+	grid.SetRollover(true)
+
+	squares := payoutTestSquares()
+	squares[5].State = PoolSquareStateUnclaimed
+
+	payouts := settings.GridPayouts(grid, NumberSetConfigHF, GridTypeStd100, squares, 0)
+	g.Expect(payouts).Should(gomega.HaveLen(1))
+	g.Expect(payouts[0].Period).Should(gomega.Equal(NumberSetTypeFinal))
+	g.Expect(payouts[0].Claimant).Should(gomega.Equal("Bob"))
+	g.Expect(payouts[0].Amount).Should(gomega.Equal(int64(10000)))
+	g.Expect(payouts[0].Rollover).Should(gomega.Equal(int64(2500)))
+}
+
+func TestPoolPayoutSettingsGridPayoutsNoRolloverWhenDisabled(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	settings := &PoolPayoutSettings{}
+	settings.SetPeriods(map[NumberSetType]int64{
+		NumberSetTypeHalf:  2500,
+		NumberSetTypeFinal: 7500,
+	})
+
+	squares := payoutTestSquares()
+	squares[5].State = PoolSquareStateUnclaimed
+
+	payouts := settings.GridPayouts(payoutTestGrid(), NumberSetConfigHF, GridTypeStd100, squares, 0)
+	g.Expect(payouts).Should(gomega.HaveLen(2))
+	g.Expect(payouts[0].Claimant).Should(gomega.Equal(""))
+	g.Expect(payouts[0].Amount).Should(gomega.Equal(int64(2500)))
+	g.Expect(payouts[1].Amount).Should(gomega.Equal(int64(7500)))
+	g.Expect(payouts[1].Rollover).Should(gomega.Equal(int64(0)))
+}
+
+func TestPoolPayoutSettingsGridPayoutsRolloverFinalSplit(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	settings := &PoolPayoutSettings{}
+	settings.SetRolloverFinalRule(RolloverFinalRuleSplit)
+	settings.SetPeriods(map[NumberSetType]int64{
+		NumberSetTypeHalf:  2500,
+		NumberSetTypeFinal: 7500,
+	})
+
+	grid := payoutTestGrid()
+	grid.SetRollover(true)
+
+	squares := payoutTestSquares()
+	squares[49].State = PoolSquareStateUnclaimed
+
+	payouts := settings.GridPayouts(grid, NumberSetConfigHF, GridTypeStd100, squares, 0)
+	g.Expect(payouts).Should(gomega.HaveLen(1))
+	g.Expect(payouts[0].Period).Should(gomega.Equal(NumberSetTypeHalf))
+	g.Expect(payouts[0].Claimant).Should(gomega.Equal("Alice"))
+	g.Expect(payouts[0].Amount).Should(gomega.Equal(int64(10000)))
+	g.Expect(payouts[0].Rollover).Should(gomega.Equal(int64(7500)))
+}
+
+func TestPoolPayoutSettingsGridPayoutsRolloverFinalRefund(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	settings := &PoolPayoutSettings{}
+	settings.SetRolloverFinalRule(RolloverFinalRuleRefund)
+	settings.SetPeriods(map[NumberSetType]int64{
+		NumberSetTypeHalf:  2500,
+		NumberSetTypeFinal: 7501,
+	})
+
+	grid := payoutTestGrid()
+	grid.SetRollover(true)
+
+	squares := payoutTestSquares()
+	squares[49].State = PoolSquareStateUnclaimed
+
+	payouts := settings.GridPayouts(grid, NumberSetConfigHF, GridTypeStd100, squares, 0)
+
+	// half winner, plus a refund for squares 5 and 50 (51 is a secondary square)
+	g.Expect(payouts).Should(gomega.HaveLen(3))
+	g.Expect(payouts[0].Amount).Should(gomega.Equal(int64(2500)))
+	g.Expect(payouts[0].Refund).Should(gomega.BeFalse())
+
+	g.Expect(payouts[1].Refund).Should(gomega.BeTrue())
+	g.Expect(payouts[1].SquareID).Should(gomega.Equal(5))
+	g.Expect(payouts[1].Amount).Should(gomega.Equal(int64(3751)))
+	g.Expect(payouts[2].Refund).Should(gomega.BeTrue())
+	g.Expect(payouts[2].SquareID).Should(gomega.Equal(50))
+	g.Expect(payouts[2].Claimant).Should(gomega.Equal("Carol"))
+	g.Expect(payouts[2].Amount).Should(gomega.Equal(int64(3750)))
+}
+
+func TestPoolPayoutSettingsGridPayoutsRolloverNoWinnersRefunds(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	settings := &PoolPayoutSettings{}
+	settings.SetRolloverFinalRule(RolloverFinalRuleSplit)
+	settings.SetPeriods(map[NumberSetType]int64{
+		NumberSetTypeHalf:  3000,
+		NumberSetTypeFinal: 3000,
+	})
+
+	grid := payoutTestGrid()
+	grid.SetRollover(true)
+
+	squares := payoutTestSquares()
+	squares[5].State = PoolSquareStateUnclaimed
+	squares[49].State = PoolSquareStateUnclaimed
+
+	// nobody won, so even with the split rule the amount is refunded to square 50
+	payouts := settings.GridPayouts(grid, NumberSetConfigHF, GridTypeStd100, squares, 0)
+	g.Expect(payouts).Should(gomega.HaveLen(1))
+	g.Expect(payouts[0].SquareID).Should(gomega.Equal(50))
+	g.Expect(payouts[0].Refund).Should(gomega.BeTrue())
+	g.Expect(payouts[0].Amount).Should(gomega.Equal(int64(6000)))
+}
+
+func TestSplitEvenly(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	g.Expect(splitEvenly(10, 3)).Should(gomega.Equal([]int64{4, 3, 3}))
+	g.Expect(splitEvenly(9, 3)).Should(gomega.Equal([]int64{3, 3, 3}))
+	g.Expect(splitEvenly(9, 0)).Should(gomega.BeEmpty())
+}
+
+func TestPoolPayoutSettingsGridPayoutsEveryScore(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	settings := &PoolPayoutSettings{}
+	settings.SetPayoutType(PayoutTypeAmount)
+	settings.SetPeriods(map[NumberSetType]int64{NumberSetTypeAll: 500})
+
+	nums := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	grid := &Grid{
+		id: 5,
+		numberSets: map[NumberSetType]*GridNumberSet{
+			NumberSetTypeAll: {homeNumbers: nums, awayNumbers: nums},
+		},
+	}
+	event := &BDLEvent{Status: BDLEventStatusInProgress}
+	event.SetScoreHistory([]*SportsEventScore{
+		{HomeScore: 4, AwayScore: 0}, // square 5, Alice
+		{HomeScore: 4, AwayScore: 7}, // square 75, unclaimed
+		{HomeScore: 8, AwayScore: 4}, // square 49, Bob
+	})
+	grid.SetBDLEvent(event)
+
+	payouts := settings.GridPayouts(grid, NumberSetConfigEvery, GridTypeStd100, payoutTestSquares(), 0)
+	g.Expect(payouts).Should(gomega.HaveLen(3))
+
+	g.Expect(payouts[0].SquareID).Should(gomega.Equal(5))
+	g.Expect(payouts[0].Claimant).Should(gomega.Equal("Alice"))
+	g.Expect(payouts[0].Label).Should(gomega.Equal("Score Change"))
+	g.Expect(payouts[0].Amount).Should(gomega.Equal(int64(500)))
+
+	g.Expect(payouts[1].SquareID).Should(gomega.Equal(75))
+	g.Expect(payouts[1].Claimant).Should(gomega.BeEmpty())
+
+	g.Expect(payouts[2].SquareID).Should(gomega.Equal(49))
+	g.Expect(payouts[2].Claimant).Should(gomega.Equal("Bob"))
+	g.Expect(payouts[2].HomeScore).Should(gomega.Equal(8))
+	g.Expect(payouts[2].AwayScore).Should(gomega.Equal(4))
+}
+
+func TestPoolPayoutSettingsGridPayoutsSeparateOvertime(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	settings := &PoolPayoutSettings{}
+	settings.SetPeriods(map[NumberSetType]int64{
+		NumberSetTypeHalf:  2500,
+		NumberSetTypeFinal: 5000,
+		NumberSetTypeOT:    2500,
+	})
+
+	// regulation ends 24-24, the game is won 28-24 in overtime
+	grid := payoutTestGrid()
+	grid.SetOvertimeMode(OvertimeModeSeparate)
+	event := grid.BDLEvent()
+	event.Period = intPtr(5)
+	event.HomeQ3, event.HomeQ4, event.HomeOT = intPtr(3), intPtr(7), intPtr(4)
+	event.AwayQ3, event.AwayQ4, event.AwayOT = intPtr(7), intPtr(7), intPtr(0)
+
+	payouts := settings.GridPayouts(grid, NumberSetConfigHF, GridTypeStd100, payoutTestSquares(), 0)
+	g.Expect(payouts).Should(gomega.HaveLen(3))
+	g.Expect(payouts[1].Period).Should(gomega.Equal(NumberSetTypeFinal))
+	g.Expect(payouts[1].SquareID).Should(gomega.Equal(45))
+	g.Expect(payouts[1].HomeScore).Should(gomega.Equal(24))
+	g.Expect(payouts[1].Amount).Should(gomega.Equal(int64(5000)))
+	g.Expect(payouts[2].Period).Should(gomega.Equal(NumberSetTypeOT))
+	g.Expect(payouts[2].SquareID).Should(gomega.Equal(49))
+	g.Expect(payouts[2].Claimant).Should(gomega.Equal("Bob"))
+	g.Expect(payouts[2].Amount).Should(gomega.Equal(int64(2500)))
+}
+
+func TestPoolPayoutSettingsGridPayoutsSeparateOvertimeNotPlayed(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	settings := &PoolPayoutSettings{}
+	settings.SetPeriods(map[NumberSetType]int64{
+		NumberSetTypeHalf:  2500,
+		NumberSetTypeFinal: 5000,
+		NumberSetTypeOT:    2500,
+	})
+
+	grid := payoutTestGrid()
+	grid.SetOvertimeMode(OvertimeModeSeparate)
+
+	// the game ended in regulation, so the overtime amount goes to the final winner
+	payouts := settings.GridPayouts(grid, NumberSetConfigHF, GridTypeStd100, payoutTestSquares(), 0)
+	g.Expect(payouts).Should(gomega.HaveLen(2))
+	g.Expect(payouts[1].Period).Should(gomega.Equal(NumberSetTypeFinal))
+	g.Expect(payouts[1].Claimant).Should(gomega.Equal("Bob"))
+	g.Expect(payouts[1].Amount).Should(gomega.Equal(int64(7500)))
+}
+
+func TestPoolPayoutSettingsGridPayoutsSharedSquare(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	settings := &PoolPayoutSettings{}
+	settings.SetSquarePrice(1000)
+	settings.SetPayoutType(PayoutTypeAmount)
+	settings.SetPeriods(map[NumberSetType]int64{
+		NumberSetTypeHalf:  1001,
+		NumberSetTypeFinal: 3000,
+	})
+
+	// the final winner is split between three co-owners, with a quarter of the square unsold
+	squares := payoutTestSquares()
+	squares[49].claimant = "Bob / Dave / Erin"
+	squares[49].userID = 0
+	squares[49].shares = []*PoolSquareShare{
+		{id: 1, claimant: "Bob", userID: 20, share: 2500, state: PoolSquareStateClaimed},
+		{id: 2, claimant: "Dave", userID: 40, share: 2500, state: PoolSquareStateClaimed},
+		{id: 3, claimant: "Erin", share: 2500, state: PoolSquareStateClaimed},
+	}
+
+	g.Expect(settings.Pot(squares)).Should(gomega.Equal(int64(2750)))
+
+	payouts := settings.GridPayouts(payoutTestGrid(), NumberSetConfigHF, GridTypeStd100, squares, 0)
+	g.Expect(payouts).Should(gomega.HaveLen(2))
+	g.Expect(payouts[0].Shares).Should(gomega.BeNil())
+
+	g.Expect(payouts[1].Claimant).Should(gomega.Equal("Bob / Dave / Erin"))
+	g.Expect(payouts[1].Amount).Should(gomega.Equal(int64(3000)))
+	g.Expect(payouts[1].Shares).Should(gomega.HaveLen(3))
+	g.Expect(payouts[1].Shares[0]).Should(gomega.Equal(&PayoutShare{Claimant: "Bob", UserID: 20, Share: 2500, Amount: 1000}))
+	g.Expect(payouts[1].Shares[1]).Should(gomega.Equal(&PayoutShare{Claimant: "Dave", UserID: 40, Share: 2500, Amount: 1000}))
+	g.Expect(payouts[1].Shares[2]).Should(gomega.Equal(&PayoutShare{Claimant: "Erin", Share: 2500, Amount: 1000}))
+}
+
+func TestSplitByShare(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	shares := []*PoolSquareShare{{share: 5000}, {share: 2500}, {share: 2500}}
+	g.Expect(splitByShare(1000, shares)).Should(gomega.Equal([]int64{500, 250, 250}))
+	g.Expect(splitByShare(1003, shares)).Should(gomega.Equal([]int64{502, 251, 250}))
+
+	thirds := []*PoolSquareShare{{share: 3333}, {share: 3333}, {share: 3334}}
+	g.Expect(splitByShare(100, thirds)).Should(gomega.Equal([]int64{34, 33, 33}))
+
+	g.Expect(splitByShare(100, nil)).Should(gomega.BeEmpty())
+}
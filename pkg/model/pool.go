@@ -359,6 +359,10 @@ func (m *Model) PoolByID(id int64) (*Pool, error) {
 
 // NewPool will save new pool into the database
 func (m *Model) NewPool(ctx context.Context, userID int64, name string, gridType GridType, password string, numberSetConfig NumberSetConfig) (*Pool, error) {
+	return m.newPool(ctx, m.DB, userID, name, gridType, password, numberSetConfig)
+}
+
+func (m *Model) newPool(ctx context.Context, q Queryable, userID int64, name string, gridType GridType, password string, numberSetConfig NumberSetConfig) (*Pool, error) {
 	if err := IsValidGridType(string(gridType)); err != nil {
 		return nil, fmt.Errorf("validating grid type: %w", err)
 	}
@@ -382,7 +386,7 @@ func (m *Model) NewPool(ctx context.Context, userID int64, name string, gridType
 		FROM new_pool($1, $2, $3, $4, $5, $6, $7) AS pools
 	`
 
-	row := m.DB.QueryRowContext(ctx, query, token, userID, name, gridType, passwordHash, gridType.Squares(), numberSetConfig)
+	row := q.QueryRowContext(ctx, query, token, userID, name, gridType, passwordHash, gridType.Squares(), numberSetConfig)
 
 	pool, err := m.poolByRow(row.Scan)
 	if err != nil {
@@ -405,6 +409,10 @@ func (p *Pool) SetPassword(password string) error {
 
 // Save will save the pool
 func (p *Pool) Save(ctx context.Context) error {
+	return p.save(ctx, p.model.DB)
+}
+
+func (p *Pool) save(ctx context.Context, q Queryable) error {
 	const query = `
 UPDATE pools
 SET name = $1,
@@ -425,7 +433,7 @@ WHERE id = $10`
 		locks = &locksInUTC
 	}
 
-	_, err := p.model.DB.ExecContext(ctx, query, p.name, p.gridType, p.passwordHash, locks, p.checkID, p.archived, p.passwordRequired, p.openAccessOnLock, p.numberSetConfig, p.id)
+	_, err := q.ExecContext(ctx, query, p.name, p.gridType, p.passwordHash, locks, p.checkID, p.archived, p.passwordRequired, p.openAccessOnLock, p.numberSetConfig, p.id)
 	if err != nil {
 		return fmt.Errorf("saving pool: %w", err)
 	}
@@ -454,7 +462,19 @@ func (p *Pool) CheckIDIsValid(check int) bool {
 // Squares will return the squares that belong to a pool. This method will lazily load the squares
 func (p *Pool) Squares() (map[int]*PoolSquare, error) {
 	if p.squares == nil {
-		const query = `
+		squares, err := p.loadSquares(context.Background(), p.model.DB)
+		if err != nil {
+			return nil, err
+		}
+
+		p.squares = squares
+	}
+
+	return p.squares, nil
+}
+
+func (p *Pool) loadSquares(ctx context.Context, q Queryable) (map[int]*PoolSquare, error) {
+	const query = `
 SELECT ps.id,
        ps.square_id,
        ps.parent_id,
@@ -463,7 +483,8 @@ SELECT ps.id,
        ps.claimant,
        ps.modified,
        ps2.square_id                                                                   AS parent_square_id,
-       NULLIF(array_agg(ps3.square_id) FILTER (WHERE ps3.square_id IS NOT NULL), '{}') AS child_square_ids
+       NULLIF(array_agg(ps3.square_id) FILTER (WHERE ps3.square_id IS NOT NULL), '{}') AS child_square_ids,
+       ps.reserved_until
 FROM pool_squares ps
          LEFT JOIN pool_squares ps2 ON ps.parent_id = ps2.id
          LEFT JOIN pool_squares ps3 ON ps.id = ps3.parent_id -- bring in child squares
@@ -475,28 +496,26 @@ GROUP BY ps.id,
          ps.state,
          ps.claimant,
          ps.modified,
+         ps.reserved_until,
          ps2.square_id
 ORDER BY ps.square_id`
 
-		rows, err := p.model.DB.Query(query, p.id)
+	rows, err := q.QueryContext(ctx, query, p.id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	squares := make(map[int]*PoolSquare)
+	for rows.Next() {
+		gs, err := p.squareByRow(rows.Scan)
 		if err != nil {
 			return nil, err
 		}
-		defer rows.Close()
-
-		squares := make(map[int]*PoolSquare)
-		for rows.Next() {
-			gs, err := p.squareByRow(rows.Scan)
-			if err != nil {
-				return nil, err
-			}
-			squares[gs.SquareID] = gs
-		}
-
-		p.squares = squares
+		squares[gs.SquareID] = gs
 	}
 
-	return p.squares, nil
+	return squares, nil
 }
 
 // SquareBySquareID will return a single square based on the square ID
@@ -511,7 +530,8 @@ func (p *Pool) SquareBySquareID(squareID int) (*PoolSquare, error) {
 	       ps.claimant,
 	       ps.modified,
 	       ps2.square_id AS parent_square_id,
-	       (SELECT array_agg(square_id) FROM pool_squares ps3 WHERE ps3.parent_id = ps.id) AS child_square_ids
+	       (SELECT array_agg(square_id) FROM pool_squares ps3 WHERE ps3.parent_id = ps.id) AS child_square_ids,
+	       ps.reserved_until
 	FROM pool_squares ps
 	LEFT JOIN pool_squares ps2 ON ps.parent_id = ps2.id
 	WHERE
@@ -533,7 +553,8 @@ func (p *Pool) squareByRow(scan scanFunc) (*PoolSquare, error) {
 	var parentID *int64
 	var parentSquareID *int
 	var childSquareIDs []sql.NullInt64
-	if err := scan(&gs.ID, &gs.SquareID, &parentID, &userID, &gs.State, &claimant, &gs.Modified, &parentSquareID, pq.Array(&childSquareIDs)); err != nil {
+	var reservedUntil *time.Time
+	if err := scan(&gs.ID, &gs.SquareID, &parentID, &userID, &gs.State, &claimant, &gs.Modified, &parentSquareID, pq.Array(&childSquareIDs), &reservedUntil); err != nil {
 		return nil, err
 	}
 
@@ -560,6 +581,11 @@ func (p *Pool) squareByRow(scan scanFunc) (*PoolSquare, error) {
 		}
 	}
 
+	if reservedUntil != nil {
+		until := reservedUntil.In(locationNewYork)
+		gs.reservedUntil = &until
+	}
+
 	gs.Modified = gs.Modified.In(locationNewYork)
 
 	return &gs, nil
@@ -568,7 +594,7 @@ func (p *Pool) squareByRow(scan scanFunc) (*PoolSquare, error) {
 // Logs will return all pool square logs for the pool
 func (p *Pool) Logs(ctx context.Context, offset int64, limit int) ([]*PoolSquareLog, error) {
 	const query = `
-		SELECT pool_squares_logs.id, pool_square_id, square_id, pool_squares_logs.user_id, pool_squares_logs.state, pool_squares_logs.claimant, remote_addr, note, pool_squares_logs.created
+		SELECT pool_squares_logs.id, pool_square_id, square_id, pool_squares_logs.user_id, pool_squares_logs.state, pool_squares_logs.claimant, remote_addr, note, payment_amount, payment_method, payment_reference, pool_squares_logs.created
 		FROM pool_squares_logs
 		INNER JOIN pool_squares ON pool_squares_logs.pool_square_id = pool_squares.id
 		WHERE pool_squares.pool_id = $1
@@ -613,7 +639,11 @@ func (p *Pool) LogsCount(ctx context.Context) (int64, error) {
 
 // DefaultGrid will return the default grid for the pool
 func (p *Pool) DefaultGrid(ctx context.Context) (*Grid, error) {
-	grids, err := p.Grids(ctx, 0, 1)
+	return p.defaultGrid(ctx, p.model.DB)
+}
+
+func (p *Pool) defaultGrid(ctx context.Context, q Queryable) (*Grid, error) {
+	grids, err := p.grids(ctx, q, 0, 1, true)
 	if err != nil {
 		return nil, err
 	}
@@ -629,6 +659,10 @@ func (p *Pool) DefaultGrid(ctx context.Context) (*Grid, error) {
 // argument to return grids with all states
 func (p *Pool) Grids(ctx context.Context, offset int64, limit int, allStates ...bool) ([]*Grid, error) {
 	activeOnly := len(allStates) == 0 || !allStates[0]
+	return p.grids(ctx, p.model.DB, offset, limit, activeOnly)
+}
+
+func (p *Pool) grids(ctx context.Context, q Queryable, offset int64, limit int, activeOnly bool) ([]*Grid, error) {
 	stateClause := ""
 	if activeOnly {
 		stateClause = " AND state = 'active'"
@@ -643,7 +677,7 @@ OFFSET $2
 LIMIT $3
 `
 
-	rows, err := p.model.DB.QueryContext(ctx, query, p.id, offset, limit)
+	rows, err := q.QueryContext(ctx, query, p.id, offset, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -752,9 +786,10 @@ func (p *Pool) SetGridsOrder(ctx context.Context, gridIDs []int64) error {
 // NewGrid will create a new grid for the pool with some default settings
 func (p *Pool) NewGrid() *Grid {
 	return &Grid{
-		model:    p.model,
-		poolID:   p.id,
-		settings: &GridSettings{},
+		model:        p.model,
+		poolID:       p.id,
+		overtimeMode: OvertimeModeInclude,
+		settings:     &GridSettings{},
 	}
 }
 
@@ -0,0 +1,163 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxAutoLockMinutes is the furthest ahead of kickoff that a pool can be set to lock
+const MaxAutoLockMinutes = 60 * 24
+
+// PoolAutoLock locks a pool when the earliest event linked to one of its grids starts, or MinutesBefore it starts.
+// The lock follows changes to the event's schedule until it takes effect.
+type PoolAutoLock struct {
+	MinutesBefore int `json:"minutesBefore"`
+}
+
+// syncAutoLocksQuery moves the lock of every pool with a pending auto-lock to the start of its earliest linked event.
+// Pools that are already locked are left alone so that a schedule change cannot unlock them.
+const syncAutoLocksQuery = `
+	UPDATE pools
+	SET locks = kickoffs.kickoff - make_interval(mins => pool_auto_locks.minutes_before),
+	    modified = (NOW() AT TIME ZONE 'utc')
+	FROM pool_auto_locks
+	INNER JOIN (
+		SELECT grids.pool_id, MIN(sports_events.event_date) AS kickoff
+		FROM grids
+		INNER JOIN sports_events ON grids.sports_event_id = sports_events.id
+		WHERE grids.state = 'active'
+		GROUP BY grids.pool_id
+	) kickoffs ON kickoffs.pool_id = pool_auto_locks.pool_id
+	WHERE pools.id = pool_auto_locks.pool_id AND
+	      pool_auto_locks.locked_at IS NULL AND
+	      NOT pools.archived AND
+	      (pools.locks IS NULL OR pools.locks > (NOW() AT TIME ZONE 'utc')) AND
+	      pools.locks IS DISTINCT FROM kickoffs.kickoff - make_interval(mins => pool_auto_locks.minutes_before)`
+
+// AutoLock returns the pool's auto-lock, or nil if the pool is locked by hand
+func (p *Pool) AutoLock(ctx context.Context) (*PoolAutoLock, error) {
+	autoLock := &PoolAutoLock{}
+	row := p.model.DB.QueryRowContext(ctx, "SELECT minutes_before FROM pool_auto_locks WHERE pool_id = $1", p.id)
+	if err := row.Scan(&autoLock.MinutesBefore); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("loading auto-lock: %w", err)
+	}
+
+	return autoLock, nil
+}
+
+// SetAutoLock will make the pool lock itself at kickoff and move its lock to match. A nil auto-lock leaves the lock
+// as it is, to be changed by hand.
+func (p *Pool) SetAutoLock(ctx context.Context, autoLock *PoolAutoLock) error {
+	if autoLock == nil {
+		if _, err := p.model.DB.ExecContext(ctx, "DELETE FROM pool_auto_locks WHERE pool_id = $1", p.id); err != nil {
+			return fmt.Errorf("removing auto-lock: %w", err)
+		}
+
+		return nil
+	}
+
+	if autoLock.MinutesBefore < 0 || autoLock.MinutesBefore > MaxAutoLockMinutes {
+		return fmt.Errorf("auto-lock must be between 0 and %d minutes before kickoff", MaxAutoLockMinutes)
+	}
+
+	// a pool that is already locked is not locked again, so its auto-lock is done as soon as it is set
+	const query = `
+		INSERT INTO pool_auto_locks (pool_id, minutes_before, locked_at)
+		SELECT id, $2, CASE WHEN locks <= (NOW() AT TIME ZONE 'utc') THEN (NOW() AT TIME ZONE 'utc') END
+		FROM pools
+		WHERE id = $1
+		ON CONFLICT (pool_id) DO UPDATE
+		SET minutes_before = EXCLUDED.minutes_before,
+		    locked_at = EXCLUDED.locked_at,
+		    modified = (NOW() AT TIME ZONE 'utc')`
+	if _, err := p.model.DB.ExecContext(ctx, query, p.id, autoLock.MinutesBefore); err != nil {
+		return fmt.Errorf("saving auto-lock: %w", err)
+	}
+
+	var locks time.Time
+	row := p.model.DB.QueryRowContext(ctx, syncAutoLocksQuery+" AND pools.id = $1 RETURNING pools.locks", p.id)
+	if err := row.Scan(&locks); err != nil {
+		// the pool has no linked events yet, or is already locked
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+
+		return fmt.Errorf("syncing auto-lock: %w", err)
+	}
+
+	p.locks = locks.In(locationNewYork)
+	return nil
+}
+
+// SyncAutoLocks will move the lock of every pool with a pending auto-lock to match its linked events
+func (m *Model) SyncAutoLocks(ctx context.Context) error {
+	if _, err := m.DB.ExecContext(ctx, syncAutoLocksQuery); err != nil {
+		return fmt.Errorf("syncing auto-locks: %w", err)
+	}
+
+	return nil
+}
+
+// syncAutoLocksForEvent will move the lock of the pools with a pending auto-lock that are linked to the event
+func syncAutoLocksForEvent(ctx context.Context, q Queryable, eventID int64) error {
+	query := syncAutoLocksQuery + " AND pools.id IN (SELECT pool_id FROM grids WHERE sports_event_id = $1)"
+	if _, err := q.ExecContext(ctx, query, eventID); err != nil {
+		return fmt.Errorf("syncing auto-locks: %w", err)
+	}
+
+	return nil
+}
+
+// LockDueAutoLocks will mark the auto-locks whose lock time has passed as done, and returns the tokens of their pools
+func (m *Model) LockDueAutoLocks(ctx context.Context) ([]string, error) {
+	const query = `
+		UPDATE pool_auto_locks
+		SET locked_at = (NOW() AT TIME ZONE 'utc')
+		FROM pools
+		WHERE pools.id = pool_auto_locks.pool_id AND
+		      pool_auto_locks.locked_at IS NULL AND
+		      NOT pools.archived AND
+		      pools.locks <= (NOW() AT TIME ZONE 'utc')
+		RETURNING pools.token`
+	rows, err := m.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("locking due auto-locks: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := make([]string, 0)
+	for rows.Next() {
+		var token string
+		if err := rows.Scan(&token); err != nil {
+			return nil, fmt.Errorf("scanning pool token: %w", err)
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, rows.Err()
+}
@@ -0,0 +1,248 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+)
+
+// PoolCloneOptions are what to copy when a pool is cloned, beyond its settings and grids
+type PoolCloneOptions struct {
+	Name string
+	// IncludeMembers copies the members of the pool, keeping who is a manager
+	IncludeMembers bool
+	// IncludeClaimants claims the same squares for the same claimants. Payments are not copied, and shared squares
+	// are left unclaimed.
+	IncludeClaimants bool
+	// SportsEvent links the first grid to a different event, taking its teams, team colors and date
+	SportsEvent *SportsEvent
+}
+
+// clonePoolSettingsQueries copy the settings kept outside the pools table from the pool in $1 to the pool in $2
+var clonePoolSettingsQueries = []string{
+	`INSERT INTO pool_payout_settings (pool_id, square_price, payout_type, rollover_final_rule)
+	 SELECT $2, square_price, payout_type, rollover_final_rule FROM pool_payout_settings WHERE pool_id = $1`,
+	`INSERT INTO pool_period_payouts (pool_id, period, value)
+	 SELECT $2, period, value FROM pool_period_payouts WHERE pool_id = $1`,
+	`INSERT INTO pool_square_limits (pool_id, max_per_user, max_per_claimant)
+	 SELECT $2, max_per_user, max_per_claimant FROM pool_square_limits WHERE pool_id = $1`,
+	`INSERT INTO pool_hold_settings (pool_id, hold_hours)
+	 SELECT $2, hold_hours FROM pool_hold_settings WHERE pool_id = $1`,
+	`INSERT INTO pool_auto_locks (pool_id, minutes_before)
+	 SELECT $2, minutes_before FROM pool_auto_locks WHERE pool_id = $1`,
+}
+
+// Clone will create a new pool owned by the user with the same grid type, number set config, password settings and
+// pool settings. Each active grid is copied with its teams and settings, but without its numbers, event or date. The
+// clone is created in a single transaction, so a failure part way through does not leave a partial pool behind.
+func (p *Pool) Clone(ctx context.Context, userID int64, opts PoolCloneOptions) (*Pool, error) {
+	tx, err := p.model.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// the password is replaced by the pool's own hash below
+	clone, err := p.model.newPool(ctx, tx, userID, opts.Name, p.gridType, "", p.numberSetConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	clone.passwordHash = p.passwordHash
+	clone.passwordRequired = p.passwordRequired
+	clone.openAccessOnLock = p.openAccessOnLock
+	if err := clone.save(ctx, tx); err != nil {
+		return nil, fmt.Errorf("saving password settings: %w", err)
+	}
+
+	for _, query := range clonePoolSettingsQueries {
+		if _, err := tx.ExecContext(ctx, query, p.id, clone.id); err != nil {
+			return nil, fmt.Errorf("copying pool settings: %w", err)
+		}
+	}
+
+	if err := p.cloneGrids(ctx, tx, clone, opts.SportsEvent); err != nil {
+		return nil, err
+	}
+
+	if opts.IncludeMembers {
+		const query = `
+			INSERT INTO pools_users (pool_id, user_id, role)
+			SELECT $2, user_id, role
+			FROM pools_users
+			WHERE pool_id = $1 AND user_id <> $3
+			ON CONFLICT DO NOTHING`
+		if _, err := tx.ExecContext(ctx, query, p.id, clone.id, userID); err != nil {
+			return nil, fmt.Errorf("copying members: %w", err)
+		}
+	}
+
+	if opts.IncludeClaimants {
+		if err := p.cloneClaims(ctx, tx, clone); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return clone, nil
+}
+
+// cloneGrids copies the pool's grids into the clone, reusing the grid every new pool starts with for the first one
+func (p *Pool) cloneGrids(ctx context.Context, tx *sql.Tx, clone *Pool, event *SportsEvent) error {
+	grids, err := p.Grids(ctx, 0, MaxGridsPerPool)
+	if err != nil {
+		return err
+	}
+
+	for i, grid := range grids {
+		if err := grid.LoadSettings(ctx); err != nil {
+			return err
+		}
+
+		target := clone.NewGrid()
+		if i == 0 {
+			if target, err = clone.defaultGrid(ctx, tx); err != nil {
+				return err
+			}
+		}
+
+		target.label = grid.label
+		target.homeTeamName = grid.homeTeamName
+		target.awayTeamName = grid.awayTeamName
+		target.rollover = grid.rollover
+		target.payoutConfig = grid.payoutConfig
+		target.overtimeMode = grid.overtimeMode
+
+		settings := *grid.settings
+		settings.gridID = target.id
+		settings.modified = nil
+		target.settings = &settings
+
+		if i == 0 && event != nil {
+			target.linkSportsEvent(event)
+		}
+
+		if err := target.save(ctx, tx); err != nil {
+			return fmt.Errorf("copying grid %d: %w", grid.id, err)
+		}
+	}
+
+	return nil
+}
+
+// linkSportsEvent links the grid to the event and takes the names and colors of its teams
+func (g *Grid) linkSportsEvent(event *SportsEvent) {
+	g.SetBDLEventID(&event.ID)
+	g.SetEventDate(event.EventDate)
+
+	if team := event.HomeTeam(); team != nil {
+		g.SetHomeTeamName(team.FullName)
+		if team.Color != nil {
+			g.settings.SetHomeTeamColor1("#" + *team.Color)
+		}
+		if team.AlternateColor != nil {
+			g.settings.SetHomeTeamColor2("#" + *team.AlternateColor)
+		}
+	}
+
+	if team := event.AwayTeam(); team != nil {
+		g.SetAwayTeamName(team.FullName)
+		if team.Color != nil {
+			g.settings.SetAwayTeamColor1("#" + *team.Color)
+		}
+		if team.AlternateColor != nil {
+			g.settings.SetAwayTeamColor2("#" + *team.AlternateColor)
+		}
+	}
+}
+
+// cloneClaims claims the clone's squares for whoever holds the same squares in the pool
+func (p *Pool) cloneClaims(ctx context.Context, tx *sql.Tx, clone *Pool) error {
+	squares, err := p.Squares()
+	if err != nil {
+		return err
+	}
+
+	if err := p.LoadSquareShares(ctx); err != nil {
+		return err
+	}
+
+	targets, err := clone.loadSquares(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	squareIDs := make([]int, 0, len(squares))
+	for squareID := range squares {
+		squareIDs = append(squareIDs, squareID)
+	}
+	sort.Ints(squareIDs)
+
+	poolSquareLog := PoolSquareLog{Note: fmt.Sprintf("system: copied from pool %s", p.token)}
+	for _, squareID := range squareIDs {
+		square := squares[squareID]
+		// secondary squares are claimed with their primary square
+		if square.ParentID > 0 || square.IsShared() || square.State == PoolSquareStateUnclaimed {
+			continue
+		}
+
+		target, ok := targets[squareID]
+		if !ok {
+			continue
+		}
+
+		if err := cloneClaim(ctx, tx, square, target, poolSquareLog); err != nil {
+			return err
+		}
+
+		for _, childID := range square.ChildSquareIDs {
+			child, ok := targets[int(childID)]
+			if !ok {
+				continue
+			}
+
+			if err := cloneClaim(ctx, tx, square, child, PoolSquareLog{Note: poolSquareLog.Note + " (secondary)"}); err != nil {
+				return err
+			}
+
+			if err := child.SetParentSquare(ctx, tx, target); err != nil {
+				return fmt.Errorf("linking square %d: %w", child.SquareID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func cloneClaim(ctx context.Context, tx Queryable, square, target *PoolSquare, poolSquareLog PoolSquareLog) error {
+	target.SetClaimant(square.claimant)
+	target.SetUserID(square.userID)
+	target.State = PoolSquareStateClaimed
+
+	if err := target.Save(ctx, tx, true, poolSquareLog); err != nil {
+		return fmt.Errorf("claiming square %d: %w", target.SquareID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/onsi/gomega"
+)
+
+func TestGridLinkSportsEvent(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	color := "002244"
+	alternate := "c60c30"
+	eventDate := time.Date(2026, time.February, 8, 23, 30, 0, 0, time.UTC)
+	event := &SportsEvent{
+		ID:        42,
+		EventDate: eventDate,
+		homeTeam:  &SportsTeam{FullName: "New England Patriots", Color: &color, AlternateColor: &alternate},
+		awayTeam:  &SportsTeam{FullName: "Seattle Seahawks"},
+	}
+
+	grid := &Grid{settings: &GridSettings{}}
+	grid.SetAwayTeamName("Away")
+	grid.settings.SetAwayTeamColor1("#ff0000")
+	grid.linkSportsEvent(event)
+
+	g.Expect(grid.BDLEventID()).Should(gomega.Equal(&event.ID))
+	g.Expect(grid.EventDate()).Should(gomega.Equal(eventDate))
+	g.Expect(grid.HomeTeamName()).Should(gomega.Equal("New England Patriots"))
+	g.Expect(grid.AwayTeamName()).Should(gomega.Equal("Seattle Seahawks"))
+	g.Expect(grid.settings.HomeTeamColor1()).Should(gomega.Equal("#002244"))
+	g.Expect(grid.settings.HomeTeamColor2()).Should(gomega.Equal("#c60c30"))
+
+	// teams without colors keep the grid's colors
+	g.Expect(grid.settings.AwayTeamColor1()).Should(gomega.Equal("#ff0000"))
+}
+
+func TestPoolClone(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	defer db.Close()
+
+	m := New(db)
+	now := time.Now()
+
+	poolColumns := []string{"id", "token", "user_id", "name", "grid_type", "number_set_config", "password_hash",
+		"password_required", "open_access_on_lock", "locks", "created", "modified", "check_id", "archived"}
+	gridColumns := []string{"id", "pool_id", "ord", "label", "home_team_name", "home_numbers", "away_team_name",
+		"away_numbers", "event_date", "rollover", "state", "created", "modified", "manual_draw", "sports_event_id",
+		"payout_config", "overtime_mode"}
+	gridSettingsColumns := []string{"grid_id", "home_team_color_1", "home_team_color_2", "away_team_color_1",
+		"away_team_color_2", "notes", "branding_image_url", "branding_image_alt", "modified"}
+	squareColumns := []string{"id", "square_id", "parent_id", "user_id", "state", "claimant", "modified",
+		"parent_square_id", "child_square_ids", "reserved_until"}
+
+	pool := &Pool{
+		model:            m,
+		id:               1,
+		token:            "src",
+		gridType:         GridTypeRoll100,
+		numberSetConfig:  NumberSetConfigStandard,
+		passwordHash:     "source-hash",
+		passwordRequired: true,
+		openAccessOnLock: true,
+	}
+
+	homeColor := "002244"
+	homeAlternate := "c60c30"
+	eventDate := time.Date(2026, time.February, 8, 23, 30, 0, 0, time.UTC)
+	event := &SportsEvent{
+		ID:        42,
+		EventDate: eventDate,
+		homeTeam:  &SportsTeam{FullName: "New England Patriots", Color: &homeColor, AlternateColor: &homeAlternate},
+		awayTeam:  &SportsTeam{FullName: "Seattle Seahawks"},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare("SELECT new_token FROM new_token").
+		ExpectQuery().
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"new_token"}).AddRow(true))
+	mock.ExpectQuery("FROM new_pool\\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$7\\)").
+		WithArgs(sqlmock.AnyArg(), int64(200), "Copy", GridTypeRoll100, sqlmock.AnyArg(), 100, NumberSetConfigStandard).
+		WillReturnRows(sqlmock.NewRows(poolColumns).
+			AddRow(2, "clone", int64(200), "Copy", "roll100", "standard", "new-hash", false, false, nil, now, now, 0, false))
+
+	// the clone takes the pool's password hash rather than the one it was created with
+	mock.ExpectExec("UPDATE pools SET name = \\$1").
+		WithArgs("Copy", GridTypeRoll100, "source-hash", nil, 0, false, true, true, NumberSetConfigStandard, int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	for _, table := range []string{"pool_payout_settings", "pool_period_payouts", "pool_square_limits", "pool_hold_settings", "pool_auto_locks"} {
+		mock.ExpectExec("INSERT INTO "+table+" .+ SELECT \\$2, .+ FROM "+table+" WHERE pool_id = \\$1").
+			WithArgs(int64(1), int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	mock.ExpectQuery("SELECT .+ FROM grids WHERE pool_id = \\$1 AND state = 'active'").
+		WithArgs(int64(1), int64(0), MaxGridsPerPool).
+		WillReturnRows(sqlmock.NewRows(gridColumns).
+			AddRow(10, int64(1), 0, "Game 1", "Home 1", "{1,2,3,4,5,6,7,8,9,0}", "Away 1", "{0,9,8,7,6,5,4,3,2,1}", now, true, "active", now, now, false, int64(7), nil, "separate").
+			AddRow(11, int64(1), 1, nil, "Home 2", nil, "Away 2", nil, nil, false, "active", now, now, false, nil, nil, "include"))
+
+	// the first grid reuses the grid the new pool was created with, and is linked to the event
+	mock.ExpectQuery("SELECT .+ FROM grid_settings WHERE grid_id = \\$1").
+		WithArgs(int64(10)).
+		WillReturnRows(sqlmock.NewRows(gridSettingsColumns).
+			AddRow(int64(10), "#111111", "#222222", "#333333", "#444444", "Pay the treasurer", nil, nil, now))
+	mock.ExpectQuery("SELECT .+ FROM grids WHERE pool_id = \\$1 AND state = 'active'").
+		WithArgs(int64(2), int64(0), 1).
+		WillReturnRows(sqlmock.NewRows(gridColumns).
+			AddRow(20, int64(2), 0, nil, nil, nil, nil, nil, nil, false, "active", now, now, false, nil, nil, "include"))
+	mock.ExpectExec("UPDATE grid_settings SET").
+		WithArgs("#002244", "#c60c30", "#333333", "#444444", "Pay the treasurer", nil, nil, int64(20)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE grids SET ord = \\$1").
+		WithArgs(0, "New England Patriots", nil, "Seattle Seahawks", nil, false, eventDate, true, Active, "Game 1", int64(42), nil, OvertimeModeSeparate, int64(20)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// other grids are created, without their numbers, event or date
+	mock.ExpectQuery("SELECT .+ FROM grid_settings WHERE grid_id = \\$1").
+		WithArgs(int64(11)).
+		WillReturnRows(sqlmock.NewRows(gridSettingsColumns).
+			AddRow(int64(11), "#555555", "#666666", "#777777", "#888888", nil, nil, nil, now))
+	mock.ExpectQuery("FROM new_grid\\(\\$1, \\$2\\)").
+		WithArgs(int64(2), MaxGridsPerPool).
+		WillReturnRows(sqlmock.NewRows(gridColumns).
+			AddRow(21, int64(2), 1, nil, nil, nil, nil, nil, nil, false, "active", now, now, false, nil, nil, "include"))
+	mock.ExpectExec("UPDATE grid_settings SET").
+		WithArgs("#555555", "#666666", "#777777", "#888888", nil, nil, nil, int64(21)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE grids SET ord = \\$1").
+		WithArgs(1, "Home 2", nil, "Away 2", nil, false, nil, false, Active, nil, nil, nil, OvertimeModeInclude, int64(21)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectExec("INSERT INTO pools_users \\(pool_id, user_id, role\\) SELECT \\$2, user_id, role FROM pools_users WHERE pool_id = \\$1 AND user_id <> \\$3").
+		WithArgs(int64(1), int64(2), int64(200)).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	// square 3 is the secondary square of square 1, and square 2 is unclaimed
+	mock.ExpectQuery("SELECT ps.id, .+ FROM pool_squares ps").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(squareColumns).
+			AddRow(int64(101), 1, nil, int64(300), "paid-full", "Alice", now, nil, "{3}", nil).
+			AddRow(int64(102), 2, nil, nil, "unclaimed", nil, now, nil, nil, nil).
+			AddRow(int64(103), 3, int64(101), int64(300), "paid-full", "Alice", now, 1, nil, nil))
+	mock.ExpectQuery("SELECT .+ FROM pool_square_shares").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "pool_square_id", "user_id", "claimant", "share", "state", "modified"}))
+	mock.ExpectQuery("SELECT ps.id, .+ FROM pool_squares ps").
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows(squareColumns).
+			AddRow(int64(201), 1, nil, nil, "unclaimed", nil, now, nil, nil, nil).
+			AddRow(int64(202), 2, nil, nil, "unclaimed", nil, now, nil, nil, nil).
+			AddRow(int64(203), 3, nil, nil, "unclaimed", nil, now, nil, nil, nil))
+
+	// claims are copied without their payments, and the secondary square is linked to its primary square
+	mock.ExpectQuery("SELECT \\* FROM update_pool_square").
+		WithArgs(int64(201), PoolSquareStateClaimed, "Alice", int64(300), nil, "system: copied from pool src", true).
+		WillReturnRows(sqlmock.NewRows([]string{"update_pool_square"}).AddRow(true))
+	mock.ExpectQuery("SELECT \\* FROM update_pool_square").
+		WithArgs(int64(203), PoolSquareStateClaimed, "Alice", int64(300), nil, "system: copied from pool src (secondary)", true).
+		WillReturnRows(sqlmock.NewRows([]string{"update_pool_square"}).AddRow(true))
+	mock.ExpectExec("UPDATE pool_squares SET parent_id = \\$1 WHERE id = \\$2").
+		WithArgs(int64(201), int64(203)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	clone, err := pool.Clone(context.Background(), 200, PoolCloneOptions{
+		Name:             "Copy",
+		IncludeMembers:   true,
+		IncludeClaimants: true,
+		SportsEvent:      event,
+	})
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	g.Expect(clone.ID()).Should(gomega.Equal(int64(2)))
+	g.Expect(clone.passwordHash).Should(gomega.Equal("source-hash"))
+	g.Expect(clone.PasswordRequired()).Should(gomega.BeTrue())
+	g.Expect(clone.OpenAccessOnLock()).Should(gomega.BeTrue())
+	g.Expect(mock.ExpectationsWereMet()).Should(gomega.Succeed())
+}
@@ -29,13 +29,66 @@ import (
 
 const inviteTokenLen = 10
 
-// PoolInvite represents an invite token for joining a pool
+// bounds for invite links
+const (
+	// InviteLabelMaxLength is the longest label an invite link may have
+	InviteLabelMaxLength = 50
+	// InviteMaxUses is the most uses an invite link may be limited to. Links may also allow any number of uses.
+	InviteMaxUses = 10000
+)
+
+// ErrInvalidInvite is an error when an invite does not exist or does not belong to the pool
+var ErrInvalidInvite = errors.New("invalid invite token")
+
+// ErrInviteExpired is an error when an invite is used after it has expired
+var ErrInviteExpired = errors.New("this invite link has expired")
+
+// ErrInviteRevoked is an error when an invite is used after a manager has revoked it
+var ErrInviteRevoked = errors.New("this invite link has been revoked")
+
+// ErrInviteUsedUp is an error when an invite has already been used as many times as it allows
+var ErrInviteUsedUp = errors.New("this invite link has been used the maximum number of times")
+
+// ErrInviteNotFound is an error when an invite link cannot be found in the pool
+var ErrInviteNotFound = errors.New("the invite link could not be found")
+
+// PoolInvite represents an invite token for joining a pool. Invites created by NewPoolInvite are tied to the pool's
+// check ID, and stop working when the password changes. Invite links created by NewInviteLink have a nil CheckID and
+// are revoked individually.
 type PoolInvite struct {
-	Token     string    `json:"token"`
-	PoolID    int64     `json:"-"`
-	CheckID   int       `json:"-"`
-	ExpiresAt time.Time `json:"-"`
-	Created   time.Time `json:"-"`
+	Token     string     `json:"token"`
+	PoolID    int64      `json:"-"`
+	CheckID   *int       `json:"-"`
+	Label     string     `json:"label"`
+	Role      PoolRole   `json:"role"`
+	MaxUses   *int       `json:"maxUses"`
+	Uses      int        `json:"uses"`
+	CreatedBy *int64     `json:"createdBy"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+	Revoked   *time.Time `json:"revoked"`
+	Created   time.Time  `json:"created"`
+}
+
+const inviteColumns = "token, pool_id, check_id, label, role, max_uses, uses, created_by, expires_at, revoked, created"
+
+func poolInviteByRow(scan scanFunc) (*PoolInvite, error) {
+	invite := &PoolInvite{}
+	if err := scan(&invite.Token, &invite.PoolID, &invite.CheckID, &invite.Label, &invite.Role, &invite.MaxUses,
+		&invite.Uses, &invite.CreatedBy, &invite.ExpiresAt, &invite.Revoked, &invite.Created); err != nil {
+		return nil, err
+	}
+
+	if invite.ExpiresAt != nil {
+		expiresAt := invite.ExpiresAt.In(locationNewYork)
+		invite.ExpiresAt = &expiresAt
+	}
+	if invite.Revoked != nil {
+		revoked := invite.Revoked.In(locationNewYork)
+		invite.Revoked = &revoked
+	}
+	invite.Created = invite.Created.In(locationNewYork)
+
+	return invite, nil
 }
 
 // NewPoolInvite creates a new invite token for a pool. It retries on token collision.
@@ -48,13 +101,49 @@ func (m *Model) NewPoolInvite(ctx context.Context, poolID int64, checkID int, tt
 
 		expiresAt := time.Now().Add(ttl)
 
-		invite := &PoolInvite{}
-		err = m.DB.QueryRowContext(ctx,
+		invite, err := poolInviteByRow(m.DB.QueryRowContext(ctx,
 			`INSERT INTO pool_invites (token, pool_id, check_id, expires_at)
 			 VALUES ($1, $2, $3, $4)
-			 RETURNING token, pool_id, check_id, expires_at, created`,
+			 RETURNING `+inviteColumns,
 			token, poolID, checkID, expiresAt,
-		).Scan(&invite.Token, &invite.PoolID, &invite.CheckID, &invite.ExpiresAt, &invite.Created)
+		).Scan)
+
+		if err != nil {
+			// Token collision — retry
+			continue
+		}
+
+		return invite, nil
+	}
+
+	return nil, ErrRetryLimitExceeded
+}
+
+// NewInviteLink creates a named invite link for the pool. Users who join with it are given the role. A nil maxUses
+// allows any number of uses, and a nil expiresAt means the link never expires. It retries on token collision.
+func (p *Pool) NewInviteLink(ctx context.Context, label string, role PoolRole, maxUses *int, expiresAt *time.Time, createdBy int64) (*PoolInvite, error) {
+	if !role.IsValid() {
+		return nil, fmt.Errorf("invalid pool role %q", role)
+	}
+
+	var expiresAtUTC *time.Time
+	if expiresAt != nil {
+		utc := expiresAt.UTC()
+		expiresAtUTC = &utc
+	}
+
+	for i := 0; i <= maxRetries; i++ {
+		token, err := tokengen.Generate(inviteTokenLen)
+		if err != nil {
+			return nil, fmt.Errorf("generating invite token: %w", err)
+		}
+
+		invite, err := poolInviteByRow(p.model.DB.QueryRowContext(ctx,
+			`INSERT INTO pool_invites (token, pool_id, label, role, max_uses, created_by, expires_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7)
+			 RETURNING `+inviteColumns,
+			token, p.id, label, role, maxUses, createdBy, expiresAtUTC,
+		).Scan)
 
 		if err != nil {
 			// Token collision — retry
@@ -69,13 +158,12 @@ func (m *Model) NewPoolInvite(ctx context.Context, poolID int64, checkID int, tt
 
 // PoolInviteByToken looks up an invite by its short token
 func (m *Model) PoolInviteByToken(ctx context.Context, token string) (*PoolInvite, error) {
-	invite := &PoolInvite{}
-	err := m.DB.QueryRowContext(ctx,
-		`SELECT token, pool_id, check_id, expires_at, created
+	invite, err := poolInviteByRow(m.DB.QueryRowContext(ctx,
+		`SELECT `+inviteColumns+`
 		 FROM pool_invites
 		 WHERE token = $1`,
 		token,
-	).Scan(&invite.Token, &invite.PoolID, &invite.CheckID, &invite.ExpiresAt, &invite.Created)
+	).Scan)
 
 	if err != nil {
 		return nil, fmt.Errorf("looking up pool invite: %w", err)
@@ -86,15 +174,14 @@ func (m *Model) PoolInviteByToken(ctx context.Context, token string) (*PoolInvit
 
 // ActiveInvite returns the current valid invite for the pool (matching check_id, not expired), or nil if none exists
 func (p *Pool) ActiveInvite(ctx context.Context) (*PoolInvite, error) {
-	invite := &PoolInvite{}
-	err := p.model.DB.QueryRowContext(ctx,
-		`SELECT token, pool_id, check_id, expires_at, created
+	invite, err := poolInviteByRow(p.model.DB.QueryRowContext(ctx,
+		`SELECT `+inviteColumns+`
 		 FROM pool_invites
 		 WHERE pool_id = $1 AND check_id = $2 AND expires_at > (NOW() AT TIME ZONE 'utc')
 		 ORDER BY created DESC
 		 LIMIT 1`,
 		p.id, p.checkID,
-	).Scan(&invite.Token, &invite.PoolID, &invite.CheckID, &invite.ExpiresAt, &invite.Created)
+	).Scan)
 
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
@@ -105,3 +192,144 @@ func (p *Pool) ActiveInvite(ctx context.Context) (*PoolInvite, error) {
 
 	return invite, nil
 }
+
+// InviteLinks returns the pool's invite links, including those that have been revoked or have expired, newest first
+func (p *Pool) InviteLinks(ctx context.Context) ([]*PoolInvite, error) {
+	rows, err := p.model.DB.QueryContext(ctx,
+		`SELECT `+inviteColumns+`
+		 FROM pool_invites
+		 WHERE pool_id = $1 AND check_id IS NULL
+		 ORDER BY created DESC, token`,
+		p.id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("loading invite links: %w", err)
+	}
+	defer rows.Close()
+
+	invites := make([]*PoolInvite, 0)
+	for rows.Next() {
+		invite, err := poolInviteByRow(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("scanning invite link: %w", err)
+		}
+
+		invites = append(invites, invite)
+	}
+
+	return invites, rows.Err()
+}
+
+// RevokeInviteLink will stop the invite link from being used. Members who already joined with it are not affected.
+// ErrInviteNotFound is returned if the pool has no such link, or it has already been revoked.
+func (p *Pool) RevokeInviteLink(ctx context.Context, token string) error {
+	result, err := p.model.DB.ExecContext(ctx,
+		`UPDATE pool_invites
+		 SET revoked = (NOW() AT TIME ZONE 'utc')
+		 WHERE pool_id = $1 AND token = $2 AND check_id IS NULL AND revoked IS NULL`,
+		p.id, token,
+	)
+	if err != nil {
+		return fmt.Errorf("revoking invite link: %w", err)
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("revoking invite link: %w", err)
+	} else if n == 0 {
+		return ErrInviteNotFound
+	}
+
+	return nil
+}
+
+// Check returns nil if the invite may be used to join the pool, or an error explaining why it may not
+func (i *PoolInvite) Check(p *Pool, now time.Time) error {
+	if i.PoolID != p.id || (i.CheckID != nil && !p.CheckIDIsValid(*i.CheckID)) {
+		return ErrInvalidInvite
+	}
+
+	if i.Revoked != nil {
+		return ErrInviteRevoked
+	}
+
+	if i.ExpiresAt != nil && now.After(*i.ExpiresAt) {
+		return ErrInviteExpired
+	}
+
+	if i.MaxUses != nil && i.Uses >= *i.MaxUses {
+		return ErrInviteUsedUp
+	}
+
+	return nil
+}
+
+// JoinPoolWithInvite will link the user to the pool with the role the invite grants, and count it as a use of the
+// invite. Users who are already members keep their role, and the invite is not used. ErrInviteUsedUp is returned if
+// the invite was used up, revoked or expired since it was checked.
+func (u *User) JoinPoolWithInvite(ctx context.Context, p *Pool, invite *PoolInvite) error {
+	// no-op
+	if isManager, err := u.IsManagerOf(ctx, p); err != nil {
+		return fmt.Errorf("checking manager status: %w", err)
+	} else if isManager {
+		return nil
+	}
+
+	tx, err := u.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	const query = `
+		INSERT INTO pools_users (pool_id, user_id, role, invite_token, manager_granted_by, manager_granted_at)
+		SELECT $1, $2, $3, $4,
+		       CASE WHEN $3 = 'manager' THEN $5::BIGINT END,
+		       CASE WHEN $3 = 'manager' THEN (NOW() AT TIME ZONE 'utc') END
+		WHERE NOT EXISTS (SELECT 1 FROM pool_bans WHERE pool_id = $1 AND user_id = $2)
+		ON CONFLICT DO NOTHING`
+	result, err := tx.ExecContext(ctx, query, p.id, u.ID, invite.Role, invite.Token, invite.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("inserting pool user: %w", err)
+	}
+
+	// nothing is inserted if the user is already a member or is banned
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("inserting pool user: %w", err)
+	} else if n == 0 {
+		banned, err := p.IsBanned(ctx, u.ID)
+		if err != nil {
+			return err
+		}
+
+		if banned {
+			return ErrBannedFromPool
+		}
+
+		return nil
+	}
+
+	const useQuery = `
+		UPDATE pool_invites
+		SET uses = uses + 1
+		WHERE token = $1 AND
+		      revoked IS NULL AND
+		      (max_uses IS NULL OR uses < max_uses) AND
+		      (expires_at IS NULL OR expires_at > (NOW() AT TIME ZONE 'utc'))`
+	result, err = tx.ExecContext(ctx, useQuery, invite.Token)
+	if err != nil {
+		return fmt.Errorf("using invite: %w", err)
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("using invite: %w", err)
+	} else if n == 0 {
+		return ErrInviteUsedUp
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
+	invite.Uses++
+	return nil
+}
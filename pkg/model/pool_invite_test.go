@@ -39,8 +39,8 @@ func TestNewPoolInvite(t *testing.T) {
 	now := time.Now()
 	ttl := time.Hour * 24
 
-	rows := sqlmock.NewRows([]string{"token", "pool_id", "check_id", "expires_at", "created"}).
-		AddRow("abcdef1234", int64(1), 0, now.Add(ttl), now)
+	rows := sqlmock.NewRows([]string{"token", "pool_id", "check_id", "label", "role", "max_uses", "uses", "created_by", "expires_at", "revoked", "created"}).
+		AddRow("abcdef1234", int64(1), 0, "", "claimer", nil, 0, nil, now.Add(ttl), nil, now)
 
 	mock.ExpectQuery(`INSERT INTO pool_invites`).
 		WithArgs(sqlmock.AnyArg(), int64(1), 0, sqlmock.AnyArg()).
@@ -51,7 +51,8 @@ func TestNewPoolInvite(t *testing.T) {
 	g.Expect(invite).ShouldNot(gomega.BeNil())
 	g.Expect(invite.Token).Should(gomega.Equal("abcdef1234"))
 	g.Expect(invite.PoolID).Should(gomega.Equal(int64(1)))
-	g.Expect(invite.CheckID).Should(gomega.Equal(0))
+	g.Expect(invite.CheckID).ShouldNot(gomega.BeNil())
+	g.Expect(*invite.CheckID).Should(gomega.Equal(0))
 
 	g.Expect(mock.ExpectationsWereMet()).Should(gomega.Succeed())
 }
@@ -68,8 +69,8 @@ func TestPoolInviteByToken(t *testing.T) {
 	now := time.Now()
 	expiresAt := now.Add(time.Hour * 24)
 
-	rows := sqlmock.NewRows([]string{"token", "pool_id", "check_id", "expires_at", "created"}).
-		AddRow("abcdef1234", int64(1), 0, expiresAt, now)
+	rows := sqlmock.NewRows([]string{"token", "pool_id", "check_id", "label", "role", "max_uses", "uses", "created_by", "expires_at", "revoked", "created"}).
+		AddRow("abcdef1234", int64(1), 0, "", "claimer", nil, 0, nil, expiresAt, nil, now)
 
 	mock.ExpectQuery(`SELECT .+ FROM pool_invites WHERE token = \$1`).
 		WithArgs("abcdef1234").
@@ -80,7 +81,13 @@ func TestPoolInviteByToken(t *testing.T) {
 	g.Expect(invite).ShouldNot(gomega.BeNil())
 	g.Expect(invite.Token).Should(gomega.Equal("abcdef1234"))
 	g.Expect(invite.PoolID).Should(gomega.Equal(int64(1)))
-	g.Expect(invite.CheckID).Should(gomega.Equal(0))
+	g.Expect(invite.CheckID).ShouldNot(gomega.BeNil())
+	g.Expect(*invite.CheckID).Should(gomega.Equal(0))
+
+	// times are returned in the same zone as the rest of the pool
+	g.Expect(invite.ExpiresAt.Location()).Should(gomega.Equal(locationNewYork))
+	g.Expect(invite.ExpiresAt.Equal(expiresAt)).Should(gomega.BeTrue())
+	g.Expect(invite.Created.Location()).Should(gomega.Equal(locationNewYork))
 
 	g.Expect(mock.ExpectationsWereMet()).Should(gomega.Succeed())
 }
@@ -104,3 +111,29 @@ func TestPoolInviteByToken_NotFound(t *testing.T) {
 
 	g.Expect(mock.ExpectationsWereMet()).Should(gomega.Succeed())
 }
+
+func TestPoolInviteCheck(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	maxUses := 3
+	checkID := 0
+
+	p := &Pool{id: 1, checkID: 0}
+
+	g.Expect((&PoolInvite{PoolID: 1}).Check(p, now)).Should(gomega.Succeed())
+	g.Expect((&PoolInvite{PoolID: 1, CheckID: &checkID, ExpiresAt: &future}).Check(p, now)).Should(gomega.Succeed())
+	g.Expect((&PoolInvite{PoolID: 1, MaxUses: &maxUses, Uses: 2}).Check(p, now)).Should(gomega.Succeed())
+
+	g.Expect((&PoolInvite{PoolID: 2}).Check(p, now)).Should(gomega.MatchError(ErrInvalidInvite))
+	g.Expect((&PoolInvite{PoolID: 1, Revoked: &past}).Check(p, now)).Should(gomega.MatchError(ErrInviteRevoked))
+	g.Expect((&PoolInvite{PoolID: 1, ExpiresAt: &past}).Check(p, now)).Should(gomega.MatchError(ErrInviteExpired))
+	g.Expect((&PoolInvite{PoolID: 1, MaxUses: &maxUses, Uses: 3}).Check(p, now)).Should(gomega.MatchError(ErrInviteUsedUp))
+
+	// invites tied to the check ID stop working when the password changes
+	p.checkID = 1
+	g.Expect((&PoolInvite{PoolID: 1, CheckID: &checkID}).Check(p, now)).Should(gomega.MatchError(ErrInvalidInvite))
+	g.Expect((&PoolInvite{PoolID: 1}).Check(p, now)).Should(gomega.Succeed())
+}
@@ -0,0 +1,84 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNotPoolMember is an error when a user who does not belong to the pool is made a manager
+var ErrNotPoolMember = errors.New("the user is not a member of the pool")
+
+// PoolManager is a user who can manage a pool. The owner is always a manager.
+type PoolManager struct {
+	UserID int64 `json:"userId"`
+	// Name is the claimant name the user most recently claimed a square with, if any
+	Name    string `json:"name"`
+	IsOwner bool   `json:"isOwner"`
+	// GrantedBy and GrantedAt are nil for the owner and for managers made before grants were recorded
+	GrantedBy *int64     `json:"grantedBy"`
+	GrantedAt *time.Time `json:"grantedAt"`
+}
+
+// Managers returns the managers of the pool, starting with the owner, then in the order they were made managers
+func (p *Pool) Managers(ctx context.Context) ([]*PoolManager, error) {
+	const query = `
+		SELECT managers.user_id, managers.is_owner, managers.granted_by, managers.granted_at,
+		       COALESCE((
+		           SELECT claimant
+		           FROM pool_squares
+		           WHERE pool_id = $1 AND user_id = managers.user_id AND claimant IS NOT NULL
+		           ORDER BY modified DESC
+		           LIMIT 1
+		       ), '')
+		FROM (
+		    SELECT user_id, true AS is_owner, NULL::BIGINT AS granted_by, NULL::TIMESTAMP AS granted_at
+		    FROM pools
+		    WHERE id = $1
+		    UNION ALL
+		    SELECT user_id, false, manager_granted_by, manager_granted_at
+		    FROM pools_users
+		    WHERE pool_id = $1 AND is_manager AND user_id <> $2
+		) managers
+		ORDER BY managers.is_owner DESC, managers.granted_at NULLS FIRST, managers.user_id`
+	rows, err := p.model.DB.QueryContext(ctx, query, p.id, p.userID)
+	if err != nil {
+		return nil, fmt.Errorf("loading managers: %w", err)
+	}
+	defer rows.Close()
+
+	managers := make([]*PoolManager, 0)
+	for rows.Next() {
+		var m PoolManager
+		if err := rows.Scan(&m.UserID, &m.IsOwner, &m.GrantedBy, &m.GrantedAt, &m.Name); err != nil {
+			return nil, fmt.Errorf("scanning manager: %w", err)
+		}
+
+		if m.GrantedAt != nil {
+			grantedAt := m.GrantedAt.In(locationNewYork)
+			m.GrantedAt = &grantedAt
+		}
+
+		managers = append(managers, &m)
+	}
+
+	return managers, rows.Err()
+}
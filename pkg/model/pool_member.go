@@ -0,0 +1,220 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrBannedFromPool is an error when a user who was banned from a pool tries to join it
+var ErrBannedFromPool = errors.New("you have been removed from this pool")
+
+// MemberPaymentStatus summarizes whether a member has paid for their squares
+type MemberPaymentStatus string
+
+// constants for MemberPaymentStatus
+const (
+	// MemberPaymentStatusNone means the member holds no squares
+	MemberPaymentStatusNone MemberPaymentStatus = "none"
+	// MemberPaymentStatusUnpaid means none of the member's squares have been paid for
+	MemberPaymentStatusUnpaid MemberPaymentStatus = "unpaid"
+	// MemberPaymentStatusPartial means some of the member's squares have been paid for, in part or in full
+	MemberPaymentStatusPartial MemberPaymentStatus = "partial"
+	// MemberPaymentStatusPaid means every one of the member's squares has been paid in full
+	MemberPaymentStatusPaid MemberPaymentStatus = "paid"
+)
+
+// PoolMember is a user who has joined a pool
+type PoolMember struct {
+	UserID int64 `json:"userId"`
+	// Name is the claimant name the user most recently claimed a square with, if any
+	Name      string    `json:"name"`
+	Email     *string   `json:"email"`
+	IsManager bool      `json:"isManager"`
+	Role      PoolRole  `json:"role"`
+	Joined    time.Time `json:"joined"`
+	// Invite is the token of the invite the user joined with, if any
+	Invite *string `json:"invite"`
+	// Squares is the number of primary squares the member holds. PaidSquares of them are paid in full.
+	Squares       int                 `json:"squares"`
+	PaidSquares   int                 `json:"paidSquares"`
+	PaymentStatus MemberPaymentStatus `json:"paymentStatus"`
+}
+
+// PoolBan is a user who may not rejoin a pool
+type PoolBan struct {
+	UserID   int64     `json:"userId"`
+	Email    *string   `json:"email"`
+	BannedBy *int64    `json:"bannedBy"`
+	Created  time.Time `json:"created"`
+}
+
+// memberPaymentStatus returns the payment status of a member holding squares, of which paid are paid in full and
+// partial are partly paid
+func memberPaymentStatus(squares, paid, partial int) MemberPaymentStatus {
+	switch {
+	case squares == 0:
+		return MemberPaymentStatusNone
+	case paid == squares:
+		return MemberPaymentStatusPaid
+	case paid+partial > 0:
+		return MemberPaymentStatusPartial
+	default:
+		return MemberPaymentStatusUnpaid
+	}
+}
+
+// Members returns the users who have joined the pool in the order they joined. The owner is not included.
+func (p *Pool) Members(ctx context.Context, offset int64, limit int) ([]*PoolMember, error) {
+	const query = `
+		SELECT pools_users.user_id, users.email, pools_users.is_manager, pools_users.role, pools_users.created,
+		       pools_users.invite_token, COALESCE(squares.name, ''), squares.claimed, squares.paid, squares.partial
+		FROM pools_users
+		INNER JOIN users ON pools_users.user_id = users.id
+		CROSS JOIN LATERAL (
+		    SELECT (ARRAY_AGG(claimant ORDER BY modified DESC))[1] AS name,
+		           COUNT(*) AS claimed,
+		           COUNT(*) FILTER (WHERE state = 'paid-full') AS paid,
+		           COUNT(*) FILTER (WHERE state = 'paid-partial') AS partial
+		    FROM pool_squares
+		    WHERE pool_squares.pool_id = pools_users.pool_id AND
+		          pool_squares.user_id = pools_users.user_id AND
+		          pool_squares.parent_id IS NULL AND
+		          pool_squares.state <> 'unclaimed'
+		) squares
+		WHERE pools_users.pool_id = $1
+		ORDER BY pools_users.created, pools_users.user_id
+		OFFSET $2
+		LIMIT $3`
+	rows, err := p.model.DB.QueryContext(ctx, query, p.id, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("loading members: %w", err)
+	}
+	defer rows.Close()
+
+	members := make([]*PoolMember, 0)
+	for rows.Next() {
+		var m PoolMember
+		var partial int
+		if err := rows.Scan(&m.UserID, &m.Email, &m.IsManager, &m.Role, &m.Joined, &m.Invite, &m.Name, &m.Squares, &m.PaidSquares, &partial); err != nil {
+			return nil, fmt.Errorf("scanning member: %w", err)
+		}
+
+		m.Joined = m.Joined.In(locationNewYork)
+		m.PaymentStatus = memberPaymentStatus(m.Squares, m.PaidSquares, partial)
+		members = append(members, &m)
+	}
+
+	return members, rows.Err()
+}
+
+// MembersCount returns the number of users who have joined the pool
+func (p *Pool) MembersCount(ctx context.Context) (int64, error) {
+	var count int64
+	if err := p.model.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM pools_users WHERE pool_id = $1", p.id).Scan(&count); err != nil {
+		return 0, fmt.Errorf("counting members: %w", err)
+	}
+
+	return count, nil
+}
+
+// RemoveMember will remove the user from the pool. Their squares are left as they are. If ban is true, the user may
+// not rejoin the pool until they are unbanned. ErrNotPoolMember is returned if the user is neither removed nor banned.
+func (p *Pool) RemoveMember(ctx context.Context, userID int64, ban bool, bannedBy int64) error {
+	tx, err := p.model.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM pools_users WHERE pool_id = $1 AND user_id = $2", p.id, userID)
+	if err != nil {
+		return fmt.Errorf("removing member: %w", err)
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("removing member: %w", err)
+	}
+
+	if ban {
+		const query = "INSERT INTO pool_bans (pool_id, user_id, banned_by) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING"
+		if _, err := tx.ExecContext(ctx, query, p.id, userID, bannedBy); err != nil {
+			return fmt.Errorf("banning member: %w", err)
+		}
+	} else if removed == 0 {
+		return ErrNotPoolMember
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return nil
+}
+
+// IsBanned returns true if the user has been banned from the pool
+func (p *Pool) IsBanned(ctx context.Context, userID int64) (bool, error) {
+	var banned bool
+	row := p.model.DB.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM pool_bans WHERE pool_id = $1 AND user_id = $2)", p.id, userID)
+	if err := row.Scan(&banned); err != nil {
+		return false, fmt.Errorf("checking ban: %w", err)
+	}
+
+	return banned, nil
+}
+
+// Bans returns the users banned from the pool, most recent first
+func (p *Pool) Bans(ctx context.Context) ([]*PoolBan, error) {
+	const query = `
+		SELECT pool_bans.user_id, users.email, pool_bans.banned_by, pool_bans.created
+		FROM pool_bans
+		INNER JOIN users ON pool_bans.user_id = users.id
+		WHERE pool_bans.pool_id = $1
+		ORDER BY pool_bans.created DESC, pool_bans.user_id`
+	rows, err := p.model.DB.QueryContext(ctx, query, p.id)
+	if err != nil {
+		return nil, fmt.Errorf("loading bans: %w", err)
+	}
+	defer rows.Close()
+
+	bans := make([]*PoolBan, 0)
+	for rows.Next() {
+		var b PoolBan
+		if err := rows.Scan(&b.UserID, &b.Email, &b.BannedBy, &b.Created); err != nil {
+			return nil, fmt.Errorf("scanning ban: %w", err)
+		}
+
+		b.Created = b.Created.In(locationNewYork)
+		bans = append(bans, &b)
+	}
+
+	return bans, rows.Err()
+}
+
+// Unban will allow a banned user to rejoin the pool
+func (p *Pool) Unban(ctx context.Context, userID int64) error {
+	if _, err := p.model.DB.ExecContext(ctx, "DELETE FROM pool_bans WHERE pool_id = $1 AND user_id = $2", p.id, userID); err != nil {
+		return fmt.Errorf("removing ban: %w", err)
+	}
+
+	return nil
+}
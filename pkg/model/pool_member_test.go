@@ -0,0 +1,34 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestMemberPaymentStatus(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Expect(memberPaymentStatus(0, 0, 0)).Should(gomega.Equal(MemberPaymentStatusNone))
+	g.Expect(memberPaymentStatus(3, 0, 0)).Should(gomega.Equal(MemberPaymentStatusUnpaid))
+	g.Expect(memberPaymentStatus(3, 0, 1)).Should(gomega.Equal(MemberPaymentStatusPartial))
+	g.Expect(memberPaymentStatus(3, 2, 0)).Should(gomega.Equal(MemberPaymentStatusPartial))
+	g.Expect(memberPaymentStatus(3, 3, 0)).Should(gomega.Equal(MemberPaymentStatusPaid))
+}
@@ -0,0 +1,166 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// PoolRole decides what a user may do in a pool
+type PoolRole string
+
+// constants for PoolRole
+const (
+	// PoolRoleNone is the role of a user who has not joined the pool
+	PoolRoleNone PoolRole = ""
+	// PoolRoleSpectator may view the pool but not claim squares
+	PoolRoleSpectator PoolRole = "spectator"
+	// PoolRoleClaimer may claim squares. It is the role given to members when they join.
+	PoolRoleClaimer PoolRole = "claimer"
+	// PoolRoleTreasurer may claim squares and record payments, but may not change the grids or the pool
+	PoolRoleTreasurer PoolRole = "treasurer"
+	// PoolRoleGridEditor may claim squares and change the grids, but may not record payments
+	PoolRoleGridEditor PoolRole = "grid-editor"
+	// PoolRoleManager may do anything but change the roles of other members
+	PoolRoleManager PoolRole = "manager"
+	// PoolRoleOwner is the role of the user who created the pool. It cannot be given to members.
+	PoolRoleOwner PoolRole = "owner"
+)
+
+// poolRolePermissions are the actions each role may take
+var poolRolePermissions = map[PoolRole][]Action{
+	PoolRoleSpectator:  {},
+	PoolRoleClaimer:    {ActionClaimSquares},
+	PoolRoleTreasurer:  {ActionClaimSquares, ActionRecordPayments},
+	PoolRoleGridEditor: {ActionClaimSquares, ActionEditGrids},
+	PoolRoleManager:    {ActionClaimSquares, ActionRecordPayments, ActionEditGrids, ActionManagePool},
+	PoolRoleOwner:      {ActionClaimSquares, ActionRecordPayments, ActionEditGrids, ActionManagePool, ActionManageRoles},
+}
+
+// poolActionDescriptions describe the pool actions in messages shown to the user
+var poolActionDescriptions = map[Action]string{
+	ActionClaimSquares:   "claim squares",
+	ActionRecordPayments: "record payments",
+	ActionEditGrids:      "edit grids",
+	ActionManagePool:     "manage the pool",
+	ActionManageRoles:    "change the roles of members",
+}
+
+// IsValid returns true if the role can be given to a member of a pool
+func (r PoolRole) IsValid() bool {
+	switch r {
+	case PoolRoleSpectator, PoolRoleClaimer, PoolRoleTreasurer, PoolRoleGridEditor, PoolRoleManager:
+		return true
+	}
+
+	return false
+}
+
+// Can returns true if the role allows the action
+func (r PoolRole) Can(action Action) bool {
+	for _, a := range poolRolePermissions[r] {
+		if a == action {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Check will return nil if the role allows the action. Otherwise it returns an ActionError with the reason.
+func (r PoolRole) Check(action Action) error {
+	if r.Can(action) {
+		return nil
+	}
+
+	description, ok := poolActionDescriptions[action]
+	if !ok {
+		return fmt.Errorf("unsupported action %d", action)
+	}
+
+	if r == PoolRoleNone {
+		return ActionError(fmt.Sprintf("You must be a member of this pool to %s", description))
+	}
+
+	return ActionError(fmt.Sprintf("Your role in this pool (%s) does not allow you to %s", r, description))
+}
+
+// PoolRole returns the user's role in the pool. PoolRoleNone is returned if the user has not joined it.
+func (u *User) PoolRole(ctx context.Context, p *Pool) (PoolRole, error) {
+	if u.ID == p.userID {
+		return PoolRoleOwner, nil
+	}
+
+	row := u.DB.QueryRowContext(ctx, "SELECT role FROM pools_users WHERE pool_id = $1 AND user_id = $2", p.id, u.ID)
+
+	var role PoolRole
+	if err := row.Scan(&role); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return PoolRoleNone, nil
+		}
+
+		return PoolRoleNone, fmt.Errorf("loading pool role: %w", err)
+	}
+
+	return role, nil
+}
+
+// SetPoolRole will give the user a role in the pool, recording who granted it if it is the manager role. Note: this
+// user must already be a member, otherwise ErrNotPoolMember is returned.
+func (u *User) SetPoolRole(ctx context.Context, p *Pool, role PoolRole, grantedBy int64) error {
+	if !role.IsValid() {
+		return fmt.Errorf("invalid pool role %q", role)
+	}
+
+	result, err := u.DB.ExecContext(ctx, `
+UPDATE
+    pools_users
+SET
+    role = $1,
+    manager_granted_by = CASE WHEN $1 = 'manager' THEN $4::BIGINT END,
+    manager_granted_at = CASE WHEN $1 = 'manager' THEN (NOW() AT TIME ZONE 'UTC') END,
+    modified = (NOW() AT TIME ZONE 'UTC')
+WHERE
+	pool_id = $2 AND
+  	user_id = $3`, role, p.ID(), u.ID, grantedBy)
+	if err != nil {
+		return fmt.Errorf("updating pool role: %w", err)
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("updating pool role: %w", err)
+	} else if n == 0 {
+		return ErrNotPoolMember
+	}
+
+	return nil
+}
+
+// CanInPool will return nil if the user's role in the pool allows the action. As with Can, an ActionError is returned
+// with the reason if it does not, and any other error should be interpreted as a 500.
+func (m *Model) CanInPool(ctx context.Context, action Action, u *User, p *Pool) error {
+	role, err := u.PoolRole(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	return role.Check(action)
+}
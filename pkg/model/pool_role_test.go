@@ -0,0 +1,62 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestPoolRoleCan(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Expect(PoolRoleNone.Can(ActionClaimSquares)).Should(gomega.BeFalse())
+	g.Expect(PoolRoleSpectator.Can(ActionClaimSquares)).Should(gomega.BeFalse())
+	g.Expect(PoolRoleClaimer.Can(ActionClaimSquares)).Should(gomega.BeTrue())
+	g.Expect(PoolRoleClaimer.Can(ActionRecordPayments)).Should(gomega.BeFalse())
+
+	g.Expect(PoolRoleTreasurer.Can(ActionRecordPayments)).Should(gomega.BeTrue())
+	g.Expect(PoolRoleTreasurer.Can(ActionEditGrids)).Should(gomega.BeFalse())
+	g.Expect(PoolRoleGridEditor.Can(ActionEditGrids)).Should(gomega.BeTrue())
+	g.Expect(PoolRoleGridEditor.Can(ActionRecordPayments)).Should(gomega.BeFalse())
+
+	g.Expect(PoolRoleManager.Can(ActionManagePool)).Should(gomega.BeTrue())
+	g.Expect(PoolRoleManager.Can(ActionManageRoles)).Should(gomega.BeFalse())
+	g.Expect(PoolRoleOwner.Can(ActionManageRoles)).Should(gomega.BeTrue())
+}
+
+func TestPoolRoleCheck(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Expect(PoolRoleClaimer.Check(ActionClaimSquares)).Should(gomega.Succeed())
+	g.Expect(PoolRoleNone.Check(ActionClaimSquares)).Should(gomega.Equal(ActionError("You must be a member of this pool to claim squares")))
+	g.Expect(PoolRoleTreasurer.Check(ActionEditGrids)).Should(gomega.Equal(ActionError("Your role in this pool (treasurer) does not allow you to edit grids")))
+	g.Expect(PoolRoleOwner.Check(ActionCreatePool)).Should(gomega.MatchError("unsupported action 0"))
+}
+
+func TestPoolRoleIsValid(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Expect(PoolRoleSpectator.IsValid()).Should(gomega.BeTrue())
+	g.Expect(PoolRoleGridEditor.IsValid()).Should(gomega.BeTrue())
+	g.Expect(PoolRoleManager.IsValid()).Should(gomega.BeTrue())
+	g.Expect(PoolRoleOwner.IsValid()).Should(gomega.BeFalse())
+	g.Expect(PoolRoleNone.IsValid()).Should(gomega.BeFalse())
+	g.Expect(PoolRole("admin").IsValid()).Should(gomega.BeFalse())
+}
@@ -0,0 +1,494 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxSeriesEntries is the most pools or grids a series may include
+const MaxSeriesEntries = 100
+
+// ErrSeriesEntryExists is an error when a pool or grid is added to a series that already includes it
+var ErrSeriesEntryExists = errors.New("the series already includes it")
+
+// ErrSeriesEntryNotFound is an error when an entry does not belong to the series
+var ErrSeriesEntryNotFound = errors.New("series entry not found")
+
+// ErrSeriesFull is an error when a series already includes MaxSeriesEntries pools or grids
+var ErrSeriesFull = fmt.Errorf("a series may include at most %d pools or grids", MaxSeriesEntries)
+
+// PoolSeries groups pools, or single grids of pools, so that their wins and payouts can be totalled per claimant
+type PoolSeries struct {
+	model    *Model
+	id       int64
+	token    string
+	userID   int64
+	name     string
+	created  time.Time
+	modified time.Time
+}
+
+// PoolSeriesJSON represents a series that can be sent to the front-end
+type PoolSeriesJSON struct {
+	Token    string    `json:"token"`
+	Name     string    `json:"name"`
+	Created  time.Time `json:"created"`
+	Modified time.Time `json:"modified"`
+}
+
+// PoolSeriesEntry is a pool in a series. If GridID is nil, every grid of the pool is included.
+type PoolSeriesEntry struct {
+	ID        int64     `json:"id"`
+	PoolToken string    `json:"poolToken"`
+	PoolName  string    `json:"poolName"`
+	GridID    *int64    `json:"gridId"`
+	Created   time.Time `json:"created"`
+	poolID    int64
+}
+
+// SeriesStanding is the total won under a claimant name across a series. Names are compared case-insensitively.
+type SeriesStanding struct {
+	// Rank starts at 1. Claimants who won the same amount the same number of times share a rank.
+	Rank     int    `json:"rank"`
+	Claimant string `json:"claimant"`
+	Wins     int    `json:"wins"`
+	// Amount is in cents. It is only non-zero if the pools have payouts configured.
+	Amount int64 `json:"amount"`
+}
+
+// SeriesMemberTotal is the total won by a member across a series, under any claimant name
+type SeriesMemberTotal struct {
+	UserID    int64    `json:"userId"`
+	Claimants []string `json:"claimants"`
+	Wins      int      `json:"wins"`
+	Amount    int64    `json:"amount"`
+}
+
+// SeriesStandings are the totals of every claimant and member who won in a series
+type SeriesStandings struct {
+	Standings []*SeriesStanding    `json:"standings"`
+	Members   []*SeriesMemberTotal `json:"members"`
+	Total     int64                `json:"total"`
+}
+
+const poolSeriesColumns = "id, token, user_id, name, created, modified"
+
+func (m *Model) poolSeriesByRow(scan scanFunc) (*PoolSeries, error) {
+	s := PoolSeries{model: m}
+	if err := scan(&s.id, &s.token, &s.userID, &s.name, &s.created, &s.modified); err != nil {
+		return nil, fmt.Errorf("scanning series row: %w", err)
+	}
+
+	s.created = s.created.In(locationNewYork)
+	s.modified = s.modified.In(locationNewYork)
+	return &s, nil
+}
+
+// NewPoolSeries will save a new series owned by the user
+func (m *Model) NewPoolSeries(ctx context.Context, userID int64, name string) (*PoolSeries, error) {
+	token, err := m.NewToken()
+	if err != nil {
+		return nil, fmt.Errorf("generating token: %w", err)
+	}
+
+	const query = "INSERT INTO pool_series (token, user_id, name) VALUES ($1, $2, $3) RETURNING " + poolSeriesColumns
+	return m.poolSeriesByRow(m.DB.QueryRowContext(ctx, query, token, userID, truncateSeriesName(name)).Scan)
+}
+
+// PoolSeriesByToken returns the series with the matching token
+func (m *Model) PoolSeriesByToken(ctx context.Context, token string) (*PoolSeries, error) {
+	row := m.DB.QueryRowContext(ctx, "SELECT "+poolSeriesColumns+" FROM pool_series WHERE token = $1", token)
+	return m.poolSeriesByRow(row.Scan)
+}
+
+// PoolSeriesByUserID returns the series the user owns or that include a pool they belong to, newest first
+func (m *Model) PoolSeriesByUserID(ctx context.Context, userID int64) ([]*PoolSeries, error) {
+	const query = `
+		SELECT ` + poolSeriesColumns + `
+		FROM pool_series
+		WHERE user_id = $1 OR EXISTS (
+		    SELECT 1
+		    FROM pool_series_entries
+		    INNER JOIN pools ON pool_series_entries.pool_id = pools.id
+		    LEFT JOIN pools_users ON pools.id = pools_users.pool_id AND pools_users.user_id = $1
+		    WHERE pool_series_entries.series_id = pool_series.id
+		      AND (pools.user_id = $1 OR pools_users.user_id IS NOT NULL)
+		)
+		ORDER BY id DESC`
+	rows, err := m.DB.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("loading series: %w", err)
+	}
+	defer rows.Close()
+
+	collection := make([]*PoolSeries, 0)
+	for rows.Next() {
+		s, err := m.poolSeriesByRow(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+
+		collection = append(collection, s)
+	}
+
+	return collection, rows.Err()
+}
+
+// ID is a getter for id
+func (s *PoolSeries) ID() int64 {
+	return s.id
+}
+
+// Token is a getter for token
+func (s *PoolSeries) Token() string {
+	return s.token
+}
+
+// UserID is a getter for userID
+func (s *PoolSeries) UserID() int64 {
+	return s.userID
+}
+
+// Name is a getter for name
+func (s *PoolSeries) Name() string {
+	return s.name
+}
+
+// SetName is a setter for name
+func (s *PoolSeries) SetName(name string) {
+	s.name = truncateSeriesName(name)
+}
+
+func truncateSeriesName(name string) string {
+	if utf8.RuneCountInString(name) > NameMaxLength {
+		return string([]rune(name)[0:NameMaxLength])
+	}
+
+	return name
+}
+
+// JSON returns the series that can be sent to the front-end
+func (s *PoolSeries) JSON() *PoolSeriesJSON {
+	return &PoolSeriesJSON{
+		Token:    s.token,
+		Name:     s.name,
+		Created:  s.created,
+		Modified: s.modified,
+	}
+}
+
+// Save will save the name of the series
+func (s *PoolSeries) Save(ctx context.Context) error {
+	const query = "UPDATE pool_series SET name = $1, modified = (NOW() AT TIME ZONE 'utc') WHERE id = $2 RETURNING modified"
+	if err := s.model.DB.QueryRowContext(ctx, query, s.name, s.id).Scan(&s.modified); err != nil {
+		return fmt.Errorf("saving series: %w", err)
+	}
+
+	s.modified = s.modified.In(locationNewYork)
+	return nil
+}
+
+// Delete will delete the series. The pools in it are not affected.
+func (s *PoolSeries) Delete(ctx context.Context) error {
+	if _, err := s.model.DB.ExecContext(ctx, "DELETE FROM pool_series WHERE id = $1", s.id); err != nil {
+		return fmt.Errorf("deleting series: %w", err)
+	}
+
+	return nil
+}
+
+// HasMember returns true if the user owns the series or belongs to one of its pools
+func (s *PoolSeries) HasMember(ctx context.Context, userID int64) (bool, error) {
+	if s.userID == userID {
+		return true, nil
+	}
+
+	const query = `
+		SELECT EXISTS (
+		    SELECT 1
+		    FROM pool_series_entries
+		    INNER JOIN pools ON pool_series_entries.pool_id = pools.id
+		    LEFT JOIN pools_users ON pools.id = pools_users.pool_id AND pools_users.user_id = $2
+		    WHERE pool_series_entries.series_id = $1
+		      AND (pools.user_id = $2 OR pools_users.user_id IS NOT NULL)
+		)`
+
+	var ok bool
+	if err := s.model.DB.QueryRowContext(ctx, query, s.id, userID).Scan(&ok); err != nil {
+		return false, fmt.Errorf("checking series membership: %w", err)
+	}
+
+	return ok, nil
+}
+
+// Entries returns the pools and grids in the series in the order they were added
+func (s *PoolSeries) Entries(ctx context.Context) ([]*PoolSeriesEntry, error) {
+	const query = `
+		SELECT pool_series_entries.id, pools.id, pools.token, pools.name, pool_series_entries.grid_id, pool_series_entries.created
+		FROM pool_series_entries
+		INNER JOIN pools ON pool_series_entries.pool_id = pools.id
+		WHERE pool_series_entries.series_id = $1
+		ORDER BY pool_series_entries.id`
+	rows, err := s.model.DB.QueryContext(ctx, query, s.id)
+	if err != nil {
+		return nil, fmt.Errorf("loading series entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*PoolSeriesEntry, 0)
+	for rows.Next() {
+		var e PoolSeriesEntry
+		if err := rows.Scan(&e.ID, &e.poolID, &e.PoolToken, &e.PoolName, &e.GridID, &e.Created); err != nil {
+			return nil, fmt.Errorf("scanning series entry: %w", err)
+		}
+
+		e.Created = e.Created.In(locationNewYork)
+		entries = append(entries, &e)
+	}
+
+	return entries, rows.Err()
+}
+
+// AddEntry will add the pool to the series. If grid is not nil, only that grid of the pool is included.
+// ErrSeriesEntryExists is returned if the series already includes it, and ErrSeriesFull if the series is full.
+func (s *PoolSeries) AddEntry(ctx context.Context, pool *Pool, grid *Grid) (*PoolSeriesEntry, error) {
+	var count int
+	if err := s.model.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM pool_series_entries WHERE series_id = $1", s.id).Scan(&count); err != nil {
+		return nil, fmt.Errorf("counting series entries: %w", err)
+	}
+
+	if count >= MaxSeriesEntries {
+		return nil, ErrSeriesFull
+	}
+
+	entry := &PoolSeriesEntry{
+		PoolToken: pool.token,
+		PoolName:  pool.name,
+		poolID:    pool.id,
+	}
+	if grid != nil {
+		entry.GridID = &grid.id
+	}
+
+	const query = `
+		INSERT INTO pool_series_entries (series_id, pool_id, grid_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (series_id, pool_id, COALESCE(grid_id, 0)) DO NOTHING
+		RETURNING id, created`
+	if err := s.model.DB.QueryRowContext(ctx, query, s.id, pool.id, entry.GridID).Scan(&entry.ID, &entry.Created); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSeriesEntryExists
+		}
+
+		return nil, fmt.Errorf("adding series entry: %w", err)
+	}
+
+	entry.Created = entry.Created.In(locationNewYork)
+	return entry, nil
+}
+
+// RemoveEntry will remove a pool or grid from the series. ErrSeriesEntryNotFound is returned if the entry does not
+// belong to the series.
+func (s *PoolSeries) RemoveEntry(ctx context.Context, entryID int64) error {
+	result, err := s.model.DB.ExecContext(ctx, "DELETE FROM pool_series_entries WHERE id = $1 AND series_id = $2", entryID, s.id)
+	if err != nil {
+		return fmt.Errorf("removing series entry: %w", err)
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("removing series entry: %w", err)
+	} else if n == 0 {
+		return ErrSeriesEntryNotFound
+	}
+
+	return nil
+}
+
+// Standings totals the wins and payouts of every grid in the series that is linked to a sports event
+func (s *PoolSeries) Standings(ctx context.Context) (*SeriesStandings, error) {
+	entries, err := s.Entries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// a nil set of grid IDs means every grid of the pool is included
+	poolIDs := make([]int64, 0)
+	gridIDs := make(map[int64]map[int64]bool)
+	for _, entry := range entries {
+		included, ok := gridIDs[entry.poolID]
+		if !ok {
+			poolIDs = append(poolIDs, entry.poolID)
+			included = make(map[int64]bool)
+			gridIDs[entry.poolID] = included
+		}
+
+		if entry.GridID == nil {
+			gridIDs[entry.poolID] = nil
+		} else if included != nil {
+			included[*entry.GridID] = true
+		}
+	}
+
+	tally := newSeriesTally()
+	for _, poolID := range poolIDs {
+		pool, err := s.model.PoolByID(poolID)
+		if err != nil {
+			return nil, err
+		}
+
+		payouts, err := pool.Payouts(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("calculating payouts for pool %d: %w", poolID, err)
+		}
+
+		included := gridIDs[poolID]
+		for _, payout := range payouts.Payouts {
+			if included == nil || included[payout.GridID] {
+				tally.add(payout)
+			}
+		}
+	}
+
+	return tally.standings(), nil
+}
+
+// seriesTally adds up the payouts of a series by claimant name and by member
+type seriesTally struct {
+	claimants map[string]*SeriesStanding
+	members   map[int64]*SeriesMemberTotal
+	total     int64
+}
+
+func newSeriesTally() *seriesTally {
+	return &seriesTally{
+		claimants: make(map[string]*SeriesStanding),
+		members:   make(map[int64]*SeriesMemberTotal),
+	}
+}
+
+// add counts a win for whoever claimed the winning square. The payout of a shared square is a win for each of its
+// co-owners, who are credited with their part of the amount. Refunds and unclaimed squares are not counted.
+func (t *seriesTally) add(payout *Payout) {
+	if payout.Refund || payout.Claimant == "" {
+		return
+	}
+
+	t.total += payout.Amount
+	if payout.Shares == nil {
+		t.credit(payout.Claimant, payout.UserID, payout.Amount)
+		return
+	}
+
+	for _, share := range payout.Shares {
+		t.credit(share.Claimant, share.UserID, share.Amount)
+	}
+}
+
+func (t *seriesTally) credit(claimant string, userID int64, amount int64) {
+	key := strings.ToLower(strings.TrimSpace(claimant))
+	standing, ok := t.claimants[key]
+	if !ok {
+		standing = &SeriesStanding{Claimant: strings.TrimSpace(claimant)}
+		t.claimants[key] = standing
+	}
+
+	standing.Wins++
+	standing.Amount += amount
+
+	if userID == 0 {
+		return
+	}
+
+	member, ok := t.members[userID]
+	if !ok {
+		member = &SeriesMemberTotal{UserID: userID, Claimants: make([]string, 0)}
+		t.members[userID] = member
+	}
+
+	member.Wins++
+	member.Amount += amount
+
+	for _, name := range member.Claimants {
+		if strings.EqualFold(name, standing.Claimant) {
+			return
+		}
+	}
+
+	member.Claimants = append(member.Claimants, standing.Claimant)
+}
+
+// standings returns the totals with the largest amount first, then the most wins
+func (t *seriesTally) standings() *SeriesStandings {
+	result := &SeriesStandings{
+		Standings: make([]*SeriesStanding, 0, len(t.claimants)),
+		Members:   make([]*SeriesMemberTotal, 0, len(t.members)),
+		Total:     t.total,
+	}
+
+	for _, standing := range t.claimants {
+		result.Standings = append(result.Standings, standing)
+	}
+
+	sort.Slice(result.Standings, func(i, j int) bool {
+		a, b := result.Standings[i], result.Standings[j]
+		if a.Amount != b.Amount {
+			return a.Amount > b.Amount
+		}
+		if a.Wins != b.Wins {
+			return a.Wins > b.Wins
+		}
+
+		return strings.ToLower(a.Claimant) < strings.ToLower(b.Claimant)
+	})
+
+	for i, standing := range result.Standings {
+		standing.Rank = i + 1
+		if i > 0 {
+			prev := result.Standings[i-1]
+			if prev.Amount == standing.Amount && prev.Wins == standing.Wins {
+				standing.Rank = prev.Rank
+			}
+		}
+	}
+
+	for _, member := range t.members {
+		result.Members = append(result.Members, member)
+	}
+
+	sort.Slice(result.Members, func(i, j int) bool {
+		a, b := result.Members[i], result.Members[j]
+		if a.Amount != b.Amount {
+			return a.Amount > b.Amount
+		}
+		if a.Wins != b.Wins {
+			return a.Wins > b.Wins
+		}
+
+		return a.UserID < b.UserID
+	})
+
+	return result
+}
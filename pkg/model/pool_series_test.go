@@ -0,0 +1,58 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestSeriesTally(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	tally := newSeriesTally()
+	tally.add(&Payout{Claimant: "Alice", UserID: 1, Amount: 5000})
+	tally.add(&Payout{Claimant: "alice ", UserID: 1, Amount: 2500})
+	tally.add(&Payout{Claimant: "Bob", UserID: 2, Amount: 2500})
+	tally.add(&Payout{Claimant: "Carol", Amount: 2500})
+	tally.add(&Payout{Claimant: "Carol", Amount: 0})
+	tally.add(&Payout{Claimant: "Erin", Amount: 500})
+	// unclaimed squares and refunds are not wins
+	tally.add(&Payout{Amount: 1000})
+	tally.add(&Payout{Claimant: "Bob", UserID: 2, Amount: 100, Refund: true})
+	// a shared square is a win for each co-owner
+	tally.add(&Payout{Claimant: "Bob", UserID: 2, Amount: 1000, Shares: []*PayoutShare{
+		{Claimant: "Bob", UserID: 2, Share: 5000, Amount: 500},
+		{Claimant: "Dave", UserID: 1, Share: 5000, Amount: 500},
+	}})
+
+	result := tally.standings()
+	g.Expect(result.Total).Should(gomega.Equal(int64(14000)))
+	g.Expect(result.Standings).Should(gomega.Equal([]*SeriesStanding{
+		{Rank: 1, Claimant: "Alice", Wins: 2, Amount: 7500},
+		{Rank: 2, Claimant: "Bob", Wins: 2, Amount: 3000},
+		{Rank: 3, Claimant: "Carol", Wins: 2, Amount: 2500},
+		{Rank: 4, Claimant: "Dave", Wins: 1, Amount: 500},
+		{Rank: 4, Claimant: "Erin", Wins: 1, Amount: 500},
+	}))
+	g.Expect(result.Members).Should(gomega.Equal([]*SeriesMemberTotal{
+		{UserID: 1, Claimants: []string{"Alice", "Dave"}, Wins: 3, Amount: 8000},
+		{UserID: 2, Claimants: []string{"Bob"}, Wins: 2, Amount: 3000},
+	}))
+}
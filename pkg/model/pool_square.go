@@ -36,6 +36,7 @@ type PoolSquareState string
 const (
 	PoolSquareStateUnclaimed   PoolSquareState = "unclaimed"
 	PoolSquareStateClaimed     PoolSquareState = "claimed"
+	PoolSquareStateReserved    PoolSquareState = "reserved"
 	PoolSquareStatePaidPartial PoolSquareState = "paid-partial"
 	PoolSquareStatePaidFull    PoolSquareState = "paid-full"
 )
@@ -43,6 +44,7 @@ const (
 // PoolSquareStates are the valid states of a PoolSquare
 var PoolSquareStates = []PoolSquareState{
 	PoolSquareStateClaimed,
+	PoolSquareStateReserved,
 	PoolSquareStatePaidPartial,
 	PoolSquareStatePaidFull,
 	PoolSquareStateUnclaimed,
@@ -80,6 +82,8 @@ type PoolSquare struct {
 	claimant       string
 	Modified       time.Time        `json:"-"`
 	Logs           []*PoolSquareLog `json:"-"`
+	shares         []*PoolSquareShare
+	reservedUntil  *time.Time
 }
 
 // FIXME - remove the above json tags once we validate it's no longer necessary
@@ -108,6 +112,11 @@ func (p *PoolSquare) SetUserID(userID int64) {
 	p.userID = userID
 }
 
+// ReservedUntil returns when the hold on a reserved square expires, or nil if the square is not reserved
+func (p *PoolSquare) ReservedUntil() *time.Time {
+	return p.reservedUntil
+}
+
 // SquareUserInfoJSON contains user info for a square (admin only)
 type SquareUserInfoJSON struct {
 	UserType string `json:"userType"`
@@ -116,20 +125,31 @@ type SquareUserInfoJSON struct {
 
 // PoolSquareJSON represents JSON that can be sent to the front-end
 type PoolSquareJSON struct {
-	UserID         int64               `json:"userId"`
-	SquareID       int                 `json:"squareId"`
-	ParentSquareID int                 `json:"parentSquareId"`
-	ChildSquareIDs []int8              `json:"childSquareIds"`
-	State          PoolSquareState     `json:"state"`
-	Claimant       string              `json:"claimant"`
-	Modified       time.Time           `json:"modified"`
-	Logs           []*PoolSquareLog    `json:"logs,omitempty"`
-	UserInfo       *SquareUserInfoJSON `json:"userInfo,omitempty"`
-	WinningPeriods []WinningPeriodInfo `json:"winningPeriods,omitempty"`
+	UserID         int64                  `json:"userId"`
+	SquareID       int                    `json:"squareId"`
+	ParentSquareID int                    `json:"parentSquareId"`
+	ChildSquareIDs []int8                 `json:"childSquareIds"`
+	State          PoolSquareState        `json:"state"`
+	Claimant       string                 `json:"claimant"`
+	Modified       time.Time              `json:"modified"`
+	Logs           []*PoolSquareLog       `json:"logs,omitempty"`
+	UserInfo       *SquareUserInfoJSON    `json:"userInfo,omitempty"`
+	WinningPeriods []WinningPeriodInfo    `json:"winningPeriods,omitempty"`
+	Shares         []*PoolSquareShareJSON `json:"shares,omitempty"`
+	AvailableShare int                    `json:"availableShare"`
+	ReservedUntil  *time.Time             `json:"reservedUntil,omitempty"`
 }
 
 // JSON will custom JSON encode a PoolSquare
 func (p *PoolSquare) JSON() *PoolSquareJSON {
+	var shares []*PoolSquareShareJSON
+	if p.IsShared() {
+		shares = make([]*PoolSquareShareJSON, len(p.shares))
+		for i, share := range p.shares {
+			shares[i] = share.JSON()
+		}
+	}
+
 	return &PoolSquareJSON{
 		UserID:         p.userID,
 		SquareID:       p.SquareID,
@@ -139,6 +159,9 @@ func (p *PoolSquare) JSON() *PoolSquareJSON {
 		Claimant:       p.Claimant(),
 		Modified:       p.Modified,
 		Logs:           p.Logs,
+		Shares:         shares,
+		AvailableShare: p.AvailableShare(),
+		ReservedUntil:  p.reservedUntil,
 	}
 }
 
@@ -152,6 +175,7 @@ type PoolSquareLog struct {
 	claimant     string
 	RemoteAddr   string
 	Note         string
+	Payment      *SquarePayment
 	created      time.Time
 }
 
@@ -171,6 +195,7 @@ type PoolSquareLogJSON struct {
 	State    PoolSquareState `json:"state"`
 	Claimant string          `json:"claimant"`
 	Note     string          `json:"note"`
+	Payment  *SquarePayment  `json:"payment,omitempty"`
 	Created  time.Time       `json:"created"`
 }
 
@@ -181,6 +206,7 @@ func (p *PoolSquareLog) JSON() *PoolSquareLogJSON {
 		State:    p.State(),
 		Claimant: p.Claimant(),
 		Note:     p.Note,
+		Payment:  p.Payment,
 		Created:  p.Created(),
 	}
 }
@@ -244,6 +270,11 @@ func (p *PoolSquare) Save(ctx context.Context, dbFn Queryable, isManager bool, p
 	}
 
 	if !ok {
+		// a manager can only be refused when the square is shared
+		if isManager {
+			return ErrSquareShared
+		}
+
 		return ErrSquareAlreadyClaimed
 	}
 
@@ -255,11 +286,25 @@ func poolSquareLogByRow(scan scanFunc) (*PoolSquareLog, error) {
 	var remoteAddr *string
 	var userID *int64
 	var claimant *string
+	var paymentAmount *int64
+	var paymentMethod *PaymentMethod
+	var paymentReference *string
 
-	if err := scan(&l.id, &l.poolSquareID, &l.squareID, &userID, &l.state, &claimant, &remoteAddr, &l.Note, &l.created); err != nil {
+	if err := scan(&l.id, &l.poolSquareID, &l.squareID, &userID, &l.state, &claimant, &remoteAddr, &l.Note, &paymentAmount, &paymentMethod, &paymentReference, &l.created); err != nil {
 		return nil, err
 	}
 
+	if paymentAmount != nil {
+		l.Payment = &SquarePayment{Amount: *paymentAmount}
+		if paymentMethod != nil {
+			l.Payment.Method = *paymentMethod
+		}
+
+		if paymentReference != nil {
+			l.Payment.Reference = *paymentReference
+		}
+	}
+
 	if userID != nil {
 		l.userID = *userID
 	}
@@ -288,6 +333,7 @@ func (p *PoolSquare) LoadLogs(ctx context.Context) error {
 		       pool_squares_logs.state,
 		       pool_squares_logs.claimant,
 		       remote_addr, note,
+		       payment_amount, payment_method, payment_reference,
 		       pool_squares_logs.created
 		FROM
 		     pool_squares_logs
@@ -329,7 +375,8 @@ func (p *PoolSquare) ChildSquares(ctx context.Context, q Queryable) ([]*PoolSqua
 		       ps.claimant,
 		       ps.modified,
 		       ps2.square_id AS parent_square_id,
-		       (SELECT array_agg(square_id) FROM pool_squares ps3 WHERE ps3.parent_id = ps.id) AS child_square_ids
+		       (SELECT array_agg(square_id) FROM pool_squares ps3 WHERE ps3.parent_id = ps.id) AS child_square_ids,
+		       ps.reserved_until
 		FROM
 			pool_squares ps
 		LEFT JOIN
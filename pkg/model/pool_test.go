@@ -498,7 +498,7 @@ func TestCanChangeNumberSetConfig_MultiSetWithNumbersDrawn(t *testing.T) {
 	grid, err := pool.DefaultGrid(ctx)
 	g.Expect(err).Should(gomega.Succeed())
 
-	err = grid.DrawAllNumbersRandom(ctx, NumberSetConfig123F)
+	err = grid.DrawAllNumbersRandom(ctx, NumberSetConfig123F, user.ID)
 	g.Expect(err).Should(gomega.Succeed())
 
 	// Now it should return false since numbers are drawn
@@ -0,0 +1,324 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTransferNotFound is an error when there is no pending ownership transfer to act on
+var ErrTransferNotFound = errors.New("there is no pending transfer of ownership")
+
+// ErrAlreadyOwner is an error when the ownership of a pool is transferred to the user who already owns it
+var ErrAlreadyOwner = errors.New("the user already owns the pool")
+
+// PoolTransferStatus is the status of a transfer of the ownership of a pool
+type PoolTransferStatus string
+
+// constants for PoolTransferStatus
+const (
+	// PoolTransferStatusPending means the nominee has not accepted or declined the transfer
+	PoolTransferStatusPending PoolTransferStatus = "pending"
+	// PoolTransferStatusAccepted means the nominee accepted the transfer and owns the pool
+	PoolTransferStatusAccepted PoolTransferStatus = "accepted"
+	// PoolTransferStatusDeclined means the nominee declined the transfer
+	PoolTransferStatusDeclined PoolTransferStatus = "declined"
+	// PoolTransferStatusCancelled means the owner withdrew the nomination, or it was replaced by another transfer
+	PoolTransferStatusCancelled PoolTransferStatus = "cancelled"
+	// PoolTransferStatusForced means a site admin transferred the pool without the owner nominating anyone
+	PoolTransferStatusForced PoolTransferStatus = "forced"
+)
+
+// PoolTransfer is a transfer of the ownership of a pool from one user to another. FromUserID and ToUserID are nil if
+// the user has since been deleted. RequestedBy is the owner who nominated the new owner, or the site admin who forced
+// the transfer.
+type PoolTransfer struct {
+	ID          int64              `json:"id"`
+	FromUserID  *int64             `json:"fromUserId"`
+	ToUserID    *int64             `json:"toUserId"`
+	RequestedBy *int64             `json:"requestedBy"`
+	Status      PoolTransferStatus `json:"status"`
+	Created     time.Time          `json:"created"`
+	Resolved    *time.Time         `json:"resolved"`
+}
+
+const transferColumns = "id, from_user_id, to_user_id, requested_by, status, created, resolved"
+
+func poolTransferByRow(scan scanFunc) (*PoolTransfer, error) {
+	var t PoolTransfer
+	if err := scan(&t.ID, &t.FromUserID, &t.ToUserID, &t.RequestedBy, &t.Status, &t.Created, &t.Resolved); err != nil {
+		return nil, err
+	}
+
+	t.Created = t.Created.In(locationNewYork)
+	if t.Resolved != nil {
+		resolved := t.Resolved.In(locationNewYork)
+		t.Resolved = &resolved
+	}
+
+	return &t, nil
+}
+
+// IsNominee returns true if the transfer is to the user
+func (t *PoolTransfer) IsNominee(userID int64) bool {
+	return t.ToUserID != nil && *t.ToUserID == userID
+}
+
+// PendingTransfer returns the transfer of ownership waiting for the nominee to accept it, or nil if there is none
+func (p *Pool) PendingTransfer(ctx context.Context) (*PoolTransfer, error) {
+	const query = "SELECT " + transferColumns + " FROM pool_ownership_transfers WHERE pool_id = $1 AND status = 'pending'"
+	transfer, err := poolTransferByRow(p.model.DB.QueryRowContext(ctx, query, p.id).Scan)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("loading pending transfer: %w", err)
+	}
+
+	return transfer, nil
+}
+
+// Transfers returns every transfer of the ownership of the pool, newest first
+func (p *Pool) Transfers(ctx context.Context) ([]*PoolTransfer, error) {
+	const query = "SELECT " + transferColumns + " FROM pool_ownership_transfers WHERE pool_id = $1 ORDER BY created DESC, id DESC"
+	rows, err := p.model.DB.QueryContext(ctx, query, p.id)
+	if err != nil {
+		return nil, fmt.Errorf("loading transfers: %w", err)
+	}
+	defer rows.Close()
+
+	transfers := make([]*PoolTransfer, 0)
+	for rows.Next() {
+		transfer, err := poolTransferByRow(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("scanning transfer: %w", err)
+		}
+
+		transfers = append(transfers, transfer)
+	}
+
+	return transfers, rows.Err()
+}
+
+// NominateOwner will offer the ownership of the pool to one of its members. It replaces any transfer that is already
+// pending. ErrNotPoolMember is returned if the user has not joined the pool.
+func (p *Pool) NominateOwner(ctx context.Context, toUserID int64, nominatedBy int64) (*PoolTransfer, error) {
+	if toUserID == p.userID {
+		return nil, ErrAlreadyOwner
+	}
+
+	tx, err := p.model.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var isMember bool
+	row := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM pools_users WHERE pool_id = $1 AND user_id = $2)", p.id, toUserID)
+	if err := row.Scan(&isMember); err != nil {
+		return nil, fmt.Errorf("checking pool membership: %w", err)
+	}
+
+	if !isMember {
+		return nil, ErrNotPoolMember
+	}
+
+	if err := resolvePendingTransfer(ctx, tx, p.id, PoolTransferStatusCancelled); err != nil && !errors.Is(err, ErrTransferNotFound) {
+		return nil, err
+	}
+
+	const query = `
+		INSERT INTO pool_ownership_transfers (pool_id, from_user_id, to_user_id, requested_by, status)
+		VALUES ($1, $2, $3, $4, 'pending')
+		RETURNING ` + transferColumns
+	transfer, err := poolTransferByRow(tx.QueryRowContext(ctx, query, p.id, p.userID, toUserID, nominatedBy).Scan)
+	if err != nil {
+		return nil, fmt.Errorf("saving transfer: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return transfer, nil
+}
+
+// ResolvePendingTransfer will mark the pending transfer as declined or cancelled without changing the owner.
+// ErrTransferNotFound is returned if there is no pending transfer.
+func (p *Pool) ResolvePendingTransfer(ctx context.Context, status PoolTransferStatus) error {
+	if status != PoolTransferStatusDeclined && status != PoolTransferStatusCancelled {
+		return fmt.Errorf("invalid transfer status %q", status)
+	}
+
+	return resolvePendingTransfer(ctx, p.model.DB, p.id, status)
+}
+
+// AcceptTransfer will make the nominee the owner of the pool. ErrTransferNotFound is returned if the user has not
+// been nominated, and ErrNotPoolMember if they have left the pool since.
+func (p *Pool) AcceptTransfer(ctx context.Context, userID int64) error {
+	tx, err := p.model.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	const query = "SELECT id FROM pool_ownership_transfers WHERE pool_id = $1 AND to_user_id = $2 AND status = 'pending' FOR UPDATE"
+	var transferID int64
+	if err := tx.QueryRowContext(ctx, query, p.id, userID).Scan(&transferID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTransferNotFound
+		}
+
+		return fmt.Errorf("loading pending transfer: %w", err)
+	}
+
+	if err := p.transferOwnership(ctx, tx, userID, true); err != nil {
+		return err
+	}
+
+	const updateQuery = `
+		UPDATE pool_ownership_transfers
+		SET status = 'accepted',
+		    resolved = (NOW() AT TIME ZONE 'utc')
+		WHERE id = $1`
+	if _, err := tx.ExecContext(ctx, updateQuery, transferID); err != nil {
+		return fmt.Errorf("accepting transfer: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
+	p.userID = userID
+	return nil
+}
+
+// ForceTransfer will make the user the owner of the pool without a nomination, cancelling any pending transfer.
+// It is meant for site admins, so the user does not need to be a member of the pool.
+func (p *Pool) ForceTransfer(ctx context.Context, toUserID int64, forcedBy int64) (*PoolTransfer, error) {
+	if toUserID == p.userID {
+		return nil, ErrAlreadyOwner
+	}
+
+	tx, err := p.model.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := resolvePendingTransfer(ctx, tx, p.id, PoolTransferStatusCancelled); err != nil && !errors.Is(err, ErrTransferNotFound) {
+		return nil, err
+	}
+
+	const query = `
+		INSERT INTO pool_ownership_transfers (pool_id, from_user_id, to_user_id, requested_by, status, resolved)
+		VALUES ($1, $2, $3, $4, 'forced', (NOW() AT TIME ZONE 'utc'))
+		RETURNING ` + transferColumns
+	transfer, err := poolTransferByRow(tx.QueryRowContext(ctx, query, p.id, p.userID, toUserID, forcedBy).Scan)
+	if err != nil {
+		return nil, fmt.Errorf("saving transfer: %w", err)
+	}
+
+	if err := p.transferOwnership(ctx, tx, toUserID, false); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+
+	p.userID = toUserID
+	return transfer, nil
+}
+
+// transferOwnership will make the user the owner of the pool. The previous owner stays on as a manager, and the new
+// owner's membership is removed as the owner has every permission. If mustBeMember is true, ErrNotPoolMember is
+// returned unless the user is a member of the pool.
+func (p *Pool) transferOwnership(ctx context.Context, tx *sql.Tx, toUserID int64, mustBeMember bool) error {
+	result, err := tx.ExecContext(ctx, "DELETE FROM pools_users WHERE pool_id = $1 AND user_id = $2", p.id, toUserID)
+	if err != nil {
+		return fmt.Errorf("removing membership of new owner: %w", err)
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("removing membership of new owner: %w", err)
+	} else if n == 0 && mustBeMember {
+		return ErrNotPoolMember
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM pool_bans WHERE pool_id = $1 AND user_id = $2", p.id, toUserID); err != nil {
+		return fmt.Errorf("removing ban of new owner: %w", err)
+	}
+
+	const ownerQuery = `
+		UPDATE pools
+		SET user_id = $2,
+		    modified = (NOW() AT TIME ZONE 'utc')
+		WHERE id = $1 AND user_id = $3`
+	result, err = tx.ExecContext(ctx, ownerQuery, p.id, toUserID, p.userID)
+	if err != nil {
+		return fmt.Errorf("changing owner: %w", err)
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("changing owner: %w", err)
+	} else if n == 0 {
+		return fmt.Errorf("changing owner: pool %d is no longer owned by user %d", p.id, p.userID)
+	}
+
+	const managerQuery = `
+		INSERT INTO pools_users (pool_id, user_id, role, manager_granted_by, manager_granted_at)
+		VALUES ($1, $2, 'manager', $3, (NOW() AT TIME ZONE 'utc'))
+		ON CONFLICT (user_id, pool_id) DO UPDATE
+		SET role = EXCLUDED.role,
+		    manager_granted_by = EXCLUDED.manager_granted_by,
+		    manager_granted_at = EXCLUDED.manager_granted_at,
+		    modified = (NOW() AT TIME ZONE 'utc')`
+	if _, err := tx.ExecContext(ctx, managerQuery, p.id, p.userID, toUserID); err != nil {
+		return fmt.Errorf("making previous owner a manager: %w", err)
+	}
+
+	return nil
+}
+
+// resolvePendingTransfer will mark the pool's pending transfer with the status. ErrTransferNotFound is returned if
+// there is no pending transfer.
+func resolvePendingTransfer(ctx context.Context, q Queryable, poolID int64, status PoolTransferStatus) error {
+	const query = `
+		UPDATE pool_ownership_transfers
+		SET status = $2,
+		    resolved = (NOW() AT TIME ZONE 'utc')
+		WHERE pool_id = $1 AND status = 'pending'`
+	result, err := q.ExecContext(ctx, query, poolID, status)
+	if err != nil {
+		return fmt.Errorf("resolving pending transfer: %w", err)
+	}
+
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("resolving pending transfer: %w", err)
+	} else if n == 0 {
+		return ErrTransferNotFound
+	}
+
+	return nil
+}
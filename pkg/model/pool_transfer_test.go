@@ -0,0 +1,47 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestPoolTransferIsNominee(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	to := int64(200)
+	g.Expect((&PoolTransfer{ToUserID: &to}).IsNominee(200)).Should(gomega.BeTrue())
+	g.Expect((&PoolTransfer{ToUserID: &to}).IsNominee(100)).Should(gomega.BeFalse())
+	g.Expect((&PoolTransfer{}).IsNominee(200)).Should(gomega.BeFalse())
+}
+
+func TestPoolTransferAlreadyOwner(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	p := &Pool{id: 1, userID: 100}
+	_, err := p.NominateOwner(context.Background(), 100, 100)
+	g.Expect(err).Should(gomega.MatchError(ErrAlreadyOwner))
+
+	_, err = p.ForceTransfer(context.Background(), 100, 1)
+	g.Expect(err).Should(gomega.MatchError(ErrAlreadyOwner))
+
+	g.Expect(p.ResolvePendingTransfer(context.Background(), PoolTransferStatusAccepted)).Should(gomega.MatchError(`invalid transfer status "accepted"`))
+}
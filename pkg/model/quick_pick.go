@@ -0,0 +1,135 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// QuickPickMax is the most squares that can be quick picked at once
+const QuickPickMax = 100
+
+// ErrNotEnoughSquares is an error when fewer unclaimed squares remain than were asked for
+var ErrNotEnoughSquares = errors.New("not enough unclaimed squares")
+
+// QuickPickSquares will claim count random unclaimed squares for the user. For roll100 pools, each pick also claims
+// a random secondary square that is linked to it. The squares are locked as they are picked so that concurrent picks
+// never get the same square, and each claim is logged. ErrNotEnoughSquares is returned if too few squares remain,
+// in which case the transaction should be rolled back. If hold is greater than zero, the squares are reserved rather
+// than claimed outright.
+func (p *Pool) QuickPickSquares(ctx context.Context, tx *sql.Tx, userID int64, claimant string, count int, hold time.Duration, poolSquareLog PoolSquareLog) ([]*PoolSquare, error) {
+	if count < 1 || count > QuickPickMax {
+		return nil, fmt.Errorf("count must be between 1 and %d", QuickPickMax)
+	}
+
+	needed := count
+	if p.gridType == GridTypeRoll100 {
+		needed = count * 2
+	}
+
+	const query = `
+		SELECT
+		       ps.id,
+		       ps.square_id,
+		       ps.parent_id,
+		       ps.user_id,
+		       ps.state,
+		       ps.claimant,
+		       ps.modified,
+		       NULL::integer AS parent_square_id,
+		       NULL::integer[] AS child_square_ids,
+		       ps.reserved_until
+		FROM
+			pool_squares ps
+		WHERE
+			ps.pool_id = $1 AND
+			ps.state = 'unclaimed'
+		ORDER BY
+			random()
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED`
+	rows, err := tx.QueryContext(ctx, query, p.id, needed)
+	if err != nil {
+		return nil, fmt.Errorf("picking squares: %w", err)
+	}
+	defer rows.Close()
+
+	picked := make([]*PoolSquare, 0, needed)
+	for rows.Next() {
+		square, err := p.squareByRow(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("scanning square: %w", err)
+		}
+		picked = append(picked, square)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating squares: %w", err)
+	}
+
+	if len(picked) < needed {
+		return nil, ErrNotEnoughSquares
+	}
+
+	squares, secondaries := picked[:count], picked[count:]
+	for i, square := range squares {
+		claimPickedSquare(square, userID, claimant, hold)
+
+		if err := square.Save(ctx, tx, false, poolSquareLog); err != nil {
+			return nil, fmt.Errorf("claiming square %d: %w", square.SquareID, err)
+		}
+
+		if len(secondaries) == 0 {
+			continue
+		}
+
+		secondary := secondaries[i]
+		claimPickedSquare(secondary, userID, claimant, hold)
+
+		if err := secondary.Save(ctx, tx, false, PoolSquareLog{
+			RemoteAddr: poolSquareLog.RemoteAddr,
+			Note:       poolSquareLog.Note + " (secondary)",
+		}); err != nil {
+			return nil, fmt.Errorf("claiming square %d: %w", secondary.SquareID, err)
+		}
+
+		if err := secondary.SetParentSquare(ctx, tx, square); err != nil {
+			return nil, fmt.Errorf("linking square %d: %w", secondary.SquareID, err)
+		}
+
+		secondary.ParentID = square.ID
+		secondary.ParentSquareID = square.SquareID
+		square.ChildSquareIDs = []int8{int8(secondary.SquareID)}
+	}
+
+	return squares, nil
+}
+
+func claimPickedSquare(square *PoolSquare, userID int64, claimant string, hold time.Duration) {
+	square.SetClaimant(claimant)
+	square.State = PoolSquareStateClaimed
+	square.SetUserID(userID)
+
+	if hold > 0 {
+		square.Reserve(hold)
+	}
+}
@@ -24,6 +24,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 // SportsEventStatus represents the status of a sports event
@@ -74,14 +76,19 @@ type SportsEvent struct {
 	AwayQ4    *int
 	AwayOT    *int
 
+	// Every period's score in order, including overtime. Baseball is scored by inning from these.
+	HomePeriods []int
+	AwayPeriods []int
+
 	// Metadata
 	Created    time.Time
 	Modified   time.Time
 	LastSynced time.Time
 
 	// Loaded relationships
-	homeTeam *SportsTeam
-	awayTeam *SportsTeam
+	homeTeam     *SportsTeam
+	awayTeam     *SportsTeam
+	scoreHistory []*SportsEventScore
 }
 
 // SportsEventJSON represents event data for JSON serialization
@@ -113,6 +120,8 @@ type SportsEventJSON struct {
 	AwayQ3       *int              `json:"awayQ3,omitempty"`
 	AwayQ4       *int              `json:"awayQ4,omitempty"`
 	AwayOT       *int              `json:"awayOT,omitempty"`
+	HomePeriods  []int             `json:"homePeriods,omitempty"`
+	AwayPeriods  []int             `json:"awayPeriods,omitempty"`
 	HomeTeam     *SportsTeamJSON   `json:"homeTeam,omitempty"`
 	AwayTeam     *SportsTeamJSON   `json:"awayTeam,omitempty"`
 	LastSynced   time.Time         `json:"lastSynced"`
@@ -121,30 +130,32 @@ type SportsEventJSON struct {
 // JSON returns the JSON representation of the event
 func (e *SportsEvent) JSON() *SportsEventJSON {
 	json := &SportsEventJSON{
-		ID:         e.ID,
-		ESPNID:     e.ESPNID,
-		League:     e.League,
-		HomeTeamID: e.HomeTeamID,
-		AwayTeamID: e.AwayTeamID,
-		EventDate:  e.EventDate,
-		Season:     e.Season,
-		Week:       e.Week,
-		Postseason: e.Postseason,
-		Status:     e.Status,
-		Period:     e.Period,
-		HomeScore:  e.HomeScore,
-		AwayScore:  e.AwayScore,
-		HomeQ1:     e.HomeQ1,
-		HomeQ2:     e.HomeQ2,
-		HomeQ3:     e.HomeQ3,
-		HomeQ4:     e.HomeQ4,
-		HomeOT:     e.HomeOT,
-		AwayQ1:     e.AwayQ1,
-		AwayQ2:     e.AwayQ2,
-		AwayQ3:     e.AwayQ3,
-		AwayQ4:     e.AwayQ4,
-		AwayOT:     e.AwayOT,
-		LastSynced: e.LastSynced,
+		ID:          e.ID,
+		ESPNID:      e.ESPNID,
+		League:      e.League,
+		HomeTeamID:  e.HomeTeamID,
+		AwayTeamID:  e.AwayTeamID,
+		EventDate:   e.EventDate,
+		Season:      e.Season,
+		Week:        e.Week,
+		Postseason:  e.Postseason,
+		Status:      e.Status,
+		Period:      e.Period,
+		HomeScore:   e.HomeScore,
+		AwayScore:   e.AwayScore,
+		HomeQ1:      e.HomeQ1,
+		HomeQ2:      e.HomeQ2,
+		HomeQ3:      e.HomeQ3,
+		HomeQ4:      e.HomeQ4,
+		HomeOT:      e.HomeOT,
+		AwayQ1:      e.AwayQ1,
+		AwayQ2:      e.AwayQ2,
+		AwayQ3:      e.AwayQ3,
+		AwayQ4:      e.AwayQ4,
+		AwayOT:      e.AwayOT,
+		HomePeriods: e.HomePeriods,
+		AwayPeriods: e.AwayPeriods,
+		LastSynced:  e.LastSynced,
 	}
 	if e.Name != nil {
 		json.Name = *e.Name
@@ -223,6 +234,123 @@ func (e *SportsEvent) AwayQ3CumulativeScore() *int {
 	return &sum
 }
 
+// HomeQ4CumulativeScore returns the home team's cumulative score at the end of regulation (Q1+Q2+Q3+Q4).
+// If the quarter scores are not available, the final score is used for games that did not go to overtime.
+func (e *SportsEvent) HomeQ4CumulativeScore() *int {
+	return regulationScore(e.HomeQ1, e.HomeQ2, e.HomeQ3, e.HomeQ4, e.HomeOT, e.HomeScore, e.Status)
+}
+
+// AwayQ4CumulativeScore returns the away team's cumulative score at the end of regulation (Q1+Q2+Q3+Q4).
+// If the quarter scores are not available, the final score is used for games that did not go to overtime.
+func (e *SportsEvent) AwayQ4CumulativeScore() *int {
+	return regulationScore(e.AwayQ1, e.AwayQ2, e.AwayQ3, e.AwayQ4, e.AwayOT, e.AwayScore, e.Status)
+}
+
+func regulationScore(q1, q2, q3, q4, ot, total *int, status SportsEventStatus) *int {
+	if q1 != nil && q2 != nil && q3 != nil && q4 != nil {
+		sum := *q1 + *q2 + *q3 + *q4
+		return &sum
+	}
+
+	if status == SportsEventStatusFinal && ot == nil {
+		return total
+	}
+
+	return nil
+}
+
+// HomeOTScore returns the points the home team scored in overtime, summed across every overtime period
+func (e *SportsEvent) HomeOTScore() *int {
+	if e.League.UsesInnings() {
+		return extraInningsScore(e.HomePeriods)
+	}
+	return overtimeScore(e.League, []*int{e.HomeQ1, e.HomeQ2, e.HomeQ3, e.HomeQ4}, e.HomeOT)
+}
+
+// AwayOTScore returns the points the away team scored in overtime, summed across every overtime period
+func (e *SportsEvent) AwayOTScore() *int {
+	if e.League.UsesInnings() {
+		return extraInningsScore(e.AwayPeriods)
+	}
+	return overtimeScore(e.League, []*int{e.AwayQ1, e.AwayQ2, e.AwayQ3, e.AwayQ4}, e.AwayOT)
+}
+
+// WentToOvertime returns true if the game has been played past the end of regulation
+func (e *SportsEvent) WentToOvertime() bool {
+	if e.Period != nil && *e.Period > e.League.RegulationPeriods() {
+		return true
+	}
+
+	return e.HomeOTScore() != nil || e.AwayOTScore() != nil
+}
+
+// EndedInRegulation returns true if the game is final and never went to overtime
+func (e *SportsEvent) EndedInRegulation() bool {
+	return e.Status == SportsEventStatusFinal && !e.WentToOvertime()
+}
+
+// HomeRegulationScore returns the home team's score at the end of regulation, excluding overtime
+func (e *SportsEvent) HomeRegulationScore() *int {
+	switch {
+	case e.League.UsesHalves():
+		return e.periodsRegulationScore(e.HomeHalfScore(), e.HomeScore)
+	case e.League.UsesInnings():
+		return e.periodsRegulationScore(inningsScore(e.HomePeriods, e.League.RegulationPeriods()), e.HomeScore)
+	case e.League.RegulationPeriods() == 3:
+		return e.periodsRegulationScore(e.HomeQ3CumulativeScore(), e.HomeScore)
+	}
+	return e.HomeQ4CumulativeScore()
+}
+
+// AwayRegulationScore returns the away team's score at the end of regulation, excluding overtime
+func (e *SportsEvent) AwayRegulationScore() *int {
+	switch {
+	case e.League.UsesHalves():
+		return e.periodsRegulationScore(e.AwayHalfScore(), e.AwayScore)
+	case e.League.UsesInnings():
+		return e.periodsRegulationScore(inningsScore(e.AwayPeriods, e.League.RegulationPeriods()), e.AwayScore)
+	case e.League.RegulationPeriods() == 3:
+		return e.periodsRegulationScore(e.AwayQ3CumulativeScore(), e.AwayScore)
+	}
+	return e.AwayQ4CumulativeScore()
+}
+
+// periodsRegulationScore returns the score summed through the last period of regulation. If that is not available,
+// the final score is used for games that did not go to overtime.
+func (e *SportsEvent) periodsRegulationScore(regulation, total *int) *int {
+	if regulation != nil {
+		return regulation
+	}
+
+	if e.EndedInRegulation() {
+		return total
+	}
+
+	return nil
+}
+
+// HasStarted returns true once the event is under way, either because its status has moved on from scheduled or
+// because its kickoff has passed
+func (e *SportsEvent) HasStarted() bool {
+	return e.Status != SportsEventStatusScheduled || !e.EventDate.After(time.Now())
+}
+
+// IsRegulationComplete returns true once the last period of regulation has ended
+func (e *SportsEvent) IsRegulationComplete() bool {
+	if e.Status == SportsEventStatusFinal {
+		return true
+	}
+
+	period := 0
+	if e.Period != nil {
+		period = *e.Period
+	}
+
+	regulation := e.League.RegulationPeriods()
+	atEndOfPeriod := e.StatusDetail != nil && strings.Contains(strings.ToLower(*e.StatusDetail), "end of")
+	return period > regulation || (period == regulation && atEndOfPeriod)
+}
+
 // IsPeriodComplete checks if a scoring period is complete based on game status and current period
 func (e *SportsEvent) IsPeriodComplete(setType NumberSetType) bool {
 	isFinal := e.Status == SportsEventStatusFinal
@@ -248,12 +376,30 @@ func (e *SportsEvent) IsPeriodComplete(setType NumberSetType) bool {
 		return isFinal || period >= 3 || atHalftime || (period == 2 && atEndOfPeriod)
 	case NumberSetTypeQ3:
 		return isFinal || period >= 4 || (period == 3 && atEndOfPeriod)
-	case NumberSetTypeQ4, NumberSetTypeFinal, NumberSetTypeAll:
+	case NumberSetTypeQ4:
+		// the 4th quarter is complete at the end of regulation, even if the game goes to overtime
+		return isFinal || period >= 5 || (period == 4 && atEndOfPeriod)
+	case NumberSetTypeFinal, NumberSetTypeAll:
 		return isFinal
+	case NumberSetTypeOT:
+		return isFinal && e.WentToOvertime()
+	case NumberSetTypeI3, NumberSetTypeI5, NumberSetTypeI7:
+		// an inning is complete once both teams have batted, which ESPN reports as the end of the inning
+		inning := setType.Inning()
+		return isFinal || period > inning || (period == inning && atEndOfPeriod)
 	}
 	return false
 }
 
+// IsPeriodCompleteWithOvertime checks if a scoring period is complete for a grid with the given overtime mode.
+// When overtime is paid separately, the final period is complete at the end of regulation.
+func (e *SportsEvent) IsPeriodCompleteWithOvertime(setType NumberSetType, overtime OvertimeMode) bool {
+	if overtime == OvertimeModeSeparate && (setType == NumberSetTypeFinal || setType == NumberSetTypeAll) {
+		return e.IsRegulationComplete()
+	}
+	return e.IsPeriodComplete(setType)
+}
+
 // ScoreForPeriod returns the home and away scores for a given number set type
 func (e *SportsEvent) ScoreForPeriod(setType NumberSetType) (*int, *int) {
 	switch setType {
@@ -268,17 +414,79 @@ func (e *SportsEvent) ScoreForPeriod(setType NumberSetType) (*int, *int) {
 		return e.HomeHalfScore(), e.AwayHalfScore()
 	case NumberSetTypeQ3:
 		return e.HomeQ3CumulativeScore(), e.AwayQ3CumulativeScore()
-	case NumberSetTypeQ4, NumberSetTypeFinal, NumberSetTypeAll:
+	case NumberSetTypeQ4:
+		return e.HomeQ4CumulativeScore(), e.AwayQ4CumulativeScore()
+	case NumberSetTypeFinal, NumberSetTypeAll:
 		return e.HomeScore, e.AwayScore
+	case NumberSetTypeOT:
+		// overtime is scored on the final score, which includes every overtime period
+		if !e.WentToOvertime() {
+			return nil, nil
+		}
+		return e.HomeScore, e.AwayScore
+	case NumberSetTypeI3, NumberSetTypeI5, NumberSetTypeI7:
+		return e.inningCheckpointScore(e.HomePeriods, e.HomeScore, setType.Inning()),
+			e.inningCheckpointScore(e.AwayPeriods, e.AwayScore, setType.Inning())
 	}
 	return nil, nil
 }
 
+// inningCheckpointScore returns the runs scored through the inning. A game called before the inning was played
+// is scored on its final score.
+func (e *SportsEvent) inningCheckpointScore(innings []int, total *int, through int) *int {
+	if score := inningsScore(innings, through); score != nil {
+		return score
+	}
+
+	if e.Status == SportsEventStatusFinal {
+		return total
+	}
+
+	return nil
+}
+
+// inningsScore sums the runs scored through the inning, or returns nil if the inning has not been played
+func inningsScore(innings []int, through int) *int {
+	if len(innings) < through {
+		return nil
+	}
+
+	sum := 0
+	for _, runs := range innings[:through] {
+		sum += runs
+	}
+	return &sum
+}
+
+// extraInningsScore sums the runs scored after the 9th inning, or returns nil if the game did not go to extra innings
+func extraInningsScore(innings []int) *int {
+	regulation := SportsLeagueMLB.RegulationPeriods()
+	if len(innings) <= regulation {
+		return nil
+	}
+
+	sum := 0
+	for _, runs := range innings[regulation:] {
+		sum += runs
+	}
+	return &sum
+}
+
+// ScoreForPeriodWithOvertime returns the home and away scores for a given number set type for a grid with the
+// given overtime mode. When overtime is paid separately, the final period is scored at the end of regulation.
+func (e *SportsEvent) ScoreForPeriodWithOvertime(setType NumberSetType, overtime OvertimeMode) (*int, *int) {
+	if overtime == OvertimeModeSeparate && (setType == NumberSetTypeFinal || setType == NumberSetTypeAll) {
+		return e.HomeRegulationScore(), e.AwayRegulationScore()
+	}
+	return e.ScoreForPeriod(setType)
+}
+
 const sportsEventColumns = `
 	id, espn_id, league, name, home_team_id, away_team_id, event_date, season, week, postseason, venue,
 	status, status_detail, period, clock, home_score, away_score,
 	home_q1, home_q2, home_q3, home_q4, home_ot,
 	away_q1, away_q2, away_q3, away_q4, away_ot,
+	home_periods, away_periods,
 	created, modified, last_synced`
 
 // sportsEventColumnsWithPrefix is for use in JOIN queries where table alias is needed
@@ -287,10 +495,12 @@ const sportsEventColumnsWithPrefix = `
 	e.status, e.status_detail, e.period, e.clock, e.home_score, e.away_score,
 	e.home_q1, e.home_q2, e.home_q3, e.home_q4, e.home_ot,
 	e.away_q1, e.away_q2, e.away_q3, e.away_q4, e.away_ot,
+	e.home_periods, e.away_periods,
 	e.created, e.modified, e.last_synced`
 
 func (m *Model) sportsEventByRow(scan scanFunc) (*SportsEvent, error) {
 	event := &SportsEvent{model: m}
+	var homePeriods, awayPeriods []sql.NullInt64
 	if err := scan(
 		&event.ID,
 		&event.ESPNID,
@@ -319,12 +529,29 @@ func (m *Model) sportsEventByRow(scan scanFunc) (*SportsEvent, error) {
 		&event.AwayQ3,
 		&event.AwayQ4,
 		&event.AwayOT,
+		pq.Array(&homePeriods),
+		pq.Array(&awayPeriods),
 		&event.Created,
 		&event.Modified,
 		&event.LastSynced,
 	); err != nil {
 		return nil, err
 	}
+
+	if homePeriods != nil {
+		event.HomePeriods = make([]int, len(homePeriods))
+		for i, val := range homePeriods {
+			event.HomePeriods[i] = int(val.Int64)
+		}
+	}
+
+	if awayPeriods != nil {
+		event.AwayPeriods = make([]int, len(awayPeriods))
+		for i, val := range awayPeriods {
+			event.AwayPeriods[i] = int(val.Int64)
+		}
+	}
+
 	return event, nil
 }
 
@@ -565,12 +792,14 @@ func (m *Model) UpsertSportsEvent(ctx context.Context, q Queryable, event *Sport
 			status, status_detail, period, clock, home_score, away_score,
 			home_q1, home_q2, home_q3, home_q4, home_ot,
 			away_q1, away_q2, away_q3, away_q4, away_ot,
+			home_periods, away_periods,
 			created, modified, last_synced
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
 			$11, $12, $13, $14, $15, $16,
 			$17, $18, $19, $20, $21,
 			$22, $23, $24, $25, $26,
+			$27, $28,
 			(NOW() AT TIME ZONE 'utc'), (NOW() AT TIME ZONE 'utc'), (NOW() AT TIME ZONE 'utc')
 		)
 		ON CONFLICT (espn_id) WHERE espn_id IS NOT NULL DO UPDATE SET
@@ -599,6 +828,8 @@ func (m *Model) UpsertSportsEvent(ctx context.Context, q Queryable, event *Sport
 			away_q3 = COALESCE(EXCLUDED.away_q3, sports_events.away_q3),
 			away_q4 = COALESCE(EXCLUDED.away_q4, sports_events.away_q4),
 			away_ot = COALESCE(EXCLUDED.away_ot, sports_events.away_ot),
+			home_periods = COALESCE(EXCLUDED.home_periods, sports_events.home_periods),
+			away_periods = COALESCE(EXCLUDED.away_periods, sports_events.away_periods),
 			modified = (NOW() AT TIME ZONE 'utc'),
 			last_synced = (NOW() AT TIME ZONE 'utc')
 		RETURNING id
@@ -631,8 +862,15 @@ func (m *Model) UpsertSportsEvent(ctx context.Context, q Queryable, event *Sport
 		event.AwayQ3,
 		event.AwayQ4,
 		event.AwayOT,
+		pq.Array(event.HomePeriods),
+		pq.Array(event.AwayPeriods),
 	).Scan(&event.ID)
-	return err
+	if err != nil {
+		return err
+	}
+
+	// pools that lock at kickoff follow the event if it is rescheduled
+	return syncAutoLocksForEvent(ctx, q, event.ID)
 }
 
 // FinalizeStaleEvents sets any non-final events with an event_date older than the
@@ -655,6 +893,8 @@ func (m *Model) FinalizeStaleEvents(ctx context.Context) (int64, error) {
 		    away_q3 = NULL,
 		    away_q4 = NULL,
 		    away_ot = NULL,
+		    home_periods = NULL,
+		    away_periods = NULL,
 		    period = NULL,
 		    clock = NULL,
 		    status_detail = NULL
@@ -0,0 +1,193 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SportsEventScore is a distinct score state of a sports event
+type SportsEventScore struct {
+	HomeScore int       `json:"homeScore"`
+	AwayScore int       `json:"awayScore"`
+	Period    *int      `json:"period,omitempty"`
+	Clock     *string   `json:"clock,omitempty"`
+	Created   time.Time `json:"created"`
+}
+
+// RecordSportsEventScore will add the event's current score to its score history if it hasn't been seen before.
+// A scoreless game is not recorded. It returns true if a new score was recorded.
+func (m *Model) RecordSportsEventScore(ctx context.Context, q Queryable, event *SportsEvent) (bool, error) {
+	if q == nil {
+		q = m.DB
+	}
+
+	if event.HomeScore == nil || event.AwayScore == nil {
+		return false, nil
+	}
+
+	if *event.HomeScore == 0 && *event.AwayScore == 0 {
+		return false, nil
+	}
+
+	return recordSportsEventScore(ctx, q, event.ID, &SportsEventScore{
+		HomeScore: *event.HomeScore,
+		AwayScore: *event.AwayScore,
+		Period:    event.Period,
+		Clock:     event.Clock,
+	})
+}
+
+// RecordSportsEventScoringPlays will make the event's score history the score after each of its scoring plays, which
+// must be in the order they happened. Scores not yet recorded are added, and if the history no longer matches the
+// plays, such as when a score was overturned, it is rewritten. It returns true if the history changed.
+func (m *Model) RecordSportsEventScoringPlays(ctx context.Context, event *SportsEvent, plays []*SportsEventScore) (bool, error) {
+	// a score is only recorded once, and a scoreless game is not recorded
+	scores := make([]*SportsEventScore, 0, len(plays))
+	seen := make(map[[2]int]bool)
+	for _, play := range plays {
+		key := [2]int{play.HomeScore, play.AwayScore}
+		if seen[key] || (play.HomeScore == 0 && play.AwayScore == 0) {
+			continue
+		}
+		seen[key] = true
+		scores = append(scores, play)
+	}
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	const query = `
+		SELECT home_score, away_score
+		FROM sports_event_scores
+		WHERE sports_event_id = $1
+		ORDER BY id
+		FOR UPDATE`
+	rows, err := tx.QueryContext(ctx, query, event.ID)
+	if err != nil {
+		return false, fmt.Errorf("loading score history: %w", err)
+	}
+	defer rows.Close()
+
+	recorded := make([][2]int, 0)
+	for rows.Next() {
+		var key [2]int
+		if err := rows.Scan(&key[0], &key[1]); err != nil {
+			return false, fmt.Errorf("scanning score history: %w", err)
+		}
+		recorded = append(recorded, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return false, fmt.Errorf("iterating score history: %w", err)
+	}
+
+	// the history is kept if the plays only add to it, otherwise it is recorded again from the first play
+	matches := len(recorded) <= len(scores)
+	for i := 0; matches && i < len(recorded); i++ {
+		matches = recorded[i] == [2]int{scores[i].HomeScore, scores[i].AwayScore}
+	}
+
+	changed := false
+	start := len(recorded)
+	if !matches {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM sports_event_scores WHERE sports_event_id = $1", event.ID); err != nil {
+			return false, fmt.Errorf("clearing score history: %w", err)
+		}
+		changed = true
+		start = 0
+	}
+
+	for _, score := range scores[start:] {
+		if _, err := recordSportsEventScore(ctx, tx, event.ID, score); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return changed, nil
+}
+
+func recordSportsEventScore(ctx context.Context, q Queryable, eventID int64, score *SportsEventScore) (bool, error) {
+	const query = `
+		INSERT INTO sports_event_scores (sports_event_id, home_score, away_score, period, clock)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (sports_event_id, home_score, away_score) DO NOTHING`
+	res, err := q.ExecContext(ctx, query, eventID, score.HomeScore, score.AwayScore, score.Period, score.Clock)
+	if err != nil {
+		return false, fmt.Errorf("recording score: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("recording score: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// LoadScoreHistory loads every recorded score of the event in the order they happened
+func (e *SportsEvent) LoadScoreHistory(ctx context.Context) error {
+	const query = `
+		SELECT home_score, away_score, period, clock, created
+		FROM sports_event_scores
+		WHERE sports_event_id = $1
+		ORDER BY id`
+	rows, err := e.model.DB.QueryContext(ctx, query, e.ID)
+	if err != nil {
+		return fmt.Errorf("loading score history: %w", err)
+	}
+	defer rows.Close()
+
+	scores := make([]*SportsEventScore, 0)
+	for rows.Next() {
+		var score SportsEventScore
+		if err := rows.Scan(&score.HomeScore, &score.AwayScore, &score.Period, &score.Clock, &score.Created); err != nil {
+			return fmt.Errorf("scanning score history: %w", err)
+		}
+
+		score.Created = score.Created.In(locationNewYork)
+		scores = append(scores, &score)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating score history: %w", err)
+	}
+
+	e.scoreHistory = scores
+	return nil
+}
+
+// ScoreHistory returns the loaded score history
+func (e *SportsEvent) ScoreHistory() []*SportsEventScore {
+	return e.scoreHistory
+}
+
+// SetScoreHistory sets the score history
+func (e *SportsEvent) SetScoreHistory(scores []*SportsEventScore) {
+	e.scoreHistory = scores
+}
@@ -0,0 +1,157 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/onsi/gomega"
+)
+
+func TestRecordSportsEventScore(t *testing.T) {
+	ensureIntegration(t)
+
+	g := gomega.NewWithT(t)
+	m := New(getDB())
+	ctx := context.Background()
+
+	homeTeam := &SportsTeam{
+		ID:           "test-home-" + randString(),
+		League:       SportsLeagueNFL,
+		Name:         "Bills",
+		FullName:     "Buffalo Bills",
+		Abbreviation: "BUF",
+	}
+	awayTeam := &SportsTeam{
+		ID:           "test-away-" + randString(),
+		League:       SportsLeagueNFL,
+		Name:         "Dolphins",
+		FullName:     "Miami Dolphins",
+		Abbreviation: "MIA",
+	}
+	g.Expect(m.UpsertSportsTeam(ctx, nil, homeTeam)).Should(gomega.Succeed())
+	g.Expect(m.UpsertSportsTeam(ctx, nil, awayTeam)).Should(gomega.Succeed())
+
+	event := m.NewSportsEvent()
+	event.ESPNID = "test-event-" + randString()
+	event.League = SportsLeagueNFL
+	event.HomeTeamID = homeTeam.ID
+	event.AwayTeamID = awayTeam.ID
+	event.EventDate = time.Now()
+	event.Season = 2024
+	event.Status = SportsEventStatusInProgress
+	event.HomeScore = intPtr(0)
+	event.AwayScore = intPtr(0)
+	g.Expect(m.UpsertSportsEvent(ctx, nil, event)).Should(gomega.Succeed())
+
+	// a scoreless game is not recorded
+	recorded, err := m.RecordSportsEventScore(ctx, nil, event)
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(recorded).Should(gomega.BeFalse())
+
+	event.HomeScore = intPtr(7)
+	recorded, err = m.RecordSportsEventScore(ctx, nil, event)
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(recorded).Should(gomega.BeTrue())
+
+	// the same score is only recorded once
+	recorded, err = m.RecordSportsEventScore(ctx, nil, event)
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(recorded).Should(gomega.BeFalse())
+
+	event.AwayScore = intPtr(3)
+	recorded, err = m.RecordSportsEventScore(ctx, nil, event)
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(recorded).Should(gomega.BeTrue())
+
+	g.Expect(event.LoadScoreHistory(ctx)).Should(gomega.Succeed())
+	history := event.ScoreHistory()
+	g.Expect(history).Should(gomega.HaveLen(2))
+	g.Expect(history[0].HomeScore).Should(gomega.Equal(7))
+	g.Expect(history[0].AwayScore).Should(gomega.Equal(0))
+	g.Expect(history[1].HomeScore).Should(gomega.Equal(7))
+	g.Expect(history[1].AwayScore).Should(gomega.Equal(3))
+}
+
+func TestRecordSportsEventScoringPlays(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	db, mock, err := sqlmock.New()
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	defer db.Close()
+
+	m := New(db)
+	event := &SportsEvent{model: m, ID: 10}
+	plays := []*SportsEventScore{
+		{HomeScore: 7, AwayScore: 0, Period: intPtr(1)},
+		{HomeScore: 7, AwayScore: 3, Period: intPtr(1)},
+		{HomeScore: 14, AwayScore: 3, Period: intPtr(2)},
+	}
+
+	// the plays after the recorded history are added in order
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT home_score, away_score FROM sports_event_scores WHERE sports_event_id = \$1 ORDER BY id FOR UPDATE`).
+		WithArgs(int64(10)).
+		WillReturnRows(sqlmock.NewRows([]string{"home_score", "away_score"}).AddRow(7, 0))
+	mock.ExpectExec(`INSERT INTO sports_event_scores`).
+		WithArgs(int64(10), 7, 3, intPtr(1), nil).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectExec(`INSERT INTO sports_event_scores`).
+		WithArgs(int64(10), 14, 3, intPtr(2), nil).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectCommit()
+
+	changed, err := m.RecordSportsEventScoringPlays(context.Background(), event, plays)
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(changed).Should(gomega.BeTrue())
+
+	// a score recorded between two plays is dropped by recording the history again
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT home_score, away_score FROM sports_event_scores`).
+		WithArgs(int64(10)).
+		WillReturnRows(sqlmock.NewRows([]string{"home_score", "away_score"}).AddRow(7, 0).AddRow(14, 0))
+	mock.ExpectExec(`DELETE FROM sports_event_scores WHERE sports_event_id = \$1`).
+		WithArgs(int64(10)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	for _, play := range plays {
+		mock.ExpectExec(`INSERT INTO sports_event_scores`).
+			WithArgs(int64(10), play.HomeScore, play.AwayScore, play.Period, nil).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+
+	changed, err = m.RecordSportsEventScoringPlays(context.Background(), event, plays)
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(changed).Should(gomega.BeTrue())
+
+	// nothing changes once every play is recorded
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT home_score, away_score FROM sports_event_scores`).
+		WithArgs(int64(10)).
+		WillReturnRows(sqlmock.NewRows([]string{"home_score", "away_score"}).AddRow(7, 0).AddRow(7, 3).AddRow(14, 3))
+	mock.ExpectCommit()
+
+	changed, err = m.RecordSportsEventScoringPlays(context.Background(), event, plays)
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(changed).Should(gomega.BeFalse())
+
+	g.Expect(mock.ExpectationsWereMet()).Should(gomega.Succeed())
+}
@@ -377,6 +377,280 @@ func TestSportsEventScoreForPeriod(t *testing.T) {
 	g.Expect(*awayFinal).Should(gomega.Equal(100))
 }
 
+func TestSportsEventQ4(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// regulation ends 24-24 and the game goes to overtime
+	event := &SportsEvent{
+		League:       SportsLeagueNFL,
+		Status:       SportsEventStatusInProgress,
+		Period:       intPtr(4),
+		StatusDetail: strPtr("End of 4th Quarter"),
+		HomeQ1:       intPtr(7),
+		HomeQ2:       intPtr(7),
+		HomeQ3:       intPtr(3),
+		HomeQ4:       intPtr(7),
+		HomeScore:    intPtr(24),
+		AwayQ1:       intPtr(0),
+		AwayQ2:       intPtr(10),
+		AwayQ3:       intPtr(7),
+		AwayQ4:       intPtr(7),
+		AwayScore:    intPtr(24),
+	}
+	g.Expect(event.IsPeriodComplete(NumberSetTypeQ4)).Should(gomega.BeTrue())
+	g.Expect(event.IsPeriodComplete(NumberSetTypeFinal)).Should(gomega.BeFalse())
+
+	event.Period = intPtr(5)
+	event.StatusDetail = nil
+	event.Status = SportsEventStatusFinal
+	event.HomeOT = intPtr(6)
+	event.AwayOT = intPtr(0)
+	event.HomeScore = intPtr(30)
+	g.Expect(event.IsPeriodComplete(NumberSetTypeQ4)).Should(gomega.BeTrue())
+
+	// the 4th quarter uses the score at the end of regulation, not the final score
+	home, away := event.ScoreForPeriod(NumberSetTypeQ4)
+	g.Expect(*home).Should(gomega.Equal(24))
+	g.Expect(*away).Should(gomega.Equal(24))
+
+	home, away = event.ScoreForPeriod(NumberSetTypeFinal)
+	g.Expect(*home).Should(gomega.Equal(30))
+	g.Expect(*away).Should(gomega.Equal(24))
+
+	// without quarter scores, the final score is only used when there was no overtime
+	event.HomeQ4 = nil
+	event.AwayQ4 = nil
+	home, away = event.ScoreForPeriod(NumberSetTypeQ4)
+	g.Expect(home).Should(gomega.BeNil())
+	g.Expect(away).Should(gomega.BeNil())
+
+	event.HomeOT = nil
+	event.AwayOT = nil
+	home, away = event.ScoreForPeriod(NumberSetTypeQ4)
+	g.Expect(*home).Should(gomega.Equal(30))
+	g.Expect(*away).Should(gomega.Equal(24))
+}
+
+func TestSportsEventOvertime(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// NFL game tied 24-24 at the end of regulation, in double overtime
+	event := &SportsEvent{
+		League:    SportsLeagueNFL,
+		Status:    SportsEventStatusInProgress,
+		Period:    intPtr(6),
+		HomeQ1:    intPtr(7),
+		HomeQ2:    intPtr(7),
+		HomeQ3:    intPtr(3),
+		HomeQ4:    intPtr(7),
+		HomeOT:    intPtr(3),
+		HomeScore: intPtr(27),
+		AwayQ1:    intPtr(0),
+		AwayQ2:    intPtr(10),
+		AwayQ3:    intPtr(7),
+		AwayQ4:    intPtr(7),
+		AwayOT:    intPtr(3),
+		AwayScore: intPtr(27),
+	}
+	g.Expect(event.WentToOvertime()).Should(gomega.BeTrue())
+	g.Expect(event.IsRegulationComplete()).Should(gomega.BeTrue())
+	g.Expect(event.IsPeriodComplete(NumberSetTypeOT)).Should(gomega.BeFalse())
+	g.Expect(event.IsPeriodCompleteWithOvertime(NumberSetTypeFinal, OvertimeModeInclude)).Should(gomega.BeFalse())
+	g.Expect(event.IsPeriodCompleteWithOvertime(NumberSetTypeFinal, OvertimeModeSeparate)).Should(gomega.BeTrue())
+
+	home, away := event.ScoreForPeriodWithOvertime(NumberSetTypeFinal, OvertimeModeSeparate)
+	g.Expect(*home).Should(gomega.Equal(24))
+	g.Expect(*away).Should(gomega.Equal(24))
+
+	event.Status = SportsEventStatusFinal
+	event.HomeOT = intPtr(9)
+	event.HomeScore = intPtr(33)
+	g.Expect(event.IsPeriodComplete(NumberSetTypeOT)).Should(gomega.BeTrue())
+	g.Expect(*event.HomeOTScore()).Should(gomega.Equal(9))
+	g.Expect(*event.AwayOTScore()).Should(gomega.Equal(3))
+
+	// overtime and an included final are both scored on the score at the end of the game
+	home, away = event.ScoreForPeriod(NumberSetTypeOT)
+	g.Expect(*home).Should(gomega.Equal(33))
+	g.Expect(*away).Should(gomega.Equal(27))
+	home, away = event.ScoreForPeriodWithOvertime(NumberSetTypeFinal, OvertimeModeInclude)
+	g.Expect(*home).Should(gomega.Equal(33))
+	g.Expect(*away).Should(gomega.Equal(27))
+
+	// a game that ends in regulation never completes an overtime period
+	event = &SportsEvent{
+		League:    SportsLeagueNFL,
+		Status:    SportsEventStatusFinal,
+		Period:    intPtr(4),
+		HomeScore: intPtr(21),
+		AwayScore: intPtr(17),
+	}
+	g.Expect(event.WentToOvertime()).Should(gomega.BeFalse())
+	g.Expect(event.EndedInRegulation()).Should(gomega.BeTrue())
+	g.Expect(event.IsPeriodComplete(NumberSetTypeOT)).Should(gomega.BeFalse())
+	home, away = event.ScoreForPeriod(NumberSetTypeOT)
+	g.Expect(home).Should(gomega.BeNil())
+	g.Expect(away).Should(gomega.BeNil())
+	home, away = event.ScoreForPeriodWithOvertime(NumberSetTypeFinal, OvertimeModeSeparate)
+	g.Expect(*home).Should(gomega.Equal(21))
+	g.Expect(*away).Should(gomega.Equal(17))
+}
+
+func TestSportsEventOvertimeNCAAB(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// triple overtime: the 1st and 2nd overtime are synced into Q3 and Q4, any later overtime into OT
+	event := &SportsEvent{
+		League:    SportsLeagueNCAAB,
+		Status:    SportsEventStatusFinal,
+		Period:    intPtr(5),
+		HomeQ1:    intPtr(35),
+		HomeQ2:    intPtr(40),
+		HomeQ3:    intPtr(10),
+		HomeQ4:    intPtr(8),
+		HomeOT:    intPtr(12),
+		HomeScore: intPtr(105),
+		AwayQ1:    intPtr(40),
+		AwayQ2:    intPtr(35),
+		AwayQ3:    intPtr(10),
+		AwayQ4:    intPtr(8),
+		AwayOT:    intPtr(9),
+		AwayScore: intPtr(102),
+	}
+	g.Expect(event.WentToOvertime()).Should(gomega.BeTrue())
+	g.Expect(*event.HomeOTScore()).Should(gomega.Equal(30))
+	g.Expect(*event.AwayOTScore()).Should(gomega.Equal(27))
+
+	home, away := event.ScoreForPeriodWithOvertime(NumberSetTypeFinal, OvertimeModeSeparate)
+	g.Expect(*home).Should(gomega.Equal(75))
+	g.Expect(*away).Should(gomega.Equal(75))
+
+	home, away = event.ScoreForPeriod(NumberSetTypeOT)
+	g.Expect(*home).Should(gomega.Equal(105))
+	g.Expect(*away).Should(gomega.Equal(102))
+
+	// regulation is over after the 2nd half
+	event.Status = SportsEventStatusInProgress
+	event.Period = intPtr(2)
+	event.StatusDetail = strPtr("End of 2nd Half")
+	g.Expect(event.IsRegulationComplete()).Should(gomega.BeTrue())
+
+	event.StatusDetail = nil
+	event.HomeQ3, event.HomeQ4, event.HomeOT = nil, nil, nil
+	event.AwayQ3, event.AwayQ4, event.AwayOT = nil, nil, nil
+	g.Expect(event.IsRegulationComplete()).Should(gomega.BeFalse())
+	g.Expect(event.WentToOvertime()).Should(gomega.BeFalse())
+}
+
+func TestSportsEventNHL(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// tied 2-2 after three periods and won in a shootout. ESPN's 4th period is overtime and the shootout follows.
+	event := &SportsEvent{
+		League:       SportsLeagueNHL,
+		Status:       SportsEventStatusInProgress,
+		Period:       intPtr(3),
+		StatusDetail: strPtr("End of 3rd Period"),
+		HomeQ1:       intPtr(1),
+		HomeQ2:       intPtr(0),
+		HomeQ3:       intPtr(1),
+		HomeScore:    intPtr(2),
+		AwayQ1:       intPtr(0),
+		AwayQ2:       intPtr(2),
+		AwayQ3:       intPtr(0),
+		AwayScore:    intPtr(2),
+	}
+	g.Expect(event.IsPeriodComplete(NumberSetTypeQ3)).Should(gomega.BeTrue())
+	g.Expect(event.IsRegulationComplete()).Should(gomega.BeTrue())
+	g.Expect(event.WentToOvertime()).Should(gomega.BeFalse())
+
+	home, away := event.ScoreForPeriod(NumberSetTypeQ3)
+	g.Expect(*home).Should(gomega.Equal(2))
+	g.Expect(*away).Should(gomega.Equal(2))
+
+	event.Status = SportsEventStatusFinal
+	event.Period = intPtr(5)
+	event.StatusDetail = strPtr("Final/SO")
+	event.HomeQ4, event.HomeOT, event.HomeScore = intPtr(0), intPtr(1), intPtr(3)
+	event.AwayQ4, event.AwayOT = intPtr(0), intPtr(0)
+	g.Expect(event.WentToOvertime()).Should(gomega.BeTrue())
+	g.Expect(*event.HomeOTScore()).Should(gomega.Equal(1))
+	g.Expect(*event.AwayOTScore()).Should(gomega.Equal(0))
+
+	home, away = event.ScoreForPeriodWithOvertime(NumberSetTypeFinal, OvertimeModeSeparate)
+	g.Expect(*home).Should(gomega.Equal(2))
+	g.Expect(*away).Should(gomega.Equal(2))
+
+	home, away = event.ScoreForPeriod(NumberSetTypeOT)
+	g.Expect(*home).Should(gomega.Equal(3))
+	g.Expect(*away).Should(gomega.Equal(2))
+}
+
+func TestSportsEventMLB(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// in the middle of the 7th inning
+	event := &SportsEvent{
+		League:       SportsLeagueMLB,
+		Status:       SportsEventStatusInProgress,
+		Period:       intPtr(7),
+		StatusDetail: strPtr("Middle of 7th Inning"),
+		HomeScore:    intPtr(4),
+		AwayScore:    intPtr(3),
+		HomePeriods:  []int{0, 2, 0, 0, 1, 1},
+		AwayPeriods:  []int{1, 0, 0, 2, 0, 0, 0},
+	}
+	g.Expect(event.IsPeriodComplete(NumberSetTypeI3)).Should(gomega.BeTrue())
+	g.Expect(event.IsPeriodComplete(NumberSetTypeI5)).Should(gomega.BeTrue())
+	g.Expect(event.IsPeriodComplete(NumberSetTypeI7)).Should(gomega.BeFalse())
+
+	home, away := event.ScoreForPeriod(NumberSetTypeI3)
+	g.Expect(*home).Should(gomega.Equal(2))
+	g.Expect(*away).Should(gomega.Equal(1))
+
+	home, away = event.ScoreForPeriod(NumberSetTypeI5)
+	g.Expect(*home).Should(gomega.Equal(3))
+	g.Expect(*away).Should(gomega.Equal(3))
+
+	// the home team hasn't batted in the 7th yet
+	home, _ = event.ScoreForPeriod(NumberSetTypeI7)
+	g.Expect(home).Should(gomega.BeNil())
+
+	// won in the 10th inning
+	event.Status = SportsEventStatusFinal
+	event.Period = intPtr(10)
+	event.StatusDetail = strPtr("Final/10")
+	event.HomePeriods = []int{0, 2, 0, 0, 1, 1, 0, 0, 0, 1}
+	event.AwayPeriods = []int{1, 0, 0, 2, 0, 0, 0, 1, 0, 0}
+	event.HomeScore = intPtr(5)
+	g.Expect(event.IsPeriodComplete(NumberSetTypeI7)).Should(gomega.BeTrue())
+	g.Expect(event.WentToOvertime()).Should(gomega.BeTrue())
+	g.Expect(*event.HomeOTScore()).Should(gomega.Equal(1))
+	g.Expect(*event.AwayOTScore()).Should(gomega.Equal(0))
+
+	home, away = event.ScoreForPeriodWithOvertime(NumberSetTypeFinal, OvertimeModeSeparate)
+	g.Expect(*home).Should(gomega.Equal(4))
+	g.Expect(*away).Should(gomega.Equal(4))
+
+	// a game called after five innings is scored on its final score at every later checkpoint
+	event = &SportsEvent{
+		League:      SportsLeagueMLB,
+		Status:      SportsEventStatusFinal,
+		Period:      intPtr(5),
+		HomeScore:   intPtr(6),
+		AwayScore:   intPtr(1),
+		HomePeriods: []int{3, 0, 0, 3},
+		AwayPeriods: []int{0, 0, 1, 0, 0},
+	}
+	g.Expect(event.WentToOvertime()).Should(gomega.BeFalse())
+	home, away = event.ScoreForPeriod(NumberSetTypeI7)
+	g.Expect(*home).Should(gomega.Equal(6))
+	g.Expect(*away).Should(gomega.Equal(1))
+	home, away = event.ScoreForPeriodWithOvertime(NumberSetTypeFinal, OvertimeModeSeparate)
+	g.Expect(*home).Should(gomega.Equal(6))
+	g.Expect(*away).Should(gomega.Equal(1))
+}
+
 func TestSportsEventNewSportsEvent(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
@@ -983,3 +1257,17 @@ func intPtr(i int) *int {
 func strPtr(s string) *string {
 	return &s
 }
+
+func TestSportsEventHasStarted(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	event := &SportsEvent{Status: SportsEventStatusScheduled, EventDate: time.Now().Add(time.Hour)}
+	g.Expect(event.HasStarted()).Should(gomega.BeFalse())
+
+	// the sync may not have seen kickoff yet
+	event.EventDate = time.Now().Add(-time.Minute)
+	g.Expect(event.HasStarted()).Should(gomega.BeTrue())
+
+	event = &SportsEvent{Status: SportsEventStatusInProgress, EventDate: time.Now().Add(time.Hour)}
+	g.Expect(event.HasStarted()).Should(gomega.BeTrue())
+}
@@ -36,21 +36,31 @@ const (
 	SportsLeagueNCAAB SportsLeague = "ncaab"
 	// SportsLeagueNCAAF is NCAA Football
 	SportsLeagueNCAAF SportsLeague = "ncaaf"
+	// SportsLeagueNHL is the National Hockey League
+	SportsLeagueNHL SportsLeague = "nhl"
+	// SportsLeagueMLB is Major League Baseball
+	SportsLeagueMLB SportsLeague = "mlb"
+	// SportsLeagueMLS is Major League Soccer
+	SportsLeagueMLS SportsLeague = "mls"
 )
 
 // SportsLeagueInfo contains metadata for a sports league
 type SportsLeagueInfo struct {
-	Key   SportsLeague `json:"key"`
-	Label string       `json:"label"`
+	Key        SportsLeague `json:"key"`
+	Label      string       `json:"label"`
+	PeriodName string       `json:"periodName"` // What the league calls a scoring period: "Quarter", "Inning"
 }
 
 // validSportsLeagues contains all valid leagues
 var validSportsLeagues = []SportsLeagueInfo{
-	{Key: SportsLeagueNFL, Label: "NFL"},
-	{Key: SportsLeagueNBA, Label: "NBA"},
-	{Key: SportsLeagueWNBA, Label: "WNBA"},
-	{Key: SportsLeagueNCAAB, Label: "NCAAB"},
-	{Key: SportsLeagueNCAAF, Label: "NCAAF"},
+	{Key: SportsLeagueNFL, Label: "NFL", PeriodName: "Quarter"},
+	{Key: SportsLeagueNBA, Label: "NBA", PeriodName: "Quarter"},
+	{Key: SportsLeagueWNBA, Label: "WNBA", PeriodName: "Quarter"},
+	{Key: SportsLeagueNCAAB, Label: "NCAAB", PeriodName: "Half"},
+	{Key: SportsLeagueNCAAF, Label: "NCAAF", PeriodName: "Quarter"},
+	{Key: SportsLeagueNHL, Label: "NHL", PeriodName: "Period"},
+	{Key: SportsLeagueMLB, Label: "MLB", PeriodName: "Inning"},
+	{Key: SportsLeagueMLS, Label: "MLS", PeriodName: "Half"},
 }
 
 // ValidSportsLeagues returns all valid sports leagues with metadata
@@ -73,9 +83,51 @@ func (l SportsLeague) IsValid() bool {
 	return IsValidSportsLeague(string(l))
 }
 
-// UsesHalves returns true if the league uses halves instead of quarters (e.g., NCAAB)
+// UsesHalves returns true if the league uses halves instead of quarters (e.g., NCAAB, MLS)
 func (l SportsLeague) UsesHalves() bool {
-	return l == SportsLeagueNCAAB
+	return l == SportsLeagueNCAAB || l == SportsLeagueMLS
+}
+
+// UsesInnings returns true if the league plays innings (e.g., MLB)
+func (l SportsLeague) UsesInnings() bool {
+	return l == SportsLeagueMLB
+}
+
+// RegulationPeriods returns the number of periods played in regulation. Any period after these is overtime.
+func (l SportsLeague) RegulationPeriods() int {
+	switch {
+	case l.UsesHalves():
+		return 2
+	case l.UsesInnings():
+		return 9
+	case l == SportsLeagueNHL:
+		return 3
+	}
+	return 4
+}
+
+// HasScoringPlays returns true if ESPN lists the league's scoring plays, which is where the score history that an
+// every score grid pays out on comes from. Other leagues only have the score at each sync, which can miss a score.
+func (l SportsLeague) HasScoringPlays() bool {
+	return l == SportsLeagueNFL || l == SportsLeagueNCAAF
+}
+
+// HasPeriod returns true if the league has the scoring period of the number set type. Hockey plays three periods
+// with no halftime, and baseball is only scored at inning checkpoints.
+func (l SportsLeague) HasPeriod(setType NumberSetType) bool {
+	switch setType {
+	case NumberSetTypeAll, NumberSetTypeFinal, NumberSetTypeOT:
+		return true
+	case NumberSetTypeHalf:
+		return l.UsesHalves() || l.RegulationPeriods() == 4
+	case NumberSetTypeQ1, NumberSetTypeQ2, NumberSetTypeQ3:
+		return !l.UsesHalves() && !l.UsesInnings()
+	case NumberSetTypeQ4:
+		return l.RegulationPeriods() == 4
+	case NumberSetTypeI3, NumberSetTypeI5, NumberSetTypeI7:
+		return l.UsesInnings()
+	}
+	return false
 }
 
 // Value implements driver.Valuer for database storage
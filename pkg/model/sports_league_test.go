@@ -31,6 +31,9 @@ func TestSportsLeagueIsValid(t *testing.T) {
 	g.Expect(SportsLeagueWNBA.IsValid()).Should(gomega.BeTrue())
 	g.Expect(SportsLeagueNCAAB.IsValid()).Should(gomega.BeTrue())
 	g.Expect(SportsLeagueNCAAF.IsValid()).Should(gomega.BeTrue())
+	g.Expect(SportsLeagueNHL.IsValid()).Should(gomega.BeTrue())
+	g.Expect(SportsLeagueMLB.IsValid()).Should(gomega.BeTrue())
+	g.Expect(SportsLeagueMLS.IsValid()).Should(gomega.BeTrue())
 	g.Expect(SportsLeague("invalid").IsValid()).Should(gomega.BeFalse())
 }
 
@@ -50,7 +53,7 @@ func TestValidSportsLeagues(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
 	leagues := ValidSportsLeagues()
-	g.Expect(len(leagues)).Should(gomega.Equal(5))
+	g.Expect(len(leagues)).Should(gomega.Equal(8))
 
 	// Check that all leagues are present
 	keys := make(map[SportsLeague]bool)
@@ -63,6 +66,9 @@ func TestValidSportsLeagues(t *testing.T) {
 	g.Expect(keys[SportsLeagueWNBA]).Should(gomega.BeTrue())
 	g.Expect(keys[SportsLeagueNCAAB]).Should(gomega.BeTrue())
 	g.Expect(keys[SportsLeagueNCAAF]).Should(gomega.BeTrue())
+	g.Expect(keys[SportsLeagueNHL]).Should(gomega.BeTrue())
+	g.Expect(keys[SportsLeagueMLB]).Should(gomega.BeTrue())
+	g.Expect(keys[SportsLeagueMLS]).Should(gomega.BeTrue())
 }
 
 func TestSportsLeagueScan(t *testing.T) {
@@ -106,6 +112,54 @@ func TestSportsLeagueUsesHalves(t *testing.T) {
 	g.Expect(SportsLeagueNBA.UsesHalves()).Should(gomega.BeFalse())
 	g.Expect(SportsLeagueWNBA.UsesHalves()).Should(gomega.BeFalse())
 	g.Expect(SportsLeagueNCAAF.UsesHalves()).Should(gomega.BeFalse())
+	g.Expect(SportsLeagueMLS.UsesHalves()).Should(gomega.BeTrue())
+	g.Expect(SportsLeagueNHL.UsesHalves()).Should(gomega.BeFalse())
+	g.Expect(SportsLeagueMLB.UsesHalves()).Should(gomega.BeFalse())
+}
+
+func TestSportsLeagueRegulationPeriods(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	g.Expect(SportsLeagueNFL.RegulationPeriods()).Should(gomega.Equal(4))
+	g.Expect(SportsLeagueNBA.RegulationPeriods()).Should(gomega.Equal(4))
+	g.Expect(SportsLeagueNCAAB.RegulationPeriods()).Should(gomega.Equal(2))
+	g.Expect(SportsLeagueMLS.RegulationPeriods()).Should(gomega.Equal(2))
+	g.Expect(SportsLeagueNHL.RegulationPeriods()).Should(gomega.Equal(3))
+	g.Expect(SportsLeagueMLB.RegulationPeriods()).Should(gomega.Equal(9))
+}
+
+func TestSportsLeagueHasPeriod(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	g.Expect(SportsLeagueNFL.HasPeriod(NumberSetTypeQ4)).Should(gomega.BeTrue())
+	g.Expect(SportsLeagueNFL.HasPeriod(NumberSetTypeHalf)).Should(gomega.BeTrue())
+	g.Expect(SportsLeagueNFL.HasPeriod(NumberSetTypeI5)).Should(gomega.BeFalse())
+
+	// hockey plays three periods with no halftime
+	g.Expect(SportsLeagueNHL.HasPeriod(NumberSetTypeQ3)).Should(gomega.BeTrue())
+	g.Expect(SportsLeagueNHL.HasPeriod(NumberSetTypeQ4)).Should(gomega.BeFalse())
+	g.Expect(SportsLeagueNHL.HasPeriod(NumberSetTypeHalf)).Should(gomega.BeFalse())
+	g.Expect(SportsLeagueNHL.HasPeriod(NumberSetTypeOT)).Should(gomega.BeTrue())
+
+	// baseball is only scored at inning checkpoints
+	g.Expect(SportsLeagueMLB.HasPeriod(NumberSetTypeI3)).Should(gomega.BeTrue())
+	g.Expect(SportsLeagueMLB.HasPeriod(NumberSetTypeI7)).Should(gomega.BeTrue())
+	g.Expect(SportsLeagueMLB.HasPeriod(NumberSetTypeQ1)).Should(gomega.BeFalse())
+	g.Expect(SportsLeagueMLB.HasPeriod(NumberSetTypeHalf)).Should(gomega.BeFalse())
+	g.Expect(SportsLeagueMLB.HasPeriod(NumberSetTypeFinal)).Should(gomega.BeTrue())
+
+	g.Expect(SportsLeagueMLS.HasPeriod(NumberSetTypeHalf)).Should(gomega.BeTrue())
+	g.Expect(SportsLeagueMLS.HasPeriod(NumberSetTypeQ1)).Should(gomega.BeFalse())
+}
+
+func TestSportsLeagueHasScoringPlays(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	g.Expect(SportsLeagueNFL.HasScoringPlays()).Should(gomega.BeTrue())
+	g.Expect(SportsLeagueNCAAF.HasScoringPlays()).Should(gomega.BeTrue())
+	g.Expect(SportsLeagueNBA.HasScoringPlays()).Should(gomega.BeFalse())
+	g.Expect(SportsLeagueNHL.HasScoringPlays()).Should(gomega.BeFalse())
+	g.Expect(SportsLeagueMLB.HasScoringPlays()).Should(gomega.BeFalse())
 }
 
 // Test backward compatibility aliases
@@ -125,5 +179,5 @@ func TestBDLLeagueAliases(t *testing.T) {
 
 	// Backward compatibility functions
 	g.Expect(IsValidBDLLeague("nfl")).Should(gomega.BeTrue())
-	g.Expect(len(ValidBDLLeagues())).Should(gomega.Equal(5))
+	g.Expect(len(ValidBDLLeagues())).Should(gomega.Equal(8))
 }
@@ -0,0 +1,108 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxHoldHours is the longest a square can be reserved before it must be paid for
+const MaxHoldHours = 24 * 14
+
+// ReleasedSquare is a reserved square that was unclaimed because its hold expired
+type ReleasedSquare struct {
+	PoolToken string
+	SquareID  int
+}
+
+// HoldPeriod returns how long squares claimed by members are reserved before they must be paid for.
+// Zero means squares are claimed outright.
+func (p *Pool) HoldPeriod(ctx context.Context) (time.Duration, error) {
+	var hours int
+	row := p.model.DB.QueryRowContext(ctx, "SELECT hold_hours FROM pool_hold_settings WHERE pool_id = $1", p.id)
+	if err := row.Scan(&hours); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("loading hold period: %w", err)
+	}
+
+	return time.Duration(hours) * time.Hour, nil
+}
+
+// SetHoldPeriod will set how many hours squares claimed by members are reserved for. Zero turns holds off.
+// Squares that are already reserved keep their current hold.
+func (p *Pool) SetHoldPeriod(ctx context.Context, hours int) error {
+	if hours < 0 || hours > MaxHoldHours {
+		return fmt.Errorf("hold must be between 0 and %d hours", MaxHoldHours)
+	}
+
+	if hours == 0 {
+		if _, err := p.model.DB.ExecContext(ctx, "DELETE FROM pool_hold_settings WHERE pool_id = $1", p.id); err != nil {
+			return fmt.Errorf("removing hold period: %w", err)
+		}
+
+		return nil
+	}
+
+	const query = `
+		INSERT INTO pool_hold_settings (pool_id, hold_hours)
+		VALUES ($1, $2)
+		ON CONFLICT (pool_id) DO UPDATE
+		SET hold_hours = EXCLUDED.hold_hours,
+		    modified = (NOW() AT TIME ZONE 'utc')`
+	if _, err := p.model.DB.ExecContext(ctx, query, p.id, hours); err != nil {
+		return fmt.Errorf("saving hold period: %w", err)
+	}
+
+	return nil
+}
+
+// Reserve will mark the square as reserved. When saved in a pool with a hold period, the square is held until the
+// period expires, after which it is released unless a manager has marked it paid.
+func (p *PoolSquare) Reserve(hold time.Duration) {
+	until := time.Now().Add(hold).In(locationNewYork)
+	p.State = PoolSquareStateReserved
+	p.reservedUntil = &until
+}
+
+// ReleaseExpiredHolds will unclaim every reserved square whose hold has expired and log why it was released
+func (m *Model) ReleaseExpiredHolds(ctx context.Context) ([]ReleasedSquare, error) {
+	rows, err := m.DB.QueryContext(ctx, "SELECT pool_token, released_square_id FROM release_expired_holds()")
+	if err != nil {
+		return nil, fmt.Errorf("releasing expired holds: %w", err)
+	}
+	defer rows.Close()
+
+	released := make([]ReleasedSquare, 0)
+	for rows.Next() {
+		var r ReleasedSquare
+		if err := rows.Scan(&r.PoolToken, &r.SquareID); err != nil {
+			return nil, fmt.Errorf("scanning released square: %w", err)
+		}
+
+		released = append(released, r)
+	}
+
+	return released, rows.Err()
+}
@@ -0,0 +1,40 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func TestPoolSquareReserve(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Expect(PoolSquareStateReserved.IsValid()).Should(gomega.BeTrue())
+
+	square := &PoolSquare{State: PoolSquareStateClaimed}
+	g.Expect(square.ReservedUntil()).Should(gomega.BeNil())
+	g.Expect(square.JSON().ReservedUntil).Should(gomega.BeNil())
+
+	square.Reserve(24 * time.Hour)
+	g.Expect(square.State).Should(gomega.Equal(PoolSquareStateReserved))
+	g.Expect(*square.ReservedUntil()).Should(gomega.BeTemporally("~", time.Now().Add(24*time.Hour), time.Minute))
+	g.Expect(square.JSON().ReservedUntil).Should(gomega.Equal(square.ReservedUntil()))
+}
@@ -0,0 +1,315 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrSquareLimitReached is an error when a claim would take a user or claimant over the pool's square limits.
+// Claims that fail because of a limit return a *SquareLimitError which wraps it.
+var ErrSquareLimitReached = errors.New("square limit reached")
+
+// SquareLimitError is returned when a claim would exceed one of the pool's square limits
+type SquareLimitError struct {
+	// Claimant is the name that reached its limit. It is empty when the per-user limit was reached.
+	Claimant  string
+	Limit     int
+	Remaining int
+}
+
+// Error returns a message that can be shown to the user
+func (e *SquareLimitError) Error() string {
+	if e.Claimant != "" {
+		return fmt.Sprintf("%s may hold at most %d squares in this pool (%d remaining)", e.Claimant, e.Limit, e.Remaining)
+	}
+
+	return fmt.Sprintf("you may hold at most %d squares in this pool (%d remaining)", e.Limit, e.Remaining)
+}
+
+// Unwrap allows errors.Is(err, ErrSquareLimitReached)
+func (e *SquareLimitError) Unwrap() error {
+	return ErrSquareLimitReached
+}
+
+// PoolSquareLimits caps how many squares a single user or claimant name may hold in a pool. A limit of 0 is unlimited.
+// Only primary squares count towards the limits, so a roll100 claim counts once. A share of a square counts as a whole
+// square for its co-owner.
+type PoolSquareLimits struct {
+	model          *Model
+	poolID         int64
+	maxPerUser     int
+	maxPerClaimant int
+	modified       *time.Time
+}
+
+// PoolSquareLimitsJSON represents the square limits that can be sent to the front-end
+type PoolSquareLimitsJSON struct {
+	MaxPerUser     int `json:"maxPerUser"`
+	MaxPerClaimant int `json:"maxPerClaimant"`
+}
+
+// SquareAllowance is how many more squares a user may claim in a pool with square limits
+type SquareAllowance struct {
+	PoolSquareLimitsJSON
+	// Claimed is the number of squares held by the user
+	Claimed int `json:"claimed"`
+	// Remaining is how many more squares the user may claim. It is nil if the user is not limited.
+	Remaining *int `json:"remaining"`
+	// Claimants is how many more squares may be claimed under each name the user holds squares with
+	Claimants map[string]int `json:"claimants,omitempty"`
+}
+
+// MaxPerUser is a getter for maxPerUser
+func (l *PoolSquareLimits) MaxPerUser() int {
+	return l.maxPerUser
+}
+
+// SetMaxPerUser is a setter for maxPerUser
+func (l *PoolSquareLimits) SetMaxPerUser(limit int) {
+	if limit < 0 {
+		limit = 0
+	}
+
+	l.maxPerUser = limit
+}
+
+// MaxPerClaimant is a getter for maxPerClaimant
+func (l *PoolSquareLimits) MaxPerClaimant() int {
+	return l.maxPerClaimant
+}
+
+// SetMaxPerClaimant is a setter for maxPerClaimant
+func (l *PoolSquareLimits) SetMaxPerClaimant(limit int) {
+	if limit < 0 {
+		limit = 0
+	}
+
+	l.maxPerClaimant = limit
+}
+
+// IsLimited returns true if either limit is set
+func (l *PoolSquareLimits) IsLimited() bool {
+	return l.maxPerUser > 0 || l.maxPerClaimant > 0
+}
+
+// JSON returns the square limits that can be sent to the front-end
+func (l *PoolSquareLimits) JSON() *PoolSquareLimitsJSON {
+	return &PoolSquareLimitsJSON{
+		MaxPerUser:     l.maxPerUser,
+		MaxPerClaimant: l.maxPerClaimant,
+	}
+}
+
+// Save will save the square limits
+func (l *PoolSquareLimits) Save(ctx context.Context) error {
+	const query = `
+		INSERT INTO pool_square_limits (pool_id, max_per_user, max_per_claimant)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (pool_id) DO UPDATE
+		SET max_per_user = EXCLUDED.max_per_user,
+		    max_per_claimant = EXCLUDED.max_per_claimant,
+		    modified = (NOW() AT TIME ZONE 'utc')`
+
+	if _, err := l.model.DB.ExecContext(ctx, query, l.poolID, l.maxPerUser, l.maxPerClaimant); err != nil {
+		return fmt.Errorf("saving square limits: %w", err)
+	}
+
+	return nil
+}
+
+// SquareLimits returns the square limits of the pool. A pool without saved limits is unlimited.
+func (p *Pool) SquareLimits(ctx context.Context) (*PoolSquareLimits, error) {
+	return p.squareLimits(ctx, p.model.DB, false)
+}
+
+// squareLimits loads the limits. If lock is true, the row is locked until the transaction ends so that concurrent
+// claims in the same pool are counted one at a time.
+func (p *Pool) squareLimits(ctx context.Context, q Queryable, lock bool) (*PoolSquareLimits, error) {
+	limits := &PoolSquareLimits{
+		model:  p.model,
+		poolID: p.id,
+	}
+
+	query := "SELECT max_per_user, max_per_claimant, modified FROM pool_square_limits WHERE pool_id = $1"
+	if lock {
+		query += " FOR UPDATE"
+	}
+
+	row := q.QueryRowContext(ctx, query, p.id)
+	if err := row.Scan(&limits.maxPerUser, &limits.maxPerClaimant, &limits.modified); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return limits, nil
+		}
+
+		return nil, fmt.Errorf("loading square limits: %w", err)
+	}
+
+	return limits, nil
+}
+
+// squareHolding is the number of squares held under a claimant name
+type squareHolding struct {
+	claimant   string
+	held       int
+	heldByUser int
+}
+
+// squareHoldings returns the number of primary squares held under each claimant name, compared case-insensitively,
+// along with how many of them belong to the user. A share of a square counts as one square for its claimant, however
+// small the share, so that the limits cannot be bypassed by claiming shares.
+func (p *Pool) squareHoldings(ctx context.Context, q Queryable, userID int64) ([]squareHolding, error) {
+	const query = `
+		WITH holdings AS (
+			SELECT id AS pool_square_id, claimant, user_id
+			FROM pool_squares
+			WHERE pool_id = $1 AND
+			      parent_id IS NULL AND
+			      state <> 'unclaimed' AND
+			      user_id IS NOT NULL
+			UNION ALL
+			SELECT pool_square_shares.pool_square_id, pool_square_shares.claimant, pool_square_shares.user_id
+			FROM pool_square_shares
+			INNER JOIN pool_squares ON pool_square_shares.pool_square_id = pool_squares.id
+			WHERE pool_squares.pool_id = $1
+		)
+		SELECT MIN(claimant),
+		       COUNT(DISTINCT pool_square_id),
+		       COUNT(DISTINCT pool_square_id) FILTER (WHERE user_id = $2)
+		FROM holdings
+		GROUP BY LOWER(claimant)`
+	rows, err := q.QueryContext(ctx, query, p.id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("loading square holdings: %w", err)
+	}
+	defer rows.Close()
+
+	holdings := make([]squareHolding, 0)
+	for rows.Next() {
+		var h squareHolding
+		if err := rows.Scan(&h.claimant, &h.held, &h.heldByUser); err != nil {
+			return nil, fmt.Errorf("scanning square holding: %w", err)
+		}
+
+		holdings = append(holdings, h)
+	}
+
+	return holdings, rows.Err()
+}
+
+// CheckSquareLimits returns a *SquareLimitError if claiming count more squares would take the user or the claimant
+// over the pool's limits. The per-user limit is only checked if checkUser is true, which lets managers assign squares
+// on behalf of others. It must be called in the same transaction as the claim, as it locks the pool's limits.
+func (p *Pool) CheckSquareLimits(ctx context.Context, tx *sql.Tx, userID int64, claimant string, count int, checkUser bool) error {
+	limits, err := p.squareLimits(ctx, tx, true)
+	if err != nil {
+		return err
+	}
+
+	if !limits.IsLimited() || (!checkUser && limits.maxPerClaimant == 0) {
+		return nil
+	}
+
+	holdings, err := p.squareHoldings(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+
+	heldByUser := 0
+	heldByClaimant := 0
+	for _, h := range holdings {
+		heldByUser += h.heldByUser
+		if strings.EqualFold(h.claimant, claimant) {
+			heldByClaimant = h.held
+		}
+	}
+
+	if checkUser && limits.maxPerUser > 0 && heldByUser+count > limits.maxPerUser {
+		return &SquareLimitError{
+			Limit:     limits.maxPerUser,
+			Remaining: remainingSquares(limits.maxPerUser, heldByUser),
+		}
+	}
+
+	if limits.maxPerClaimant > 0 && heldByClaimant+count > limits.maxPerClaimant {
+		return &SquareLimitError{
+			Claimant:  claimant,
+			Limit:     limits.maxPerClaimant,
+			Remaining: remainingSquares(limits.maxPerClaimant, heldByClaimant),
+		}
+	}
+
+	return nil
+}
+
+// SquareAllowance returns how many more squares the user may claim, or nil if the pool has no square limits.
+// Users who are not limited, such as managers, get a nil Remaining.
+func (p *Pool) SquareAllowance(ctx context.Context, userID int64, limitUser bool) (*SquareAllowance, error) {
+	limits, err := p.SquareLimits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !limits.IsLimited() {
+		return nil, nil
+	}
+
+	holdings, err := p.squareHoldings(ctx, p.model.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	allowance := &SquareAllowance{
+		PoolSquareLimitsJSON: *limits.JSON(),
+	}
+
+	for _, h := range holdings {
+		if h.heldByUser == 0 {
+			continue
+		}
+
+		allowance.Claimed += h.heldByUser
+		if limits.maxPerClaimant > 0 {
+			if allowance.Claimants == nil {
+				allowance.Claimants = make(map[string]int)
+			}
+
+			allowance.Claimants[h.claimant] = remainingSquares(limits.maxPerClaimant, h.held)
+		}
+	}
+
+	if limitUser && limits.maxPerUser > 0 {
+		remaining := remainingSquares(limits.maxPerUser, allowance.Claimed)
+		allowance.Remaining = &remaining
+	}
+
+	return allowance, nil
+}
+
+func remainingSquares(limit, held int) int {
+	if held >= limit {
+		return 0
+	}
+
+	return limit - held
+}
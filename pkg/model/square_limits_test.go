@@ -0,0 +1,52 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestPoolSquareLimits(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	limits := &PoolSquareLimits{}
+	g.Expect(limits.IsLimited()).Should(gomega.BeFalse())
+
+	limits.SetMaxPerClaimant(3)
+	g.Expect(limits.IsLimited()).Should(gomega.BeTrue())
+
+	limits.SetMaxPerUser(-1)
+	g.Expect(limits.MaxPerUser()).Should(gomega.Equal(0))
+	g.Expect(limits.JSON()).Should(gomega.Equal(&PoolSquareLimitsJSON{MaxPerUser: 0, MaxPerClaimant: 3}))
+}
+
+func TestSquareLimitError(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	var err error = &SquareLimitError{Limit: 5, Remaining: 2}
+	g.Expect(errors.Is(err, ErrSquareLimitReached)).Should(gomega.BeTrue())
+	g.Expect(err.Error()).Should(gomega.Equal("you may hold at most 5 squares in this pool (2 remaining)"))
+
+	err = &SquareLimitError{Claimant: "Alice", Limit: 3, Remaining: 0}
+	g.Expect(err.Error()).Should(gomega.Equal("Alice may hold at most 3 squares in this pool (0 remaining)"))
+
+	g.Expect(remainingSquares(5, 7)).Should(gomega.Equal(0))
+}
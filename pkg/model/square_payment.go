@@ -0,0 +1,258 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PaymentReferenceMaxLength is the maximum number of characters allowed in a payment reference
+const PaymentReferenceMaxLength = 100
+
+// PaymentMethod is how a payment for a square was made
+type PaymentMethod string
+
+// constants for PaymentMethod
+const (
+	PaymentMethodCash    PaymentMethod = "cash"
+	PaymentMethodVenmo   PaymentMethod = "venmo"
+	PaymentMethodPayPal  PaymentMethod = "paypal"
+	PaymentMethodZelle   PaymentMethod = "zelle"
+	PaymentMethodCashApp PaymentMethod = "cashapp"
+	PaymentMethodCheck   PaymentMethod = "check"
+	PaymentMethodOther   PaymentMethod = "other"
+)
+
+// PaymentMethods are the valid payment methods
+var PaymentMethods = []PaymentMethod{
+	PaymentMethodCash,
+	PaymentMethodVenmo,
+	PaymentMethodPayPal,
+	PaymentMethodZelle,
+	PaymentMethodCashApp,
+	PaymentMethodCheck,
+	PaymentMethodOther,
+}
+
+// IsValid returns true if the payment method is valid
+func (p PaymentMethod) IsValid() bool {
+	for _, method := range PaymentMethods {
+		if p == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SquarePayment is a payment received for a square. Amount is in cents.
+type SquarePayment struct {
+	Amount    int64         `json:"amount"`
+	Method    PaymentMethod `json:"method"`
+	Reference string        `json:"reference,omitempty"`
+}
+
+// RecordPayment will add a log entry to the square recording a payment that was received
+func (p *PoolSquare) RecordPayment(ctx context.Context, q Queryable, payment SquarePayment, poolSquareLog PoolSquareLog) error {
+	return p.recordPayment(ctx, q, p.claimant, p.userID, p.State, payment, poolSquareLog)
+}
+
+func (p *PoolSquare) recordPayment(ctx context.Context, q Queryable, claimant string, userID int64, state PoolSquareState, payment SquarePayment, poolSquareLog PoolSquareLog) error {
+	if payment.Amount <= 0 {
+		return fmt.Errorf("payment amount must be greater than zero")
+	}
+
+	if !payment.Method.IsValid() {
+		return fmt.Errorf("invalid payment method: %s", payment.Method)
+	}
+
+	var claimantPtr *string
+	if claimant != "" {
+		claimantPtr = &claimant
+	}
+
+	var userIDPtr *int64
+	if userID > 0 {
+		userIDPtr = &userID
+	}
+
+	var remoteAddr *string
+	if poolSquareLog.RemoteAddr != "" {
+		ip := ipFromRemoteAddr(poolSquareLog.RemoteAddr)
+		remoteAddr = &ip
+	}
+
+	var reference *string
+	if payment.Reference != "" {
+		reference = &payment.Reference
+	}
+
+	const query = `
+		INSERT INTO pool_squares_logs (pool_square_id, user_id, state, claimant, note, remote_addr, payment_amount, payment_method, payment_reference)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	if _, err := q.ExecContext(ctx, query, p.ID, userIDPtr, state, claimantPtr, poolSquareLog.Note, remoteAddr, payment.Amount, payment.Method, reference); err != nil {
+		return fmt.Errorf("recording payment: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimantBalance is how much a claimant owes and has paid. All monetary values are in cents.
+// Outstanding is negative when the claimant has paid more than they owe.
+type ClaimantBalance struct {
+	Claimant string `json:"claimant"`
+	Squares  int    `json:"squares"`
+	// SharedSquares is the number of squares the claimant owns part of. Only their share is owed.
+	SharedSquares int   `json:"sharedSquares,omitempty"`
+	Owed          int64 `json:"owed"`
+	Paid          int64 `json:"paid"`
+	Outstanding   int64 `json:"outstanding"`
+}
+
+// PoolBalances is the balance summary for every claimant in a pool
+type PoolBalances struct {
+	SquarePrice int64              `json:"squarePrice"`
+	Balances    []*ClaimantBalance `json:"balances"`
+	Owed        int64              `json:"owed"`
+	Paid        int64              `json:"paid"`
+	Outstanding int64              `json:"outstanding"`
+}
+
+// Balances returns how much each claimant owes based on the square price and how much they have paid.
+// Only primary squares count towards what is owed. Co-owners of a shared square owe their share of its price.
+func (p *Pool) Balances(ctx context.Context) (*PoolBalances, error) {
+	settings, err := p.PayoutSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	squares, err := p.Squares()
+	if err != nil {
+		return nil, fmt.Errorf("loading squares: %w", err)
+	}
+
+	if err := p.LoadSquareShares(ctx); err != nil {
+		return nil, err
+	}
+
+	paid, err := p.paymentsByClaimant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return calculateBalances(settings.SquarePrice(), squares, paid), nil
+}
+
+// paymentsByClaimant returns the total amount paid by each claimant
+func (p *Pool) paymentsByClaimant(ctx context.Context) (map[string]int64, error) {
+	const query = `
+		SELECT pool_squares_logs.claimant, SUM(pool_squares_logs.payment_amount)
+		FROM pool_squares_logs
+		INNER JOIN pool_squares ON pool_squares_logs.pool_square_id = pool_squares.id
+		WHERE pool_squares.pool_id = $1
+		  AND pool_squares_logs.payment_amount IS NOT NULL
+		  AND pool_squares_logs.claimant IS NOT NULL
+		GROUP BY pool_squares_logs.claimant`
+	rows, err := p.model.DB.QueryContext(ctx, query, p.id)
+	if err != nil {
+		return nil, fmt.Errorf("loading payments: %w", err)
+	}
+	defer rows.Close()
+
+	paid := make(map[string]int64)
+	for rows.Next() {
+		var claimant string
+		var amount int64
+		if err := rows.Scan(&claimant, &amount); err != nil {
+			return nil, fmt.Errorf("scanning payment: %w", err)
+		}
+
+		paid[claimant] += amount
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating payments: %w", err)
+	}
+
+	return paid, nil
+}
+
+func calculateBalances(squarePrice int64, squares map[int]*PoolSquare, paid map[string]int64) *PoolBalances {
+	byClaimant := make(map[string]*ClaimantBalance)
+	balanceFor := func(claimant string) *ClaimantBalance {
+		b, ok := byClaimant[claimant]
+		if !ok {
+			b = &ClaimantBalance{Claimant: claimant}
+			byClaimant[claimant] = b
+		}
+
+		return b
+	}
+
+	for _, sq := range squares {
+		if sq.State == PoolSquareStateUnclaimed || sq.ParentID > 0 || sq.Claimant() == "" {
+			continue
+		}
+
+		if sq.IsShared() {
+			owed := splitByShare(squarePrice*int64(sq.OwnedShare())/ShareWhole, sq.Shares())
+			for i, share := range sq.Shares() {
+				b := balanceFor(share.Claimant())
+				b.SharedSquares++
+				b.Owed += owed[i]
+			}
+
+			continue
+		}
+
+		b := balanceFor(sq.Claimant())
+		b.Squares++
+		b.Owed += squarePrice
+	}
+
+	for claimant, amount := range paid {
+		balanceFor(claimant).Paid += amount
+	}
+
+	result := &PoolBalances{
+		SquarePrice: squarePrice,
+		Balances:    make([]*ClaimantBalance, 0, len(byClaimant)),
+	}
+
+	for _, b := range byClaimant {
+		b.Outstanding = b.Owed - b.Paid
+		result.Owed += b.Owed
+		result.Paid += b.Paid
+		result.Outstanding += b.Outstanding
+		result.Balances = append(result.Balances, b)
+	}
+
+	sort.Slice(result.Balances, func(i, j int) bool {
+		a, b := strings.ToLower(result.Balances[i].Claimant), strings.ToLower(result.Balances[j].Claimant)
+		if a == b {
+			return result.Balances[i].Claimant < result.Balances[j].Claimant
+		}
+
+		return a < b
+	})
+
+	return result
+}
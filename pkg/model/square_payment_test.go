@@ -0,0 +1,77 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestPaymentMethod(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Expect(PaymentMethodCash.IsValid()).Should(gomega.BeTrue())
+	g.Expect(PaymentMethodVenmo.IsValid()).Should(gomega.BeTrue())
+	g.Expect(PaymentMethod("bitcoin").IsValid()).Should(gomega.BeFalse())
+	g.Expect(PaymentMethod("").IsValid()).Should(gomega.BeFalse())
+}
+
+func TestCalculateBalances(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	squares := payoutTestSquares()
+	squares[52] = &PoolSquare{ID: 152, SquareID: 52, State: PoolSquareStateClaimed, claimant: "Alice"}
+
+	balances := calculateBalances(1000, squares, map[string]int64{
+		"Alice": 1500,
+		"Bob":   1000,
+		"Dave":  500, // paid for a square that was since unclaimed
+	})
+
+	g.Expect(balances.SquarePrice).Should(gomega.Equal(int64(1000)))
+	g.Expect(balances.Balances).Should(gomega.Equal([]*ClaimantBalance{
+		{Claimant: "Alice", Squares: 2, Owed: 2000, Paid: 1500, Outstanding: 500},
+		{Claimant: "Bob", Squares: 1, Owed: 1000, Paid: 1000, Outstanding: 0},
+		{Claimant: "Carol", Squares: 1, Owed: 1000, Paid: 0, Outstanding: 1000},
+		{Claimant: "Dave", Squares: 0, Owed: 0, Paid: 500, Outstanding: -500},
+	}))
+	g.Expect(balances.Owed).Should(gomega.Equal(int64(4000)))
+	g.Expect(balances.Paid).Should(gomega.Equal(int64(3000)))
+	g.Expect(balances.Outstanding).Should(gomega.Equal(int64(1000)))
+}
+
+func TestCalculateBalancesSharedSquare(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	squares := payoutTestSquares()
+	squares[49].claimant = "Bob / Dave"
+	squares[49].shares = []*PoolSquareShare{
+		{id: 1, claimant: "Bob", share: 5000, state: PoolSquareStateClaimed},
+		{id: 2, claimant: "Dave", share: 2500, state: PoolSquareStatePaidFull},
+	}
+
+	balances := calculateBalances(1000, squares, map[string]int64{"Dave": 250})
+	g.Expect(balances.Balances).Should(gomega.Equal([]*ClaimantBalance{
+		{Claimant: "Alice", Squares: 1, Owed: 1000, Paid: 0, Outstanding: 1000},
+		{Claimant: "Bob", SharedSquares: 1, Owed: 500, Paid: 0, Outstanding: 500},
+		{Claimant: "Carol", Squares: 1, Owed: 1000, Paid: 0, Outstanding: 1000},
+		{Claimant: "Dave", SharedSquares: 1, Owed: 250, Paid: 250, Outstanding: 0},
+	}))
+	g.Expect(balances.Owed).Should(gomega.Equal(int64(2750)))
+}
@@ -0,0 +1,325 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ShareWhole is the share of a square owned outright, in basis points
+const ShareWhole = PercentBasisPoints
+
+// ErrShareUnavailable is an error when a user tries to claim more of a square than is available
+var ErrShareUnavailable = errors.New("not enough of the square is available")
+
+// ErrShareNotUpdated is an error when a share does not exist or the user is not allowed to change it
+var ErrShareNotUpdated = errors.New("share could not be updated")
+
+// ErrSquareShared is an error when a manager tries to change a shared square as a whole. Shared squares are
+// changed through their shares, but may be unclaimed entirely.
+var ErrSquareShared = errors.New("square is shared")
+
+// PoolSquareShare is part of a square owned by one of its co-owners. Share is in basis points.
+type PoolSquareShare struct {
+	id           int64
+	poolSquareID int64
+	userID       int64
+	claimant     string
+	share        int
+	state        PoolSquareState
+	modified     time.Time
+}
+
+// PoolSquareShareJSON represents a share that can be sent to the front-end
+type PoolSquareShareJSON struct {
+	ID       int64           `json:"id"`
+	UserID   int64           `json:"userId"`
+	Claimant string          `json:"claimant"`
+	Share    int             `json:"share"`
+	State    PoolSquareState `json:"state"`
+	Modified time.Time       `json:"modified"`
+}
+
+// ID is a getter for id
+func (s *PoolSquareShare) ID() int64 {
+	return s.id
+}
+
+// PoolSquareID is a getter for poolSquareID
+func (s *PoolSquareShare) PoolSquareID() int64 {
+	return s.poolSquareID
+}
+
+// UserID is a getter for userID
+func (s *PoolSquareShare) UserID() int64 {
+	return s.userID
+}
+
+// Claimant is a getter for claimant
+func (s *PoolSquareShare) Claimant() string {
+	return s.claimant
+}
+
+// Share returns the portion of the square owned, in basis points
+func (s *PoolSquareShare) Share() int {
+	return s.share
+}
+
+// State is a getter for state
+func (s *PoolSquareShare) State() PoolSquareState {
+	return s.state
+}
+
+// JSON returns the share that can be sent to the front-end
+func (s *PoolSquareShare) JSON() *PoolSquareShareJSON {
+	return &PoolSquareShareJSON{
+		ID:       s.id,
+		UserID:   s.userID,
+		Claimant: s.claimant,
+		Share:    s.share,
+		State:    s.state,
+		Modified: s.modified,
+	}
+}
+
+// Shares returns the shares of the square. It is empty unless the square is shared and its shares have been loaded.
+func (p *PoolSquare) Shares() []*PoolSquareShare {
+	return p.shares
+}
+
+// IsShared returns true if the square is owned by co-owners
+func (p *PoolSquare) IsShared() bool {
+	return len(p.shares) > 0
+}
+
+// HasShare returns true if the user already holds a share of the square under the claimant name, compared
+// case-insensitively
+func (p *PoolSquare) HasShare(userID int64, claimant string) bool {
+	for _, share := range p.shares {
+		if share.userID == userID && strings.EqualFold(share.claimant, claimant) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ShareByID returns the share of the square with the given ID, or nil if it does not belong to the square
+func (p *PoolSquare) ShareByID(id int64) *PoolSquareShare {
+	for _, share := range p.shares {
+		if share.id == id {
+			return share
+		}
+	}
+
+	return nil
+}
+
+// OwnedShare returns the portion of the square that has been claimed, in basis points
+func (p *PoolSquare) OwnedShare() int {
+	if !p.IsShared() {
+		if p.State == PoolSquareStateUnclaimed {
+			return 0
+		}
+
+		return ShareWhole
+	}
+
+	owned := 0
+	for _, share := range p.shares {
+		owned += share.share
+	}
+
+	return owned
+}
+
+// AvailableShare returns the portion of the square that can still be claimed, in basis points.
+// Secondary squares cannot be claimed directly.
+func (p *PoolSquare) AvailableShare() int {
+	if p.ParentID > 0 {
+		return 0
+	}
+
+	return ShareWhole - p.OwnedShare()
+}
+
+const shareColumns = "id, pool_square_id, user_id, claimant, share, state, modified"
+
+func poolSquareShareByRow(scan scanFunc) (*PoolSquareShare, error) {
+	var s PoolSquareShare
+	var userID *int64
+	if err := scan(&s.id, &s.poolSquareID, &userID, &s.claimant, &s.share, &s.state, &s.modified); err != nil {
+		return nil, err
+	}
+
+	if userID != nil {
+		s.userID = *userID
+	}
+
+	return &s, nil
+}
+
+// LoadShares will load the shares of the square
+func (p *PoolSquare) LoadShares(ctx context.Context) error {
+	const query = "SELECT " + shareColumns + " FROM pool_square_shares WHERE pool_square_id = $1 ORDER BY id"
+	rows, err := p.Model.DB.QueryContext(ctx, query, p.ID)
+	if err != nil {
+		return fmt.Errorf("loading shares: %w", err)
+	}
+	defer rows.Close()
+
+	shares := make([]*PoolSquareShare, 0)
+	for rows.Next() {
+		share, err := poolSquareShareByRow(rows.Scan)
+		if err != nil {
+			return fmt.Errorf("scanning share: %w", err)
+		}
+
+		shares = append(shares, share)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating shares: %w", err)
+	}
+
+	p.shares = shares
+	return nil
+}
+
+// LoadSquareShares will load the shares of every square in the pool
+func (p *Pool) LoadSquareShares(ctx context.Context) error {
+	squares, err := p.Squares()
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[int64]*PoolSquare, len(squares))
+	for _, square := range squares {
+		square.shares = nil
+		byID[square.ID] = square
+	}
+
+	const query = `
+		SELECT pool_square_shares.id, pool_square_id, pool_square_shares.user_id, pool_square_shares.claimant,
+		       share, pool_square_shares.state, pool_square_shares.modified
+		FROM pool_square_shares
+		INNER JOIN pool_squares ON pool_square_shares.pool_square_id = pool_squares.id
+		WHERE pool_squares.pool_id = $1
+		ORDER BY pool_square_shares.id`
+	rows, err := p.model.DB.QueryContext(ctx, query, p.id)
+	if err != nil {
+		return fmt.Errorf("loading shares: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		share, err := poolSquareShareByRow(rows.Scan)
+		if err != nil {
+			return fmt.Errorf("scanning share: %w", err)
+		}
+
+		if square, ok := byID[share.poolSquareID]; ok {
+			square.shares = append(square.shares, share)
+		}
+	}
+
+	return rows.Err()
+}
+
+// ClaimShare will claim part of the square for the user. The share is in basis points.
+// ErrShareUnavailable is returned if not enough of the square is left to claim.
+func (p *PoolSquare) ClaimShare(ctx context.Context, q Queryable, userID int64, claimant string, share int, poolSquareLog PoolSquareLog) (*PoolSquareShare, error) {
+	if share < 1 || share > ShareWhole {
+		return nil, fmt.Errorf("share must be between 1 and %d", ShareWhole)
+	}
+
+	if utf8.RuneCountInString(claimant) > ClaimantMaxLength {
+		claimant = string([]rune(claimant)[0:ClaimantMaxLength])
+	}
+
+	var userIDPtr *int64
+	if userID > 0 {
+		userIDPtr = &userID
+	}
+
+	var remoteAddr *string
+	if poolSquareLog.RemoteAddr != "" {
+		ip := ipFromRemoteAddr(poolSquareLog.RemoteAddr)
+		remoteAddr = &ip
+	}
+
+	const query = "SELECT * FROM claim_pool_square_share($1, $2, $3, $4, $5, $6)"
+	row := q.QueryRowContext(ctx, query, p.ID, userIDPtr, claimant, share, remoteAddr, poolSquareLog.Note)
+
+	var shareID *int64
+	if err := row.Scan(&shareID); err != nil {
+		return nil, fmt.Errorf("claiming share: %w", err)
+	}
+
+	if shareID == nil {
+		return nil, ErrShareUnavailable
+	}
+
+	return &PoolSquareShare{
+		id:           *shareID,
+		poolSquareID: p.ID,
+		userID:       userID,
+		claimant:     claimant,
+		share:        share,
+		state:        PoolSquareStateClaimed,
+	}, nil
+}
+
+// UpdateShare will change the state of one of the square's shares. Setting the state to unclaimed removes the share.
+// Users who are not managers may only remove their own unpaid shares. ErrShareNotUpdated is returned otherwise.
+func (p *PoolSquare) UpdateShare(ctx context.Context, q Queryable, shareID int64, state PoolSquareState, userID int64, isManager bool, poolSquareLog PoolSquareLog) error {
+	var userIDPtr *int64
+	if userID > 0 {
+		userIDPtr = &userID
+	}
+
+	var remoteAddr *string
+	if poolSquareLog.RemoteAddr != "" {
+		ip := ipFromRemoteAddr(poolSquareLog.RemoteAddr)
+		remoteAddr = &ip
+	}
+
+	const query = "SELECT * FROM update_pool_square_share($1, $2, $3, $4, $5, $6)"
+	row := q.QueryRowContext(ctx, query, shareID, state, userIDPtr, remoteAddr, poolSquareLog.Note, isManager)
+
+	var ok bool
+	if err := row.Scan(&ok); err != nil {
+		return fmt.Errorf("updating share: %w", err)
+	}
+
+	if !ok {
+		return ErrShareNotUpdated
+	}
+
+	return nil
+}
+
+// RecordSharePayment will add a log entry to the square recording a payment received from one of its co-owners
+func (p *PoolSquare) RecordSharePayment(ctx context.Context, q Queryable, share *PoolSquareShare, payment SquarePayment, poolSquareLog PoolSquareLog) error {
+	return p.recordPayment(ctx, q, share.claimant, share.userID, share.state, payment, poolSquareLog)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestPoolSquareAvailableShare(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	square := &PoolSquare{State: PoolSquareStateUnclaimed}
+	g.Expect(square.IsShared()).Should(gomega.BeFalse())
+	g.Expect(square.OwnedShare()).Should(gomega.Equal(0))
+	g.Expect(square.AvailableShare()).Should(gomega.Equal(ShareWhole))
+
+	square.State = PoolSquareStateClaimed
+	g.Expect(square.OwnedShare()).Should(gomega.Equal(ShareWhole))
+	g.Expect(square.AvailableShare()).Should(gomega.Equal(0))
+
+	square.shares = []*PoolSquareShare{
+		{id: 7, claimant: "Alice", share: 5000, state: PoolSquareStatePaidFull},
+		{id: 8, claimant: "Bob", share: 2500, state: PoolSquareStateClaimed},
+	}
+	g.Expect(square.IsShared()).Should(gomega.BeTrue())
+	g.Expect(square.OwnedShare()).Should(gomega.Equal(7500))
+	g.Expect(square.AvailableShare()).Should(gomega.Equal(2500))
+	g.Expect(square.ShareByID(8).Claimant()).Should(gomega.Equal("Bob"))
+	g.Expect(square.ShareByID(9)).Should(gomega.BeNil())
+
+	// secondary squares cannot be claimed directly
+	g.Expect((&PoolSquare{State: PoolSquareStateUnclaimed, ParentID: 1}).AvailableShare()).Should(gomega.Equal(0))
+}
+
+func TestPoolSquareJSONShares(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	square := &PoolSquare{SquareID: 3, State: PoolSquareStateClaimed, claimant: "Alice / Bob"}
+	g.Expect(square.JSON().Shares).Should(gomega.BeNil())
+
+	square.shares = []*PoolSquareShare{
+		{id: 7, userID: 10, claimant: "Alice", share: 5000, state: PoolSquareStatePaidFull},
+		{id: 8, claimant: "Bob", share: 2500, state: PoolSquareStateClaimed},
+	}
+
+	squareJSON := square.JSON()
+	g.Expect(squareJSON.AvailableShare).Should(gomega.Equal(2500))
+	g.Expect(squareJSON.Shares).Should(gomega.HaveLen(2))
+	g.Expect(squareJSON.Shares[0].ID).Should(gomega.Equal(int64(7)))
+	g.Expect(squareJSON.Shares[0].UserID).Should(gomega.Equal(int64(10)))
+	g.Expect(squareJSON.Shares[0].Share).Should(gomega.Equal(5000))
+	g.Expect(squareJSON.Shares[0].State).Should(gomega.Equal(PoolSquareStatePaidFull))
+	g.Expect(squareJSON.Shares[1].Claimant).Should(gomega.Equal("Bob"))
+}
+
+func TestPoolSquareHasShare(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	square := &PoolSquare{
+		shares: []*PoolSquareShare{
+			{id: 7, userID: 10, claimant: "Alice", share: 5000},
+		},
+	}
+
+	g.Expect(square.HasShare(10, "alice")).Should(gomega.BeTrue())
+	g.Expect(square.HasShare(10, "Bob")).Should(gomega.BeFalse())
+	g.Expect(square.HasShare(11, "Alice")).Should(gomega.BeFalse())
+}
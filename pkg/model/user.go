@@ -113,7 +113,7 @@ func (m *Model) GetUser(ctx context.Context, issuer, storeID string) (*User, err
 	return m.userByRow(row)
 }
 
-// JoinPool will link a user to a pool.
+// JoinPool will link a user to a pool. ErrBannedFromPool is returned if the user has been banned from it.
 func (u *User) JoinPool(ctx context.Context, p *Pool) error {
 	// no-op
 	if isManager, err := u.IsManagerOf(ctx, p); err != nil {
@@ -122,9 +122,30 @@ func (u *User) JoinPool(ctx context.Context, p *Pool) error {
 		return nil
 	}
 
-	if _, err := u.DB.ExecContext(ctx, "INSERT INTO pools_users (pool_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", p.id, u.ID); err != nil {
+	const query = `
+		INSERT INTO pools_users (pool_id, user_id)
+		SELECT $1, $2
+		WHERE NOT EXISTS (SELECT 1 FROM pool_bans WHERE pool_id = $1 AND user_id = $2)
+		ON CONFLICT DO NOTHING`
+	result, err := u.DB.ExecContext(ctx, query, p.id, u.ID)
+	if err != nil {
 		return fmt.Errorf("inserting pool user: %w", err)
 	}
+
+	// nothing is inserted if the user is already a member or is banned
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("inserting pool user: %w", err)
+	} else if n == 0 {
+		banned, err := p.IsBanned(ctx, u.ID)
+		if err != nil {
+			return err
+		}
+
+		if banned {
+			return ErrBannedFromPool
+		}
+	}
+
 	return nil
 }
 
@@ -161,23 +182,15 @@ func (u *User) IsMemberOf(ctx context.Context, p *Pool) (bool, error) {
 	return ok, nil
 }
 
-// SetManagerOf will set the user as a manager in the pool. Note: this user must
-// already be a member
-func (u *User) SetManagerOf(ctx context.Context, p *Pool, isManager bool) error {
-	_, err := u.DB.ExecContext(ctx, `
-UPDATE
-    pools_users
-SET
-    is_manager = $1,
-    modified = (NOW() AT TIME ZONE 'UTC')
-WHERE
-	pool_id = $2 AND
-  	user_id = $3`, isManager, p.ID(), u.ID)
-	if err != nil {
-		return fmt.Errorf("updating manager status: %w", err)
+// SetManagerOf will set the user as a manager in the pool, recording who granted it. A manager who is unset becomes
+// a claimer. Note: this user must already be a member, otherwise ErrNotPoolMember is returned.
+func (u *User) SetManagerOf(ctx context.Context, p *Pool, isManager bool, grantedBy int64) error {
+	role := PoolRoleClaimer
+	if isManager {
+		role = PoolRoleManager
 	}
 
-	return nil
+	return u.SetPoolRole(ctx, p, role, grantedBy)
 }
 
 // HasManagerVisibility returns true if the user should see manager-level details
@@ -70,15 +70,28 @@ func TestJoinGrid(t *testing.T) {
 	g.Expect(err).Should(gomega.Succeed())
 	g.Expect(isManagerOf).Should(gomega.BeFalse())
 
-	u2.SetManagerOf(context.Background(), pool, true)
+	g.Expect(u2.SetManagerOf(context.Background(), pool, true, u.ID)).Should(gomega.Succeed())
 	isManagerOf, err = u2.IsManagerOf(context.Background(), pool)
 	g.Expect(err).Should(gomega.Succeed())
 	g.Expect(isManagerOf).Should(gomega.BeTrue())
 
-	u2.SetManagerOf(context.Background(), pool, false)
+	managers, err := pool.Managers(context.Background())
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(managers).Should(gomega.HaveLen(2))
+	g.Expect(managers[0].UserID).Should(gomega.Equal(u.ID))
+	g.Expect(managers[0].IsOwner).Should(gomega.BeTrue())
+	g.Expect(managers[1].UserID).Should(gomega.Equal(u2.ID))
+	g.Expect(managers[1].GrantedBy).Should(gomega.Equal(&u.ID))
+	g.Expect(managers[1].GrantedAt).ShouldNot(gomega.BeNil())
+
+	g.Expect(u2.SetManagerOf(context.Background(), pool, false, u.ID)).Should(gomega.Succeed())
 	isManagerOf, err = u2.IsManagerOf(context.Background(), pool)
 	g.Expect(err).Should(gomega.Succeed())
 	g.Expect(isManagerOf).Should(gomega.BeFalse())
+
+	u3, err := m.GetUser(context.Background(), IssuerSqMGR, randString())
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(u3.SetManagerOf(context.Background(), pool, true, u.ID)).Should(gomega.Equal(ErrNotPoolMember))
 }
 
 func TestGetUserByID(t *testing.T) {
@@ -0,0 +1,273 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// UserWinning is a period of a grid that was won by one of the user's squares
+type UserWinning struct {
+	WinningPeriodInfo
+	PoolToken string    `json:"poolToken"`
+	PoolName  string    `json:"poolName"`
+	GridID    int64     `json:"gridId"`
+	GridName  string    `json:"gridName"`
+	EventDate time.Time `json:"eventDate"`
+	SquareID  int       `json:"squareId"`
+	Claimant  string    `json:"claimant"`
+	// Event is the linked sports event with its final (or current) score and teams
+	Event *SportsEventJSON `json:"event"`
+	// Amount is the payout in cents. It is only set if the pool has payouts configured.
+	Amount int64 `json:"amount,omitempty"`
+	// Share is the portion of a shared square owned by the user, in basis points
+	Share int `json:"share,omitempty"`
+}
+
+// UserWinnings is every period the user has won across all of their pools
+type UserWinnings struct {
+	Winnings []*UserWinning `json:"winnings"`
+	Total    int64          `json:"total"`
+}
+
+// WinningsByUserID returns every grid period won by a square the user claimed in a pool they own or belong to.
+// The most recent events are returned first.
+func (m *Model) WinningsByUserID(ctx context.Context, userID int64) (*UserWinnings, error) {
+	// owners are not linked to their pools through pools_users
+	const query = `
+		SELECT ` + poolColumns + `
+		FROM pools
+		LEFT JOIN pools_users ON pools.id = pools_users.pool_id AND pools_users.user_id = $1
+		WHERE (pools.user_id = $1 OR pools_users.user_id IS NOT NULL)
+		  AND EXISTS (
+		      SELECT 1
+		      FROM pool_squares
+		      WHERE pool_squares.pool_id = pools.id
+		        AND pool_squares.user_id = $1
+		        AND pool_squares.state <> 'unclaimed'
+		      UNION ALL
+		      SELECT 1
+		      FROM pool_square_shares
+		      INNER JOIN pool_squares ON pool_square_shares.pool_square_id = pool_squares.id
+		      WHERE pool_squares.pool_id = pools.id
+		        AND pool_square_shares.user_id = $1
+		  )
+		ORDER BY pools.id DESC`
+
+	pools, err := m.poolsByRows(m.DB.QueryContext(ctx, query, userID))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &UserWinnings{
+		Winnings: make([]*UserWinning, 0),
+	}
+
+	for _, pool := range pools {
+		winnings, err := pool.userWinnings(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("calculating winnings for pool %d: %w", pool.ID(), err)
+		}
+
+		for _, winning := range winnings {
+			result.Winnings = append(result.Winnings, winning)
+			result.Total += winning.Amount
+		}
+	}
+
+	sort.SliceStable(result.Winnings, func(i, j int) bool {
+		return result.Winnings[i].EventDate.After(result.Winnings[j].EventDate)
+	})
+
+	return result, nil
+}
+
+// userWinnings returns the periods won by the user's squares for every grid in the pool linked to a sports event
+func (p *Pool) userWinnings(ctx context.Context, userID int64) ([]*UserWinning, error) {
+	settings, err := p.PayoutSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	squares, err := p.Squares()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.LoadSquareShares(ctx); err != nil {
+		return nil, err
+	}
+
+	grids, err := p.Grids(ctx, 0, MaxGridsPerPool)
+	if err != nil {
+		return nil, err
+	}
+
+	gridPot := settings.GridPot(squares, int64(len(grids)))
+	winnings := make([]*UserWinning, 0)
+	for _, grid := range grids {
+		if err := grid.LoadBDLEvent(ctx); err != nil {
+			return nil, err
+		}
+
+		if grid.BDLEvent() == nil {
+			continue
+		}
+
+		config := grid.EffectiveNumberSetConfig(p.numberSetConfig)
+		if err := grid.loadWinnerData(ctx, config); err != nil {
+			return nil, err
+		}
+
+		var payouts []*Payout
+		if settings.IsConfigured() {
+			payouts = settings.GridPayouts(grid, config, p.gridType, squares, gridPot)
+		}
+
+		for _, winning := range userGridWinnings(grid, config, p.gridType, squares, userID, payouts) {
+			winning.PoolToken = p.Token()
+			winning.PoolName = p.Name()
+			winnings = append(winnings, winning)
+		}
+	}
+
+	return winnings, nil
+}
+
+// winningKey identifies a single win of a square
+type winningKey struct {
+	period    NumberSetType
+	squareID  int
+	homeScore int
+	awayScore int
+}
+
+// userGridWinnings returns the periods of the grid won by squares claimed by the user. If the config pays on
+// every score change, each score change won is returned instead. If payouts are provided, the amount won is included.
+// For a shared square, only the user's share of the amount is included.
+func userGridWinnings(grid *Grid, config NumberSetConfig, gridType GridType, squares map[int]*PoolSquare, userID int64, payouts []*Payout) []*UserWinning {
+	event := grid.BDLEvent()
+	if event == nil {
+		return nil
+	}
+
+	amounts := make(map[winningKey]int64)
+	for _, payout := range payouts {
+		if payout.Refund {
+			continue
+		}
+
+		amount := payout.Amount
+		if payout.Shares != nil {
+			amount = 0
+			for _, share := range payout.Shares {
+				if share.UserID == userID {
+					amount += share.Amount
+				}
+			}
+		}
+
+		amounts[winningKey{payout.Period, payout.SquareID, payout.HomeScore, payout.AwayScore}] += amount
+	}
+
+	isUserSquare := make(map[int]bool)
+	userShare := make(map[int]int)
+	squareIDs := make([]int, 0)
+	for squareID, square := range squares {
+		if square.IsShared() {
+			for _, share := range square.Shares() {
+				if share.UserID() == userID {
+					userShare[squareID] += share.Share()
+				}
+			}
+
+			if userShare[squareID] > 0 {
+				isUserSquare[squareID] = true
+				squareIDs = append(squareIDs, squareID)
+			}
+
+			continue
+		}
+
+		if square.UserID() == userID && square.State != PoolSquareStateUnclaimed {
+			isUserSquare[squareID] = true
+			squareIDs = append(squareIDs, squareID)
+		}
+	}
+	sort.Ints(squareIDs)
+
+	// prefer the team names from the linked event over the names entered for the grid
+	homeTeamName, awayTeamName := grid.HomeTeamName(), grid.AwayTeamName()
+	if event.HomeTeam() != nil {
+		homeTeamName = event.HomeTeam().FullName
+	}
+	if event.AwayTeam() != nil {
+		awayTeamName = event.AwayTeam().FullName
+	}
+
+	eventJSON := event.JSON()
+	winnings := make([]*UserWinning, 0)
+	addWinning := func(squareID int, info WinningPeriodInfo) {
+		winnings = append(winnings, &UserWinning{
+			WinningPeriodInfo: info,
+			GridID:            grid.ID(),
+			GridName:          grid.Name(),
+			EventDate:         grid.EventDate(),
+			SquareID:          squareID,
+			Claimant:          squares[squareID].Claimant(),
+			Event:             eventJSON,
+			Amount:            amounts[winningKey{info.Period, squareID, info.HomeScore, info.AwayScore}],
+			Share:             userShare[squareID],
+		})
+	}
+
+	if config == NumberSetConfigEvery {
+		for _, winner := range grid.GetGridScoreChangeWinners(event, gridType) {
+			if !isUserSquare[winner.SquareID] {
+				continue
+			}
+
+			addWinning(winner.SquareID, WinningPeriodInfo{
+				Period:       NumberSetTypeAll,
+				Label:        scoreChangeLabel,
+				HomeScore:    winner.HomeScore,
+				AwayScore:    winner.AwayScore,
+				HomeTeamName: homeTeamName,
+				AwayTeamName: awayTeamName,
+			})
+		}
+
+		return winnings
+	}
+
+	winningSquares := grid.GetGridWinningSquares(event, config, gridType)
+	for _, squareID := range squareIDs {
+		for _, info := range GetWinningPeriodsForSquare(squareID, winningSquares, event, homeTeamName, awayTeamName) {
+			addWinning(squareID, info)
+		}
+	}
+
+	sort.SliceStable(winnings, func(i, j int) bool {
+		return numberSetTypeOrder[winnings[i].Period] < numberSetTypeOrder[winnings[j].Period]
+	})
+
+	return winnings
+}
@@ -0,0 +1,125 @@
+/*
+Copyright (C) 2019 Tom Peters
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestUserGridWinnings(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	grid := payoutTestGrid()
+	squares := payoutTestSquares()
+
+	// Alice (user 10) won the half with square 5
+	winnings := userGridWinnings(grid, NumberSetConfigHF, GridTypeStd100, squares, 10, nil)
+	g.Expect(winnings).Should(gomega.HaveLen(1))
+	g.Expect(winnings[0].Period).Should(gomega.Equal(NumberSetTypeHalf))
+	g.Expect(winnings[0].HomeScore).Should(gomega.Equal(14))
+	g.Expect(winnings[0].AwayScore).Should(gomega.Equal(10))
+	g.Expect(winnings[0].GridID).Should(gomega.Equal(int64(5)))
+	g.Expect(winnings[0].SquareID).Should(gomega.Equal(5))
+	g.Expect(winnings[0].Claimant).Should(gomega.Equal("Alice"))
+	g.Expect(winnings[0].Event).ShouldNot(gomega.BeNil())
+	g.Expect(winnings[0].Amount).Should(gomega.Equal(int64(0)))
+
+	// Bob (user 20) won the final with square 49
+	winnings = userGridWinnings(grid, NumberSetConfigHF, GridTypeStd100, squares, 20, []*Payout{
+		{WinningPeriodInfo: WinningPeriodInfo{Period: NumberSetTypeHalf, HomeScore: 14, AwayScore: 10}, SquareID: 5, Amount: 2500},
+		{WinningPeriodInfo: WinningPeriodInfo{Period: NumberSetTypeFinal, HomeScore: 28, AwayScore: 24}, SquareID: 49, Amount: 5000},
+	})
+	g.Expect(winnings).Should(gomega.HaveLen(1))
+	g.Expect(winnings[0].Period).Should(gomega.Equal(NumberSetTypeFinal))
+	g.Expect(winnings[0].Amount).Should(gomega.Equal(int64(5000)))
+
+	// a user without any winning squares
+	g.Expect(userGridWinnings(grid, NumberSetConfigHF, GridTypeStd100, squares, 30, nil)).Should(gomega.BeEmpty())
+
+	// no event linked
+	g.Expect(userGridWinnings(&Grid{}, NumberSetConfigHF, GridTypeStd100, squares, 10, nil)).Should(gomega.BeNil())
+}
+
+func TestUserGridWinningsIgnoresUnclaimedSquares(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	squares := payoutTestSquares()
+	squares[5].State = PoolSquareStateUnclaimed
+
+	g.Expect(userGridWinnings(payoutTestGrid(), NumberSetConfigHF, GridTypeStd100, squares, 10, nil)).Should(gomega.BeEmpty())
+}
+
+func TestUserGridWinningsEveryScore(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	nums := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	grid := &Grid{
+		id: 5,
+		numberSets: map[NumberSetType]*GridNumberSet{
+			NumberSetTypeAll: {homeNumbers: nums, awayNumbers: nums},
+		},
+	}
+	event := &BDLEvent{Status: BDLEventStatusFinal}
+	event.SetScoreHistory([]*SportsEventScore{
+		{HomeScore: 4, AwayScore: 0},  // square 5, Alice
+		{HomeScore: 8, AwayScore: 4},  // square 49, Bob
+		{HomeScore: 14, AwayScore: 4}, // square 45, unclaimed
+		{HomeScore: 14, AwayScore: 10},
+	})
+	grid.SetBDLEvent(event)
+
+	winnings := userGridWinnings(grid, NumberSetConfigEvery, GridTypeStd100, payoutTestSquares(), 10, []*Payout{
+		{WinningPeriodInfo: WinningPeriodInfo{Period: NumberSetTypeAll, HomeScore: 4, AwayScore: 0}, SquareID: 5, Amount: 500},
+		{WinningPeriodInfo: WinningPeriodInfo{Period: NumberSetTypeAll, HomeScore: 14, AwayScore: 10}, SquareID: 5, Amount: 500},
+	})
+	g.Expect(winnings).Should(gomega.HaveLen(2))
+	g.Expect(winnings[0].Label).Should(gomega.Equal("Score Change"))
+	g.Expect(winnings[0].HomeScore).Should(gomega.Equal(4))
+	g.Expect(winnings[0].Amount).Should(gomega.Equal(int64(500)))
+	g.Expect(winnings[1].HomeScore).Should(gomega.Equal(14))
+	g.Expect(winnings[1].AwayScore).Should(gomega.Equal(10))
+}
+
+func TestUserGridWinningsSharedSquare(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	grid := payoutTestGrid()
+	squares := payoutTestSquares()
+	squares[49].claimant = "Bob / Dave"
+	squares[49].userID = 0
+	squares[49].shares = []*PoolSquareShare{
+		{id: 1, claimant: "Bob", userID: 20, share: 5000, state: PoolSquareStateClaimed},
+		{id: 2, claimant: "Dave", userID: 40, share: 5000, state: PoolSquareStateClaimed},
+	}
+
+	payouts := []*Payout{
+		{WinningPeriodInfo: WinningPeriodInfo{Period: NumberSetTypeFinal, HomeScore: 28, AwayScore: 24}, SquareID: 49, Amount: 5000, Shares: []*PayoutShare{
+			{Claimant: "Bob", UserID: 20, Share: 5000, Amount: 2500},
+			{Claimant: "Dave", UserID: 40, Share: 5000, Amount: 2500},
+		}},
+	}
+
+	// Dave (user 40) co-owns the final winner
+	winnings := userGridWinnings(grid, NumberSetConfigHF, GridTypeStd100, squares, 40, payouts)
+	g.Expect(winnings).Should(gomega.HaveLen(1))
+	g.Expect(winnings[0].Period).Should(gomega.Equal(NumberSetTypeFinal))
+	g.Expect(winnings[0].Amount).Should(gomega.Equal(int64(2500)))
+	g.Expect(winnings[0].Share).Should(gomega.Equal(5000))
+}
@@ -48,26 +48,92 @@ func CalculateWinningSquare(homeScore, awayScore int, homeNumbers, awayNumbers [
 		return 0
 	}
 
-	// Convert position to square ID based on grid type
-	switch gridType {
-	case GridTypeStd25:
-		// 5x5 grid: each square covers 2x2 positions
-		homePos5 := homePos / 2
-		awayPos5 := awayPos / 2
-		return (awayPos5 * 5) + homePos5 + 1
-	case GridTypeStd50:
-		// 10x5 grid: each square covers 1 away position, 2 home positions
-		homePos5 := homePos / 2
-		return (awayPos * 5) + homePos5 + 1
-	default:
-		// 10x10 grid (std100, roll100)
-		return (awayPos * 10) + homePos + 1
-	}
+	// Convert position to square ID based on the layout of the grid type
+	return gridType.SquareID(homePos, awayPos)
+}
+
+// numberSetTypeOrder defines the order periods are sorted in when reporting winners
+var numberSetTypeOrder = map[NumberSetType]int{
+	NumberSetTypeQ1:    1,
+	NumberSetTypeHalf:  2,
+	NumberSetTypeQ2:    3,
+	NumberSetTypeQ3:    4,
+	NumberSetTypeI3:    5,
+	NumberSetTypeI5:    6,
+	NumberSetTypeI7:    7,
+	NumberSetTypeFinal: 8,
+	NumberSetTypeAll:   9,
+	NumberSetTypeQ4:    10,
+	NumberSetTypeOT:    11,
+}
+
+// RolloverReason describes why a period's winnings were carried forward
+type RolloverReason string
+
+// constants for RolloverReason
+const (
+	// RolloverReasonUnclaimed means the winning square was not claimed
+	RolloverReasonUnclaimed RolloverReason = "unclaimed"
+	// RolloverReasonNoWinner means no winning square could be determined for the period
+	RolloverReasonNoWinner RolloverReason = "noWinner"
+)
+
+// RolloverInfo describes a completed period whose winnings carry forward to the next period.
+// To is empty when the final period of the grid was not won.
+type RolloverInfo struct {
+	From   NumberSetType  `json:"from"`
+	To     NumberSetType  `json:"to,omitempty"`
+	Reason RolloverReason `json:"reason"`
 }
 
 // WinningSquaresResult contains the winning squares for each applicable period
 type WinningSquaresResult struct {
-	Squares map[NumberSetType]int `json:"squares"`
+	Squares   map[NumberSetType]int `json:"squares"`
+	Rollovers []RolloverInfo        `json:"rollovers,omitempty"`
+
+	overtime OvertimeMode
+}
+
+// ApplyRollover records every completed period that either has no winner or whose winning square is unclaimed.
+// The winnings of those periods carry forward to the next period. setTypes must be in the order the periods are played.
+func (r *WinningSquaresResult) ApplyRollover(event *SportsEvent, setTypes []NumberSetType, squares map[int]*PoolSquare) {
+	r.Rollovers = nil
+	if event == nil {
+		return
+	}
+
+	for i, setType := range setTypes {
+		if !event.IsPeriodCompleteWithOvertime(setType, r.overtime) {
+			break
+		}
+
+		var reason RolloverReason
+		if squareID, ok := r.Squares[setType]; !ok {
+			reason = RolloverReasonNoWinner
+		} else if square, ok := squares[squareID]; !ok || square.State == PoolSquareStateUnclaimed {
+			reason = RolloverReasonUnclaimed
+		} else {
+			continue
+		}
+
+		var to NumberSetType
+		if i+1 < len(setTypes) {
+			to = setTypes[i+1]
+		}
+
+		r.Rollovers = append(r.Rollovers, RolloverInfo{From: setType, To: to, Reason: reason})
+	}
+}
+
+// IsRolledOver returns true if the winnings of the period carried forward
+func (r *WinningSquaresResult) IsRolledOver(setType NumberSetType) bool {
+	for _, rollover := range r.Rollovers {
+		if rollover.From == setType {
+			return true
+		}
+	}
+
+	return false
 }
 
 // WinningPeriodInfo contains information about a winning period for a square
@@ -84,26 +150,38 @@ type WinningPeriodInfo struct {
 // based on the grid's number configuration and the event's scores
 // Only returns winning squares for periods that are complete
 func GetWinningSquares(event *BDLEvent, config NumberSetConfig, gridType GridType, homeNumbers, awayNumbers []int, numberSets map[NumberSetType]*GridNumberSet) *WinningSquaresResult {
+	return GetWinningSquaresWithOvertime(event, config, OvertimeModeInclude, gridType, homeNumbers, awayNumbers, numberSets)
+}
+
+// GetWinningSquaresWithOvertime returns winning squares for each applicable period of a grid with the given
+// overtime mode. When overtime is paid separately, it is scored with the numbers of the last period of regulation.
+func GetWinningSquaresWithOvertime(event *BDLEvent, config NumberSetConfig, overtime OvertimeMode, gridType GridType, homeNumbers, awayNumbers []int, numberSets map[NumberSetType]*GridNumberSet) *WinningSquaresResult {
 	result := &WinningSquaresResult{
-		Squares: make(map[NumberSetType]int),
+		Squares:  make(map[NumberSetType]int),
+		overtime: overtime,
 	}
 
-	setTypes := GetSetTypes(config)
+	setTypes := SetTypesWithOvertime(config, overtime)
 	if setTypes == nil {
 		return result
 	}
 
 	for _, setType := range setTypes {
 		// Only include winning squares for completed periods
-		if !event.IsPeriodComplete(setType) {
+		if !event.IsPeriodCompleteWithOvertime(setType, overtime) {
 			continue
 		}
 
-		homeScore, awayScore := event.ScoreForPeriod(setType)
+		homeScore, awayScore := event.ScoreForPeriodWithOvertime(setType, overtime)
 		if homeScore == nil || awayScore == nil {
 			continue // Score not available yet
 		}
 
+		numbersSetType := setType
+		if setType == NumberSetTypeOT {
+			numbersSetType = setTypes[len(setTypes)-2]
+		}
+
 		var homeNums, awayNums []int
 
 		// For standard config, use the legacy homeNumbers/awayNumbers
@@ -112,7 +190,7 @@ func GetWinningSquares(event *BDLEvent, config NumberSetConfig, gridType GridTyp
 			awayNums = awayNumbers
 		} else {
 			// For multi-set configs, try to use the appropriate number set
-			ns, ok := numberSets[setType]
+			ns, ok := numberSets[numbersSetType]
 			if ok && ns.HasNumbers() {
 				homeNums = ns.HomeNumbers()
 				awayNums = ns.AwayNumbers()
@@ -139,7 +217,7 @@ func GetWinningSquares(event *BDLEvent, config NumberSetConfig, gridType GridTyp
 
 // GetGridWinningSquares is a convenience method that calculates winning squares for a grid
 func (g *Grid) GetGridWinningSquares(event *BDLEvent, config NumberSetConfig, gridType GridType) *WinningSquaresResult {
-	return GetWinningSquares(event, config, gridType, g.HomeNumbers(), g.AwayNumbers(), g.NumberSets())
+	return GetWinningSquaresWithOvertime(event, config, g.OvertimeMode(), gridType, g.HomeNumbers(), g.AwayNumbers(), g.NumberSets())
 }
 
 // GetWinningPeriodsForSquare returns the winning period information for a specific square.
@@ -149,22 +227,11 @@ func GetWinningPeriodsForSquare(squareID int, winningSquares *WinningSquaresResu
 		return nil
 	}
 
-	// Define the order for sorting periods
-	periodOrder := map[NumberSetType]int{
-		NumberSetTypeQ1:    1,
-		NumberSetTypeHalf:  2,
-		NumberSetTypeQ2:    3,
-		NumberSetTypeQ3:    4,
-		NumberSetTypeFinal: 5,
-		NumberSetTypeAll:   6,
-		NumberSetTypeQ4:    7,
-	}
-
 	var results []WinningPeriodInfo
 
 	for period, winnerSquareID := range winningSquares.Squares {
 		if winnerSquareID == squareID {
-			homeScore, awayScore := event.ScoreForPeriod(period)
+			homeScore, awayScore := event.ScoreForPeriodWithOvertime(period, winningSquares.overtime)
 			if homeScore != nil && awayScore != nil {
 				results = append(results, WinningPeriodInfo{
 					Period:       period,
@@ -181,7 +248,7 @@ func GetWinningPeriodsForSquare(squareID int, winningSquares *WinningSquaresResu
 	// Sort results by period order
 	for i := 0; i < len(results)-1; i++ {
 		for j := i + 1; j < len(results); j++ {
-			if periodOrder[results[i].Period] > periodOrder[results[j].Period] {
+			if numberSetTypeOrder[results[i].Period] > numberSetTypeOrder[results[j].Period] {
 				results[i], results[j] = results[j], results[i]
 			}
 		}
@@ -189,3 +256,50 @@ func GetWinningPeriodsForSquare(squareID int, winningSquares *WinningSquaresResu
 
 	return results
 }
+
+// scoreChangeLabel is the label used for the winning period of a score change
+const scoreChangeLabel = "Score Change"
+
+// ScoreChangeWinner is the winning square for a single score change of an event
+type ScoreChangeWinner struct {
+	SportsEventScore
+	SquareID int `json:"squareId"`
+}
+
+// GetScoreChangeWinners returns the winning square for each score in the event's score history
+func GetScoreChangeWinners(scores []*SportsEventScore, gridType GridType, homeNumbers, awayNumbers []int) []ScoreChangeWinner {
+	if len(homeNumbers) != 10 || len(awayNumbers) != 10 {
+		return nil
+	}
+
+	winners := make([]ScoreChangeWinner, 0, len(scores))
+	for _, score := range scores {
+		squareID := CalculateWinningSquare(score.HomeScore, score.AwayScore, homeNumbers, awayNumbers, gridType)
+		if squareID == 0 {
+			continue
+		}
+
+		winners = append(winners, ScoreChangeWinner{
+			SportsEventScore: *score,
+			SquareID:         squareID,
+		})
+	}
+
+	return winners
+}
+
+// GetGridScoreChangeWinners is a convenience method that calculates the winning square of every score change
+// for a grid. The event must have its score history loaded.
+func (g *Grid) GetGridScoreChangeWinners(event *BDLEvent, gridType GridType) []ScoreChangeWinner {
+	if event == nil {
+		return nil
+	}
+
+	homeNums, awayNums := g.HomeNumbers(), g.AwayNumbers()
+	if ns, ok := g.NumberSets()[NumberSetTypeAll]; ok && ns.HasNumbers() {
+		homeNums = ns.HomeNumbers()
+		awayNums = ns.AwayNumbers()
+	}
+
+	return GetScoreChangeWinners(event.ScoreHistory(), gridType, homeNums, awayNums)
+}
@@ -649,3 +649,224 @@ func TestGetWinningPeriodsForSquareNilInputs(t *testing.T) {
 	results = GetWinningPeriodsForSquare(1, winningSquares, nil, "HOU", "IND")
 	g.Expect(results).Should(gomega.BeNil())
 }
+
+func TestWinningSquaresResultApplyRollover(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	homeQ1 := 7
+	awayQ1 := 3
+	event := &BDLEvent{
+		Status: BDLEventStatusInProgress,
+		Period: intPtr(2),
+		HomeQ1: &homeQ1,
+		AwayQ1: &awayQ1,
+	}
+
+	squares := map[int]*PoolSquare{
+		8: {SquareID: 8, State: PoolSquareStateClaimed},
+	}
+
+	setTypes := GetSetTypes(NumberSetConfig123F)
+
+	// q1 has a claimed winner, nothing rolls over
+	result := &WinningSquaresResult{Squares: map[NumberSetType]int{NumberSetTypeQ1: 8}}
+	result.ApplyRollover(event, setTypes, squares)
+	g.Expect(result.Rollovers).Should(gomega.BeEmpty())
+
+	// q1 winner is unclaimed
+	result = &WinningSquaresResult{Squares: map[NumberSetType]int{NumberSetTypeQ1: 38}}
+	result.ApplyRollover(event, setTypes, squares)
+	g.Expect(result.Rollovers).Should(gomega.Equal([]RolloverInfo{
+		{From: NumberSetTypeQ1, To: NumberSetTypeQ2, Reason: RolloverReasonUnclaimed},
+	}))
+	g.Expect(result.IsRolledOver(NumberSetTypeQ1)).Should(gomega.BeTrue())
+	g.Expect(result.IsRolledOver(NumberSetTypeQ2)).Should(gomega.BeFalse())
+
+	// game is over, no numbers were drawn so there are no winners
+	event.Status = BDLEventStatusFinal
+	result = &WinningSquaresResult{Squares: map[NumberSetType]int{}}
+	result.ApplyRollover(event, setTypes, squares)
+	g.Expect(result.Rollovers).Should(gomega.HaveLen(4))
+	g.Expect(result.Rollovers[3]).Should(gomega.Equal(RolloverInfo{From: NumberSetTypeFinal, Reason: RolloverReasonNoWinner}))
+}
+
+func TestGetScoreChangeWinners(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	nums := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	scores := []*SportsEventScore{
+		{HomeScore: 7, AwayScore: 0},
+		{HomeScore: 7, AwayScore: 3},
+		{HomeScore: 14, AwayScore: 3},
+	}
+
+	winners := GetScoreChangeWinners(scores, GridTypeStd100, nums, nums)
+	g.Expect(winners).Should(gomega.HaveLen(3))
+	g.Expect(winners[0].SquareID).Should(gomega.Equal(8))
+	g.Expect(winners[1].SquareID).Should(gomega.Equal(38))
+	g.Expect(winners[2].SquareID).Should(gomega.Equal(35))
+	g.Expect(winners[2].HomeScore).Should(gomega.Equal(14))
+
+	// numbers not drawn
+	g.Expect(GetScoreChangeWinners(scores, GridTypeStd100, nil, nil)).Should(gomega.BeNil())
+}
+
+func TestGridGetGridScoreChangeWinnersUsesAllNumberSet(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	reversed := []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}
+	grid := &Grid{
+		homeNumbers: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		awayNumbers: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		numberSets: map[NumberSetType]*GridNumberSet{
+			NumberSetTypeAll: {homeNumbers: reversed, awayNumbers: reversed},
+		},
+	}
+
+	event := &BDLEvent{}
+	event.SetScoreHistory([]*SportsEventScore{{HomeScore: 7, AwayScore: 0}})
+
+	winners := grid.GetGridScoreChangeWinners(event, GridTypeStd100)
+	g.Expect(winners).Should(gomega.HaveLen(1))
+	// home 7 is at position 2 and away 0 is at position 9
+	g.Expect(winners[0].SquareID).Should(gomega.Equal(93))
+
+	g.Expect(grid.GetGridScoreChangeWinners(nil, GridTypeStd100)).Should(gomega.BeNil())
+}
+
+func TestGetWinningSquares1234AndH4Configs(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// regulation ends 24-21, the game is won 30-21 in overtime
+	event := &BDLEvent{
+		League:    SportsLeagueNFL,
+		Status:    BDLEventStatusFinal,
+		HomeQ1:    intPtr(7),
+		HomeQ2:    intPtr(7),
+		HomeQ3:    intPtr(3),
+		HomeQ4:    intPtr(7),
+		HomeOT:    intPtr(6),
+		HomeScore: intPtr(30),
+		AwayQ1:    intPtr(0),
+		AwayQ2:    intPtr(7),
+		AwayQ3:    intPtr(7),
+		AwayQ4:    intPtr(7),
+		AwayOT:    intPtr(0),
+		AwayScore: intPtr(21),
+	}
+
+	nums := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	numberSets := map[NumberSetType]*GridNumberSet{
+		NumberSetTypeQ1:   {homeNumbers: nums, awayNumbers: nums},
+		NumberSetTypeQ2:   {homeNumbers: nums, awayNumbers: nums},
+		NumberSetTypeQ3:   {homeNumbers: nums, awayNumbers: nums},
+		NumberSetTypeQ4:   {homeNumbers: nums, awayNumbers: nums},
+		NumberSetTypeHalf: {homeNumbers: nums, awayNumbers: nums},
+	}
+
+	result := GetWinningSquares(event, NumberSetConfig1234, GridTypeStd100, nil, nil, numberSets)
+	g.Expect(result.Squares).Should(gomega.Equal(map[NumberSetType]int{
+		NumberSetTypeQ1: 8,  // 7-0
+		NumberSetTypeQ2: 75, // 14-7
+		NumberSetTypeQ3: 48, // 17-14
+		NumberSetTypeQ4: 15, // 24-21 at the end of regulation
+	}))
+
+	result = GetWinningSquares(event, NumberSetConfigH4, GridTypeStd100, nil, nil, numberSets)
+	g.Expect(result.Squares).Should(gomega.Equal(map[NumberSetType]int{
+		NumberSetTypeHalf: 75,
+		NumberSetTypeQ4:   15,
+	}))
+}
+
+func TestGetWinningSquaresWithOvertime(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// regulation ends 24-21, the game is won 30-21 in overtime
+	event := &BDLEvent{
+		League:    SportsLeagueNFL,
+		Status:    BDLEventStatusFinal,
+		Period:    intPtr(5),
+		HomeQ1:    intPtr(7),
+		HomeQ2:    intPtr(7),
+		HomeQ3:    intPtr(3),
+		HomeQ4:    intPtr(7),
+		HomeOT:    intPtr(6),
+		HomeScore: intPtr(30),
+		AwayQ1:    intPtr(0),
+		AwayQ2:    intPtr(7),
+		AwayQ3:    intPtr(7),
+		AwayQ4:    intPtr(7),
+		AwayOT:    intPtr(0),
+		AwayScore: intPtr(21),
+	}
+
+	nums := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	numberSets := map[NumberSetType]*GridNumberSet{
+		NumberSetTypeHalf:  {homeNumbers: nums, awayNumbers: nums},
+		NumberSetTypeFinal: {homeNumbers: nums, awayNumbers: nums},
+	}
+
+	result := GetWinningSquaresWithOvertime(event, NumberSetConfigHF, OvertimeModeInclude, GridTypeStd100, nil, nil, numberSets)
+	g.Expect(result.Squares).Should(gomega.Equal(map[NumberSetType]int{
+		NumberSetTypeHalf:  75, // 14-7
+		NumberSetTypeFinal: 11, // 30-21 after overtime
+	}))
+
+	// overtime is scored with the final numbers
+	result = GetWinningSquaresWithOvertime(event, NumberSetConfigHF, OvertimeModeSeparate, GridTypeStd100, nil, nil, numberSets)
+	g.Expect(result.Squares).Should(gomega.Equal(map[NumberSetType]int{
+		NumberSetTypeHalf:  75,
+		NumberSetTypeFinal: 15, // 24-21 at the end of regulation
+		NumberSetTypeOT:    11,
+	}))
+
+	periods := GetWinningPeriodsForSquare(15, result, event, "Home", "Away")
+	g.Expect(periods).Should(gomega.HaveLen(1))
+	g.Expect(periods[0].HomeScore).Should(gomega.Equal(24))
+	g.Expect(periods[0].AwayScore).Should(gomega.Equal(21))
+
+	periods = GetWinningPeriodsForSquare(11, result, event, "Home", "Away")
+	g.Expect(periods).Should(gomega.HaveLen(1))
+	g.Expect(periods[0].Period).Should(gomega.Equal(NumberSetTypeOT))
+	g.Expect(periods[0].Label).Should(gomega.Equal("Overtime"))
+	g.Expect(periods[0].HomeScore).Should(gomega.Equal(30))
+}
+
+func TestCalculateWinningSquareStd16(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	homeNumbers := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	awayNumbers := []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}
+
+	// For std16 (4x4), the columns and rows cover positions 0-2, 3-4, 5-7 and 8-9
+
+	// Score 0-9: home digit 0 at pos 0, away digit 9 at pos 0
+	result := CalculateWinningSquare(0, 9, homeNumbers, awayNumbers, GridTypeStd16)
+	g.Expect(result).Should(gomega.Equal(1))
+
+	// Score 24-17: home digit 4 at pos 4 (column 1), away digit 7 at pos 2 (row 0)
+	result = CalculateWinningSquare(24, 17, homeNumbers, awayNumbers, GridTypeStd16)
+	g.Expect(result).Should(gomega.Equal(2))
+
+	// Score 35-10: home digit 5 at pos 5 (column 2), away digit 0 at pos 9 (row 3)
+	result = CalculateWinningSquare(35, 10, homeNumbers, awayNumbers, GridTypeStd16)
+	g.Expect(result).Should(gomega.Equal(15))
+}
+
+func TestCalculateWinningSquareStd10(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	homeNumbers := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	awayNumbers := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+	// For std10 (5 columns, 2 rows), each column covers 2 positions and each row covers 5
+
+	// Score 24-17: home digit 4 at pos 4 (column 2), away digit 7 at pos 7 (row 1)
+	result := CalculateWinningSquare(24, 17, homeNumbers, awayNumbers, GridTypeStd10)
+	g.Expect(result).Should(gomega.Equal(8))
+
+	// Score 9-4: home digit 9 at pos 9 (column 4), away digit 4 at pos 4 (row 0)
+	result = CalculateWinningSquare(9, 4, homeNumbers, awayNumbers, GridTypeStd10)
+	g.Expect(result).Should(gomega.Equal(5))
+}
@@ -596,14 +596,19 @@ func (c *Client) parseSummaryEvent(resp espnSummaryResponse) (Event, error) {
 
 		// Parse linescores (period scores - could be halves for basketball or quarters for football)
 		var q1, q2, q3, q4, ot *int
+		var periods []int
+		periodsComplete := true
 		for i, ls := range competitor.Linescores {
-			if ls.DisplayValue == "" {
-				continue
-			}
 			s, err := strconv.Atoi(ls.DisplayValue)
 			if err != nil {
+				// a period that wasn't played, such as the bottom of the 9th inning when the home team
+				// is ahead, is shown as "X" or left blank. No period after it has a score.
+				periodsComplete = false
 				continue
 			}
+			if periodsComplete {
+				periods = append(periods, s)
+			}
 			switch i {
 			case 0:
 				q1 = &s
@@ -631,6 +636,7 @@ func (c *Client) parseSummaryEvent(resp espnSummaryResponse) (Event, error) {
 			event.HomeQ3 = q3
 			event.HomeQ4 = q4
 			event.HomeOT = ot
+			event.HomePeriods = periods
 		} else {
 			event.AwayTeam = team
 			event.AwayTeamScore = score
@@ -639,6 +645,20 @@ func (c *Client) parseSummaryEvent(resp espnSummaryResponse) (Event, error) {
 			event.AwayQ3 = q3
 			event.AwayQ4 = q4
 			event.AwayOT = ot
+			event.AwayPeriods = periods
+		}
+	}
+
+	// Scoring plays
+	if resp.ScoringPlays != nil {
+		event.ScoringPlays = make([]ScoringPlay, 0, len(resp.ScoringPlays))
+		for _, play := range resp.ScoringPlays {
+			event.ScoringPlays = append(event.ScoringPlays, ScoringPlay{
+				HomeScore: play.HomeScore,
+				AwayScore: play.AwayScore,
+				Period:    play.Period.Number,
+				Clock:     play.Clock.DisplayValue,
+			})
 		}
 	}
 
@@ -776,6 +796,12 @@ func (c *Client) parseEvent(e espnEvent) (Event, error) {
 			ot = &otSum
 		}
 
+		// Keep every period as well, since baseball plays more innings than there are quarter columns
+		var periods []int
+		for _, ls := range competitor.Linescores {
+			periods = append(periods, int(ls.Value))
+		}
+
 		if competitor.HomeAway == "home" {
 			event.HomeTeam = team
 			event.HomeTeamScore = score
@@ -784,6 +810,7 @@ func (c *Client) parseEvent(e espnEvent) (Event, error) {
 			event.HomeQ3 = q3
 			event.HomeQ4 = q4
 			event.HomeOT = ot
+			event.HomePeriods = periods
 		} else {
 			event.AwayTeam = team
 			event.AwayTeamScore = score
@@ -792,6 +819,7 @@ func (c *Client) parseEvent(e espnEvent) (Event, error) {
 			event.AwayQ3 = q3
 			event.AwayQ4 = q4
 			event.AwayOT = ot
+			event.AwayPeriods = periods
 		}
 	}
 
@@ -22,6 +22,8 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -55,6 +57,9 @@ func TestLeagueIsValid(t *testing.T) {
 	g.Expect(LeagueWNBA.IsValid()).Should(gomega.BeTrue())
 	g.Expect(LeagueNCAAB.IsValid()).Should(gomega.BeTrue())
 	g.Expect(LeagueNCAAF.IsValid()).Should(gomega.BeTrue())
+	g.Expect(LeagueNHL.IsValid()).Should(gomega.BeTrue())
+	g.Expect(LeagueMLB.IsValid()).Should(gomega.BeTrue())
+	g.Expect(LeagueMLS.IsValid()).Should(gomega.BeTrue())
 	g.Expect(League("invalid").IsValid()).Should(gomega.BeFalse())
 }
 
@@ -66,13 +71,16 @@ func TestLeagueESPNPath(t *testing.T) {
 	g.Expect(LeagueWNBA.ESPNPath()).Should(gomega.Equal("basketball/wnba"))
 	g.Expect(LeagueNCAAB.ESPNPath()).Should(gomega.Equal("basketball/mens-college-basketball"))
 	g.Expect(LeagueNCAAF.ESPNPath()).Should(gomega.Equal("football/college-football"))
+	g.Expect(LeagueNHL.ESPNPath()).Should(gomega.Equal("hockey/nhl"))
+	g.Expect(LeagueMLB.ESPNPath()).Should(gomega.Equal("baseball/mlb"))
+	g.Expect(LeagueMLS.ESPNPath()).Should(gomega.Equal("soccer/usa.1"))
 }
 
 func TestAllLeagues(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
 	leagues := AllLeagues()
-	g.Expect(len(leagues)).Should(gomega.Equal(5))
+	g.Expect(len(leagues)).Should(gomega.Equal(8))
 	g.Expect(leagues).Should(gomega.ContainElement(LeagueNFL))
 	g.Expect(leagues).Should(gomega.ContainElement(LeagueNBA))
 }
@@ -1031,3 +1039,202 @@ func TestGetEventSummaryWithOT(t *testing.T) {
 	g.Expect(*event.AwayQ3).Should(gomega.Equal(5))
 	g.Expect(*event.AwayQ4).Should(gomega.Equal(10))
 }
+
+// newFixtureServer serves the ESPN response recorded in testdata for the path
+func newFixtureServer(g *gomega.WithT, path, fixture string) *httptest.Server {
+	body, err := os.ReadFile(filepath.Join("testdata", fixture))
+	g.Expect(err).Should(gomega.Succeed())
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.URL.Path).Should(gomega.Equal(path))
+		w.Write(body)
+	}))
+}
+
+func TestParseEventNHLShootout(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	server := newFixtureServer(g, "/hockey/nhl/scoreboard", "nhl_scoreboard.json")
+	defer server.Close()
+
+	client := NewClient(Config{
+		BaseURL:   server.URL,
+		RateLimit: 100,
+	})
+
+	events, err := client.GetScoreboard(context.Background(), LeagueNHL, ScoreboardOptions{})
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(len(events)).Should(gomega.Equal(1))
+
+	// Hockey: Q1-Q3 are the periods, Q4 is overtime and the shootout is summed into OT
+	event := events[0]
+	g.Expect(event.Status).Should(gomega.Equal(EventStatusFinal))
+	g.Expect(event.Period).Should(gomega.Equal(5))
+	g.Expect(event.Name).Should(gomega.Equal("Stanley Cup Final - Game 7"))
+	g.Expect(*event.HomeTeamScore).Should(gomega.Equal(3))
+	g.Expect(*event.HomeQ3).Should(gomega.Equal(1))
+	g.Expect(*event.HomeQ4).Should(gomega.Equal(0))
+	g.Expect(*event.HomeOT).Should(gomega.Equal(1))
+	g.Expect(event.HomePeriods).Should(gomega.Equal([]int{1, 0, 1, 0, 1}))
+	g.Expect(event.AwayPeriods).Should(gomega.Equal([]int{0, 2, 0, 0, 0}))
+}
+
+func TestGetEventSummaryNHLShootout(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	server := newFixtureServer(g, "/hockey/nhl/summary", "nhl_summary.json")
+	defer server.Close()
+
+	client := NewClient(Config{
+		BaseURL:   server.URL,
+		RateLimit: 100,
+	})
+
+	event, err := client.GetEventSummary(context.Background(), LeagueNHL, "401688001")
+	g.Expect(err).Should(gomega.Succeed())
+
+	g.Expect(event.Status).Should(gomega.Equal(EventStatusFinal))
+	g.Expect(*event.HomeQ4).Should(gomega.Equal(0))
+	g.Expect(*event.HomeOT).Should(gomega.Equal(1))
+	g.Expect(*event.AwayOT).Should(gomega.Equal(0))
+	g.Expect(event.HomePeriods).Should(gomega.Equal([]int{1, 0, 1, 0, 1}))
+	g.Expect(event.AwayPeriods).Should(gomega.Equal([]int{0, 2, 0, 0, 0}))
+}
+
+func TestGetScoreboardMLB(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	server := newFixtureServer(g, "/baseball/mlb/scoreboard", "mlb_scoreboard.json")
+	defer server.Close()
+
+	client := NewClient(Config{
+		BaseURL:   server.URL,
+		RateLimit: 100,
+	})
+
+	events, err := client.GetScoreboard(context.Background(), LeagueMLB, ScoreboardOptions{})
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(len(events)).Should(gomega.Equal(1))
+
+	// Every inning is kept, and the unplayed bottom of the 9th is left off
+	event := events[0]
+	g.Expect(event.Status).Should(gomega.Equal(EventStatusFinal))
+	g.Expect(event.HomePeriods).Should(gomega.Equal([]int{0, 2, 0, 0, 1, 0, 2, 0}))
+	g.Expect(event.AwayPeriods).Should(gomega.Equal([]int{1, 0, 0, 2, 0, 0, 0, 0, 0}))
+	g.Expect(*event.HomeTeamScore).Should(gomega.Equal(5))
+	g.Expect(*event.AwayTeamScore).Should(gomega.Equal(3))
+}
+
+func TestGetEventSummaryMLB(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	server := newFixtureServer(g, "/baseball/mlb/summary", "mlb_summary.json")
+	defer server.Close()
+
+	client := NewClient(Config{
+		BaseURL:   server.URL,
+		RateLimit: 100,
+	})
+
+	event, err := client.GetEventSummary(context.Background(), LeagueMLB, "401696001")
+	g.Expect(err).Should(gomega.Succeed())
+
+	// Every inning is kept, and the unplayed bottom of the 9th is left off
+	g.Expect(event.HomePeriods).Should(gomega.Equal([]int{0, 2, 0, 0, 1, 0, 2, 0}))
+	g.Expect(event.AwayPeriods).Should(gomega.Equal([]int{1, 0, 0, 2, 0, 0, 0, 0, 0}))
+	g.Expect(*event.HomeTeamScore).Should(gomega.Equal(5))
+}
+
+func TestGetScoreboardMLS(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	server := newFixtureServer(g, "/soccer/usa.1/scoreboard", "mls_scoreboard.json")
+	defer server.Close()
+
+	client := NewClient(Config{
+		BaseURL:   server.URL,
+		RateLimit: 100,
+	})
+
+	events, err := client.GetScoreboard(context.Background(), LeagueMLS, ScoreboardOptions{})
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(len(events)).Should(gomega.Equal(1))
+
+	// Soccer halves: Q1=H1, Q2=H2
+	event := events[0]
+	g.Expect(event.Status).Should(gomega.Equal(EventStatusFinal))
+	g.Expect(*event.HomeQ1).Should(gomega.Equal(1))
+	g.Expect(*event.HomeQ2).Should(gomega.Equal(1))
+	g.Expect(event.HomeQ3).Should(gomega.BeNil())
+	g.Expect(event.AwayPeriods).Should(gomega.Equal([]int{0, 1}))
+}
+
+func TestGetEventSummaryMLS(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	server := newFixtureServer(g, "/soccer/usa.1/summary", "mls_summary.json")
+	defer server.Close()
+
+	client := NewClient(Config{
+		BaseURL:   server.URL,
+		RateLimit: 100,
+	})
+
+	event, err := client.GetEventSummary(context.Background(), LeagueMLS, "401700001")
+	g.Expect(err).Should(gomega.Succeed())
+
+	// Soccer halves: Q1=H1, Q2=H2
+	g.Expect(event.Status).Should(gomega.Equal(EventStatusFinal))
+	g.Expect(*event.HomeQ1).Should(gomega.Equal(1))
+	g.Expect(*event.HomeQ2).Should(gomega.Equal(1))
+	g.Expect(event.HomeQ3).Should(gomega.BeNil())
+	g.Expect(event.AwayPeriods).Should(gomega.Equal([]int{0, 1}))
+
+	// a summary without play data has no scoring plays
+	g.Expect(event.ScoringPlays).Should(gomega.BeNil())
+}
+
+func TestGetEventSummaryScoringPlays(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.URL.Path).Should(gomega.Equal("/football/nfl/summary"))
+
+		w.Write([]byte(`{
+			"header": {
+				"id": "401772001",
+				"season": {"year": 2025, "type": 2},
+				"competitions": [{
+					"id": "401772001",
+					"date": "2025-11-02T18:00Z",
+					"competitors": [
+						{"id": "2", "homeAway": "home", "team": {"id": "2", "abbreviation": "BUF"}, "score": "10"},
+						{"id": "15", "homeAway": "away", "team": {"id": "15", "abbreviation": "MIA"}, "score": "7"}
+					],
+					"status": {"period": 2, "displayClock": "8:12", "type": {"name": "STATUS_IN_PROGRESS", "state": "in"}}
+				}]
+			},
+			"scoringPlays": [
+				{"id": "1", "homeScore": 7, "awayScore": 0, "period": {"number": 1}, "clock": {"value": 512, "displayValue": "8:32"}},
+				{"id": "2", "homeScore": 7, "awayScore": 7, "period": {"number": 1}, "clock": {"value": 64, "displayValue": "1:04"}},
+				{"id": "3", "homeScore": 10, "awayScore": 7, "period": {"number": 2}, "clock": {"value": 612, "displayValue": "10:12"}}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{
+		BaseURL:   server.URL,
+		RateLimit: 100,
+	})
+
+	event, err := client.GetEventSummary(context.Background(), LeagueNFL, "401772001")
+	g.Expect(err).Should(gomega.Succeed())
+
+	g.Expect(event.Status).Should(gomega.Equal(EventStatusInProgress))
+	g.Expect(event.ScoringPlays).Should(gomega.Equal([]ScoringPlay{
+		{HomeScore: 7, AwayScore: 0, Period: 1, Clock: "8:32"},
+		{HomeScore: 7, AwayScore: 7, Period: 1, Clock: "1:04"},
+		{HomeScore: 10, AwayScore: 7, Period: 2, Clock: "10:12"},
+	}))
+}
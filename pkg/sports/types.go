@@ -30,17 +30,20 @@ const (
 	LeagueWNBA  League = "wnba"
 	LeagueNCAAB League = "ncaab"
 	LeagueNCAAF League = "ncaaf"
+	LeagueNHL   League = "nhl"
+	LeagueMLB   League = "mlb"
+	LeagueMLS   League = "mls"
 )
 
 // AllLeagues returns all supported leagues
 func AllLeagues() []League {
-	return []League{LeagueNFL, LeagueNBA, LeagueWNBA, LeagueNCAAB, LeagueNCAAF}
+	return []League{LeagueNFL, LeagueNBA, LeagueWNBA, LeagueNCAAB, LeagueNCAAF, LeagueNHL, LeagueMLB, LeagueMLS}
 }
 
 // IsValid returns true if the league is valid
 func (l League) IsValid() bool {
 	switch l {
-	case LeagueNFL, LeagueNBA, LeagueWNBA, LeagueNCAAB, LeagueNCAAF:
+	case LeagueNFL, LeagueNBA, LeagueWNBA, LeagueNCAAB, LeagueNCAAF, LeagueNHL, LeagueMLB, LeagueMLS:
 		return true
 	}
 	return false
@@ -59,6 +62,12 @@ func (l League) ESPNPath() string {
 		return "basketball/mens-college-basketball"
 	case LeagueNCAAF:
 		return "football/college-football"
+	case LeagueNHL:
+		return "hockey/nhl"
+	case LeagueMLB:
+		return "baseball/mlb"
+	case LeagueMLS:
+		return "soccer/usa.1"
 	default:
 		return ""
 	}
@@ -91,7 +100,7 @@ type Event struct {
 	Date         time.Time   // Event date/time
 	Status       EventStatus // Game status
 	StatusDetail string      // Status description from ESPN (e.g., "Halftime", "End of 1st Quarter")
-	Period       int         // Current period (0=not started, 1-4=quarters, 5+=OT)
+	Period       int         // Current period (0=not started, 1-4=quarters, 5+=OT; innings for baseball)
 	Clock        string      // Game clock display (e.g., "12:34", "5:00")
 	Season       int         // Season year
 	SeasonType   SeasonType  // Season type (preseason, regular, postseason)
@@ -114,6 +123,22 @@ type Event struct {
 	AwayQ3 *int
 	AwayQ4 *int
 	AwayOT *int
+
+	// Every period's score in order, including overtime (e.g. each inning for baseball)
+	HomePeriods []int
+	AwayPeriods []int
+
+	// The score after each scoring play in the order they happened. Only the summary endpoint has scoring plays,
+	// and only for some sports, so this is nil when there is no play data.
+	ScoringPlays []ScoringPlay
+}
+
+// ScoringPlay is the score of an event after one of its scoring plays
+type ScoringPlay struct {
+	HomeScore int
+	AwayScore int
+	Period    int    // Period the play happened in
+	Clock     string // Game clock display when the play happened
 }
 
 // SeasonType represents the type of season
@@ -325,7 +350,20 @@ type espnScore struct {
 
 // espnSummaryResponse is the ESPN API response for event summary endpoint
 type espnSummaryResponse struct {
-	Header espnSummaryHeader `json:"header"`
+	Header       espnSummaryHeader `json:"header"`
+	ScoringPlays []espnScoringPlay `json:"scoringPlays"`
+}
+
+// espnScoringPlay represents a scoring play in summary response
+type espnScoringPlay struct {
+	HomeScore int `json:"homeScore"`
+	AwayScore int `json:"awayScore"`
+	Period    struct {
+		Number int `json:"number"`
+	} `json:"period"`
+	Clock struct {
+		DisplayValue string `json:"displayValue"`
+	} `json:"clock"`
 }
 
 // espnSummaryHeader contains the header info from summary response
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS pool_period_payouts;
+DROP TABLE IF EXISTS pool_payout_settings;
+DROP TYPE IF EXISTS payout_type;
@@ -0,0 +1,18 @@
+-- Square price and per-period payouts for a pool
+
+CREATE TYPE payout_type AS ENUM ('amount', 'percent');
+
+CREATE TABLE pool_payout_settings (
+    pool_id BIGINT PRIMARY KEY REFERENCES pools(id) ON DELETE CASCADE,
+    square_price BIGINT NOT NULL DEFAULT 0 CHECK (square_price >= 0), -- in cents
+    payout_type payout_type NOT NULL DEFAULT 'amount',
+    modified TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'utc')
+);
+
+-- value is in cents for 'amount' payouts and in basis points (1/100th of a percent) for 'percent' payouts
+CREATE TABLE pool_period_payouts (
+    pool_id BIGINT NOT NULL REFERENCES pools(id) ON DELETE CASCADE,
+    period number_set_type NOT NULL,
+    value BIGINT NOT NULL CHECK (value >= 0),
+    PRIMARY KEY (pool_id, period)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE pool_payout_settings DROP COLUMN IF EXISTS rollover_final_rule;
+DROP TYPE IF EXISTS rollover_final_rule;
@@ -0,0 +1,5 @@
+-- How an unclaimed final period is paid out when a grid rolls over
+
+CREATE TYPE rollover_final_rule AS ENUM ('split', 'refund');
+
+ALTER TABLE pool_payout_settings ADD COLUMN rollover_final_rule rollover_final_rule NOT NULL DEFAULT 'split';
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS pool_squares_logs_payments_idx;
+ALTER TABLE pool_squares_logs
+    DROP COLUMN IF EXISTS payment_reference,
+    DROP COLUMN IF EXISTS payment_method,
+    DROP COLUMN IF EXISTS payment_amount;
+DROP TYPE IF EXISTS payment_method;
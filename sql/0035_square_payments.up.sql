@@ -0,0 +1,10 @@
+-- Payments received for a square are recorded in the square's log
+
+CREATE TYPE payment_method AS ENUM ('cash', 'venmo', 'paypal', 'zelle', 'cashapp', 'check', 'other');
+
+ALTER TABLE pool_squares_logs
+    ADD COLUMN payment_amount BIGINT CHECK (payment_amount > 0), -- in cents
+    ADD COLUMN payment_method payment_method,
+    ADD COLUMN payment_reference TEXT;
+
+CREATE INDEX pool_squares_logs_payments_idx ON pool_squares_logs (pool_square_id) WHERE payment_amount IS NOT NULL;
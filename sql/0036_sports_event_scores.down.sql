@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS sports_event_scores;
+
+-- enum values cannot be dropped, so move anything using 'every' back to 'standard'
+UPDATE pools SET number_set_config = 'standard' WHERE number_set_config = 'every';
+UPDATE grids SET payout_config = NULL WHERE payout_config = 'every';
@@ -0,0 +1,15 @@
+-- Every distinct score of a sports event, used to pay a winner on each score change
+
+ALTER TYPE number_set_config ADD VALUE IF NOT EXISTS 'every';
+
+CREATE TABLE sports_event_scores (
+    id BIGSERIAL PRIMARY KEY,
+    sports_event_id BIGINT NOT NULL REFERENCES sports_events(id) ON DELETE CASCADE,
+    home_score INT NOT NULL,
+    away_score INT NOT NULL,
+    period INT,
+    clock VARCHAR(20),
+    created TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'utc')
+);
+
+CREATE UNIQUE INDEX sports_event_scores_event_score_idx ON sports_event_scores (sports_event_id, home_score, away_score);
@@ -0,0 +1,5 @@
+ALTER TABLE grids DROP COLUMN IF EXISTS overtime_mode;
+DROP TYPE IF EXISTS overtime_mode;
+
+-- enum values cannot be dropped, so remove any payouts configured for the overtime period
+DELETE FROM pool_period_payouts WHERE period = 'ot';
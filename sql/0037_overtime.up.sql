@@ -0,0 +1,7 @@
+-- Overtime as its own scoring period, with a per-grid option for whether it is folded into the final score
+
+ALTER TYPE number_set_type ADD VALUE IF NOT EXISTS 'ot';
+
+CREATE TYPE overtime_mode AS ENUM ('include', 'separate');
+
+ALTER TABLE grids ADD COLUMN overtime_mode overtime_mode NOT NULL DEFAULT 'include';
@@ -0,0 +1,65 @@
+-- Reverse: restore update_pool_square and remove square shares
+
+BEGIN;
+
+CREATE OR REPLACE FUNCTION update_pool_square(_id bigint, _state square_states, _claimant text, _user_id bigint,
+                                   _remote_addr text, _note text, _is_manager boolean) RETURNS boolean
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    _row           pool_squares;
+    _initial_claim boolean;
+    _same_user     boolean;
+    _user_unclaim  boolean;
+    _parent_id     integer;
+BEGIN
+    SELECT INTO _row * FROM pool_squares WHERE id = _id FOR SHARE;
+
+    _initial_claim := _row.claimant IS NULL AND _row.state = 'unclaimed';
+    _same_user := coalesce(_row.user_id, 0) = coalesce(_user_id, 0);
+    _user_unclaim := _same_user AND _row.state = 'claimed' AND _state = 'unclaimed';
+
+    IF NOT _is_manager
+        AND NOT _initial_claim
+        AND NOT _user_unclaim
+    THEN
+        RETURN FALSE;
+    END IF;
+
+    _parent_id = _row.parent_id;
+    IF _state = 'unclaimed' THEN
+        _claimant := NULL;
+        _user_id := NULL;
+        _parent_id := NULL;
+    END IF;
+
+    UPDATE pool_squares
+    SET state           = _state,
+        claimant        = _claimant,
+        user_id         = _user_id,
+        parent_id       = _parent_id,
+        modified        = (now() at time zone 'utc')
+    WHERE id = _id;
+
+    INSERT INTO pool_squares_logs (pool_square_id, user_id, state, claimant, note, remote_addr)
+    VALUES (_id, _user_id, _state, _claimant, _note, _remote_addr);
+
+    RETURN TRUE;
+END;
+$$;
+
+DROP FUNCTION IF EXISTS update_pool_square_share(bigint, square_states, bigint, text, text, boolean);
+DROP FUNCTION IF EXISTS claim_pool_square_share(bigint, bigint, text, integer, text, text);
+DROP FUNCTION IF EXISTS sync_pool_square_shares(bigint);
+
+-- shared squares go back to being unclaimed
+UPDATE pool_squares
+SET state    = 'unclaimed',
+    claimant = NULL,
+    user_id  = NULL
+WHERE id IN (SELECT pool_square_id FROM pool_square_shares);
+
+DROP TABLE IF EXISTS pool_square_shares;
+
+COMMIT;
@@ -0,0 +1,180 @@
+-- Squares can be split between several co-owners, each holding a share of the square in basis points
+
+BEGIN;
+
+CREATE TABLE pool_square_shares
+(
+    id             bigserial     NOT NULL PRIMARY KEY,
+    pool_square_id bigint        NOT NULL REFERENCES pool_squares (id) ON DELETE CASCADE,
+    user_id        bigint REFERENCES users (id),
+    claimant       text          NOT NULL,
+    share          integer       NOT NULL CHECK (share > 0 AND share <= 10000),
+    state          square_states NOT NULL DEFAULT 'claimed' CHECK (state <> 'unclaimed'),
+    created        timestamp     NOT NULL DEFAULT (now() at time zone 'utc'),
+    modified       timestamp     NOT NULL DEFAULT (now() at time zone 'utc')
+);
+
+CREATE INDEX pool_square_shares_pool_square_id_idx ON pool_square_shares (pool_square_id);
+CREATE INDEX pool_square_shares_user_id_idx ON pool_square_shares (user_id);
+
+-- sync_pool_square_shares updates a square from its shares. A shared square is claimed by all of its co-owners,
+-- and is only paid in full once every share has been paid in full.
+CREATE FUNCTION sync_pool_square_shares(_id bigint) RETURNS void
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    _claimant text;
+    _state    square_states;
+BEGIN
+    SELECT INTO _claimant, _state string_agg(claimant, ' / ' ORDER BY id),
+                                  CASE
+                                      WHEN bool_and(state = 'paid-full') THEN 'paid-full'
+                                      WHEN bool_or(state <> 'claimed') THEN 'paid-partial'
+                                      ELSE 'claimed'
+                                      END::square_states
+    FROM pool_square_shares
+    WHERE pool_square_id = _id;
+
+    UPDATE pool_squares
+    SET state    = coalesce(_state, 'unclaimed'),
+        claimant = _claimant,
+        user_id  = NULL,
+        modified = (now() at time zone 'utc')
+    WHERE id = _id;
+END;
+$$;
+
+-- claim_pool_square_share claims part of a square. It returns the ID of the new share, or NULL if the square is owned
+-- outright, is a secondary square, or does not have enough of its share left.
+CREATE FUNCTION claim_pool_square_share(_id bigint, _user_id bigint, _claimant text, _share integer,
+                                        _remote_addr text, _note text) RETURNS bigint
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    _row      pool_squares;
+    _shares   integer;
+    _taken    integer;
+    _share_id bigint;
+BEGIN
+    SELECT INTO _row * FROM pool_squares WHERE id = _id FOR UPDATE;
+
+    SELECT INTO _shares, _taken count(*), coalesce(sum(share), 0) FROM pool_square_shares WHERE pool_square_id = _id;
+
+    IF _row.parent_id IS NOT NULL
+        OR (_shares = 0 AND _row.state <> 'unclaimed')
+        OR _taken + _share > 10000
+    THEN
+        RETURN NULL;
+    END IF;
+
+    INSERT INTO pool_square_shares (pool_square_id, user_id, claimant, share)
+    VALUES (_id, _user_id, _claimant, _share)
+    RETURNING id INTO _share_id;
+
+    PERFORM sync_pool_square_shares(_id);
+
+    INSERT INTO pool_squares_logs (pool_square_id, user_id, state, claimant, note, remote_addr)
+    VALUES (_id, _user_id, 'claimed', _claimant, _note, _remote_addr);
+
+    RETURN _share_id;
+END;
+$$;
+
+-- update_pool_square_share changes the state of a share, or removes it when the state is 'unclaimed'.
+-- Users may only remove their own shares that have not been paid.
+CREATE FUNCTION update_pool_square_share(_share_id bigint, _state square_states, _user_id bigint,
+                                         _remote_addr text, _note text, _is_manager boolean) RETURNS boolean
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    _share pool_square_shares;
+BEGIN
+    SELECT INTO _share * FROM pool_square_shares WHERE id = _share_id FOR UPDATE;
+    IF NOT FOUND THEN
+        RETURN FALSE;
+    END IF;
+
+    IF NOT _is_manager
+        AND NOT (_state = 'unclaimed' AND _share.state = 'claimed' AND _share.user_id = _user_id)
+    THEN
+        RETURN FALSE;
+    END IF;
+
+    IF _state = 'unclaimed' THEN
+        DELETE FROM pool_square_shares WHERE id = _share_id;
+    ELSE
+        UPDATE pool_square_shares
+        SET state    = _state,
+            modified = (now() at time zone 'utc')
+        WHERE id = _share_id;
+    END IF;
+
+    PERFORM sync_pool_square_shares(_share.pool_square_id);
+
+    INSERT INTO pool_squares_logs (pool_square_id, user_id, state, claimant, note, remote_addr)
+    VALUES (_share.pool_square_id, _user_id, _state, _share.claimant, _note, _remote_addr);
+
+    RETURN TRUE;
+END;
+$$;
+
+-- a shared square is only changed through its shares, except that a manager may unclaim it entirely
+CREATE OR REPLACE FUNCTION update_pool_square(_id bigint, _state square_states, _claimant text, _user_id bigint,
+                                              _remote_addr text, _note text, _is_manager boolean) RETURNS boolean
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    _row           pool_squares;
+    _initial_claim boolean;
+    _same_user     boolean;
+    _user_unclaim  boolean;
+    _parent_id     integer;
+BEGIN
+    SELECT INTO _row * FROM pool_squares WHERE id = _id FOR SHARE;
+
+    IF EXISTS(SELECT 1 FROM pool_square_shares WHERE pool_square_id = _id) THEN
+        IF NOT _is_manager OR _state <> 'unclaimed' THEN
+            RETURN FALSE;
+        END IF;
+
+        DELETE FROM pool_square_shares WHERE pool_square_id = _id;
+    END IF;
+
+    _initial_claim := _row.claimant IS NULL AND _row.state = 'unclaimed';
+    _same_user := coalesce(_row.user_id, 0) = coalesce(_user_id, 0);
+    _user_unclaim := _same_user AND _row.state = 'claimed' AND _state = 'unclaimed';
+
+    IF NOT _is_manager
+        AND NOT _initial_claim
+        AND NOT _user_unclaim
+    THEN
+        RETURN FALSE;
+    END IF;
+
+    _parent_id = _row.parent_id;
+    IF _state = 'unclaimed' THEN
+        _claimant := NULL;
+        _user_id := NULL;
+        _parent_id := NULL;
+    END IF;
+
+    UPDATE pool_squares
+    SET state           = _state,
+        claimant        = _claimant,
+        user_id         = _user_id,
+        parent_id       = _parent_id,
+        modified        = (now() at time zone 'utc')
+    WHERE id = _id;
+
+    INSERT INTO pool_squares_logs (pool_square_id, user_id, state, claimant, note, remote_addr)
+    VALUES (_id, _user_id, _state, _claimant, _note, _remote_addr);
+
+    RETURN TRUE;
+END;
+$$;
+
+COMMIT;
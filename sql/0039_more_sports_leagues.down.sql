@@ -0,0 +1,14 @@
+ALTER TABLE sports_events DROP COLUMN IF EXISTS home_periods;
+ALTER TABLE sports_events DROP COLUMN IF EXISTS away_periods;
+
+-- enum values cannot be dropped, so move anything scored by inning back to 'standard'
+DELETE FROM grid_number_sets WHERE set_type IN ('i3', 'i5', 'i7');
+DELETE FROM pool_period_payouts WHERE period IN ('i3', 'i5', 'i7');
+UPDATE pools SET number_set_config = 'standard' WHERE number_set_config IN ('357f', '5f');
+UPDATE grids SET payout_config = NULL WHERE payout_config IN ('357f', '5f');
+
+-- and unlink and remove the events and teams of the new leagues
+UPDATE grids SET sports_event_id = NULL
+WHERE sports_event_id IN (SELECT id FROM sports_events WHERE league IN ('nhl', 'mlb', 'mls'));
+DELETE FROM sports_events WHERE league IN ('nhl', 'mlb', 'mls');
+DELETE FROM sports_teams WHERE league IN ('nhl', 'mlb', 'mls');
@@ -0,0 +1,15 @@
+-- Hockey, baseball and soccer leagues, with every period's score kept so baseball can be scored by inning
+
+ALTER TYPE sports_league ADD VALUE IF NOT EXISTS 'nhl';
+ALTER TYPE sports_league ADD VALUE IF NOT EXISTS 'mlb';
+ALTER TYPE sports_league ADD VALUE IF NOT EXISTS 'mls';
+
+ALTER TYPE number_set_type ADD VALUE IF NOT EXISTS 'i3';
+ALTER TYPE number_set_type ADD VALUE IF NOT EXISTS 'i5';
+ALTER TYPE number_set_type ADD VALUE IF NOT EXISTS 'i7';
+
+ALTER TYPE number_set_config ADD VALUE IF NOT EXISTS '357f';
+ALTER TYPE number_set_config ADD VALUE IF NOT EXISTS '5f';
+
+ALTER TABLE sports_events ADD COLUMN home_periods INT[];
+ALTER TABLE sports_events ADD COLUMN away_periods INT[];
@@ -0,0 +1,8 @@
+-- Per-pool caps on how many squares one user or one claimant name may hold. 0 means unlimited.
+
+CREATE TABLE pool_square_limits (
+    pool_id BIGINT PRIMARY KEY REFERENCES pools(id) ON DELETE CASCADE,
+    max_per_user INTEGER NOT NULL DEFAULT 0 CHECK (max_per_user >= 0),
+    max_per_claimant INTEGER NOT NULL DEFAULT 0 CHECK (max_per_claimant >= 0),
+    modified TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'utc')
+);
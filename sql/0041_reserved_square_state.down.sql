@@ -0,0 +1,3 @@
+-- enum values cannot be dropped, so reserved squares are treated as claimed
+UPDATE pool_squares SET state = 'claimed' WHERE state = 'reserved';
+UPDATE pool_squares_logs SET state = 'claimed' WHERE state = 'reserved';
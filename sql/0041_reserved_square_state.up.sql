@@ -0,0 +1,3 @@
+-- A reserved square is held for its claimant until it is paid for, or the hold expires
+
+ALTER TYPE square_states ADD VALUE IF NOT EXISTS 'reserved' AFTER 'claimed';
@@ -0,0 +1,131 @@
+-- Squares claimed by members of a pool with a hold period are reserved until a manager marks them paid.
+-- Holds that are not paid by reserved_until are released by release_expired_holds().
+
+BEGIN;
+
+CREATE TABLE pool_hold_settings (
+    pool_id BIGINT PRIMARY KEY REFERENCES pools(id) ON DELETE CASCADE,
+    hold_hours INTEGER NOT NULL CHECK (hold_hours > 0),
+    modified TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'utc')
+);
+
+ALTER TABLE pool_squares ADD COLUMN reserved_until TIMESTAMP;
+ALTER TABLE pool_squares ADD CONSTRAINT pool_squares_reserved_until_check CHECK ((state = 'reserved') = (reserved_until IS NOT NULL));
+
+CREATE INDEX pool_squares_reserved_until_idx ON pool_squares (reserved_until) WHERE reserved_until IS NOT NULL;
+
+-- a square keeps its hold when it stays reserved, and a new hold is started from the pool's hold period when it is
+-- first reserved. Squares reserved in a pool without a hold period are claimed instead.
+CREATE OR REPLACE FUNCTION update_pool_square(_id bigint, _state square_states, _claimant text, _user_id bigint,
+                                              _remote_addr text, _note text, _is_manager boolean) RETURNS boolean
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    _row            pool_squares;
+    _initial_claim  boolean;
+    _same_user      boolean;
+    _user_unclaim   boolean;
+    _parent_id      integer;
+    _reserved_until timestamp;
+BEGIN
+    SELECT INTO _row * FROM pool_squares WHERE id = _id FOR SHARE;
+
+    IF EXISTS(SELECT 1 FROM pool_square_shares WHERE pool_square_id = _id) THEN
+        IF NOT _is_manager OR _state <> 'unclaimed' THEN
+            RETURN FALSE;
+        END IF;
+
+        DELETE FROM pool_square_shares WHERE pool_square_id = _id;
+    END IF;
+
+    _initial_claim := _row.claimant IS NULL AND _row.state = 'unclaimed';
+    _same_user := coalesce(_row.user_id, 0) = coalesce(_user_id, 0);
+    _user_unclaim := _same_user AND _row.state IN ('claimed', 'reserved') AND _state = 'unclaimed';
+
+    IF NOT _is_manager
+        AND NOT _initial_claim
+        AND NOT _user_unclaim
+    THEN
+        RETURN FALSE;
+    END IF;
+
+    IF _state = 'reserved' THEN
+        IF _row.state = 'reserved' THEN
+            _reserved_until := _row.reserved_until;
+        ELSE
+            SELECT INTO _reserved_until (now() at time zone 'utc') + make_interval(hours => hold_hours)
+            FROM pool_hold_settings
+            WHERE pool_id = _row.pool_id;
+
+            IF _reserved_until IS NULL THEN
+                _state := 'claimed';
+            END IF;
+        END IF;
+    END IF;
+
+    _parent_id = _row.parent_id;
+    IF _state = 'unclaimed' THEN
+        _claimant := NULL;
+        _user_id := NULL;
+        _parent_id := NULL;
+    END IF;
+
+    UPDATE pool_squares
+    SET state           = _state,
+        claimant        = _claimant,
+        user_id         = _user_id,
+        parent_id       = _parent_id,
+        reserved_until  = _reserved_until,
+        modified        = (now() at time zone 'utc')
+    WHERE id = _id;
+
+    INSERT INTO pool_squares_logs (pool_square_id, user_id, state, claimant, note, remote_addr)
+    VALUES (_id, _user_id, _state, _claimant, _note, _remote_addr);
+
+    RETURN TRUE;
+END;
+$$;
+
+-- release_expired_holds unclaims every reserved square whose hold has expired and returns the squares released.
+-- Squares locked by another transaction are skipped and released on the next run.
+CREATE FUNCTION release_expired_holds()
+    RETURNS TABLE
+            (
+                pool_token         text,
+                released_square_id integer
+            )
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    _row pool_squares;
+BEGIN
+    FOR _row IN
+        SELECT *
+        FROM pool_squares
+        WHERE state = 'reserved'
+          AND reserved_until <= (now() at time zone 'utc')
+        ORDER BY pool_id, square_id
+        FOR UPDATE SKIP LOCKED
+        LOOP
+            UPDATE pool_squares
+            SET state          = 'unclaimed',
+                claimant       = NULL,
+                user_id        = NULL,
+                parent_id      = NULL,
+                reserved_until = NULL,
+                modified       = (now() at time zone 'utc')
+            WHERE id = _row.id;
+
+            INSERT INTO pool_squares_logs (pool_square_id, user_id, state, claimant, note)
+            VALUES (_row.id, NULL, 'unclaimed', NULL, format('system: hold for `%s` expired before payment', _row.claimant));
+
+            SELECT INTO pool_token token FROM pools WHERE id = _row.pool_id;
+            released_square_id := _row.square_id;
+            RETURN NEXT;
+        END LOOP;
+END;
+$$;
+
+COMMIT;
@@ -0,0 +1,14 @@
+-- Snake-draft square selection. While a pool has a draft, only the member on the clock may claim a square, and
+-- claiming one passes the turn to the next member. pick_seconds of 0 means turns never time out.
+
+CREATE TABLE pool_drafts (
+    pool_id BIGINT PRIMARY KEY REFERENCES pools(id) ON DELETE CASCADE,
+    user_ids BIGINT[] NOT NULL CHECK (cardinality(user_ids) > 0),
+    claimants TEXT[] NOT NULL CHECK (cardinality(claimants) = cardinality(user_ids)),
+    pick_seconds INTEGER NOT NULL DEFAULT 0 CHECK (pick_seconds >= 0),
+    pick_number INTEGER NOT NULL DEFAULT 0 CHECK (pick_number >= 0),
+    turn_started TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'utc'),
+    created TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'utc')
+);
+
+CREATE INDEX pool_drafts_timed_idx ON pool_drafts (turn_started) WHERE pick_seconds > 0;
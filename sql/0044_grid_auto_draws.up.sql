@@ -0,0 +1,8 @@
+-- Grids that draw their numbers automatically when the pool locks, or a number of minutes before the linked event starts
+
+CREATE TABLE grid_auto_draws (
+    grid_id BIGINT PRIMARY KEY REFERENCES grids(id) ON DELETE CASCADE,
+    draw_on TEXT NOT NULL CHECK (draw_on IN ('lock', 'kickoff')),
+    minutes_before INTEGER NOT NULL DEFAULT 0 CHECK (minutes_before >= 0),
+    modified TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'utc')
+);
@@ -0,0 +1,11 @@
+-- Pools that lock themselves when the earliest event linked to one of their grids starts, or a number of minutes before.
+-- locked_at is set once the lock has taken effect, after which the lock no longer follows the event's schedule.
+
+CREATE TABLE pool_auto_locks (
+    pool_id BIGINT PRIMARY KEY REFERENCES pools(id) ON DELETE CASCADE,
+    minutes_before INTEGER NOT NULL DEFAULT 0 CHECK (minutes_before >= 0),
+    locked_at TIMESTAMP,
+    modified TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'utc')
+);
+
+CREATE INDEX pool_auto_locks_pending_idx ON pool_auto_locks (pool_id) WHERE locked_at IS NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS grid_draw_entropy;
+DROP TABLE IF EXISTS grid_draw_commitments;
@@ -0,0 +1,20 @@
+-- Commit-reveal draws. Each grid gets a secret seed whose SHA-256 hash is published before the draw. The numbers are
+-- derived from the seed and any entropy contributed by members, and the seed is revealed once they are drawn so that
+-- anyone can check the result.
+
+CREATE TABLE grid_draw_commitments (
+    grid_id BIGINT PRIMARY KEY REFERENCES grids(id) ON DELETE CASCADE,
+    seed BYTEA NOT NULL CHECK (octet_length(seed) = 32),
+    drawn TIMESTAMP,
+    created TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'utc')
+);
+
+CREATE TABLE grid_draw_entropy (
+    id BIGSERIAL PRIMARY KEY,
+    grid_id BIGINT NOT NULL REFERENCES grids(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    entropy TEXT NOT NULL,
+    created TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'utc'),
+    modified TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'utc'),
+    UNIQUE (grid_id, user_id)
+);
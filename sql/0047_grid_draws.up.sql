@@ -0,0 +1,47 @@
+-- History of number draws. Every draw, random or manual, is recorded with who made it and the numbers it produced,
+-- so that redraws and manually entered numbers can be audited by the members of a pool.
+
+CREATE TABLE grid_draws (
+    id BIGSERIAL PRIMARY KEY,
+    grid_id BIGINT NOT NULL REFERENCES grids(id) ON DELETE CASCADE,
+    -- NULL when the numbers were drawn automatically
+    user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    method TEXT NOT NULL CHECK (method IN ('random', 'manual')),
+    -- only set when a manager redrew numbers that had already been drawn
+    reason TEXT,
+    number_sets JSONB NOT NULL,
+    -- the revealed seed of a random draw
+    seed BYTEA,
+    created TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'utc')
+);
+
+CREATE INDEX grid_draws_grid_id_idx ON grid_draws (grid_id, id);
+
+-- Record the numbers that have already been drawn. Who drew them is not known.
+INSERT INTO grid_draws (grid_id, method, number_sets, created)
+SELECT grids.id,
+       CASE WHEN grids.manual_draw THEN 'manual' ELSE 'random' END,
+       jsonb_build_object('all', jsonb_build_object(
+           'homeNumbers', ARRAY(SELECT unnest(grids.home_numbers)::integer),
+           'awayNumbers', ARRAY(SELECT unnest(grids.away_numbers)::integer))),
+       grids.modified
+FROM grids
+INNER JOIN pools ON grids.pool_id = pools.id
+WHERE pools.number_set_config = 'standard' AND
+      grids.home_numbers IS NOT NULL AND
+      grids.away_numbers IS NOT NULL;
+
+INSERT INTO grid_draws (grid_id, method, number_sets, created)
+SELECT grid_number_sets.grid_id,
+       CASE WHEN bool_or(grid_number_sets.manual_draw) THEN 'manual' ELSE 'random' END,
+       jsonb_object_agg(grid_number_sets.set_type::text, jsonb_build_object(
+           'homeNumbers', grid_number_sets.home_numbers,
+           'awayNumbers', grid_number_sets.away_numbers)),
+       MAX(grid_number_sets.modified)
+FROM grid_number_sets
+INNER JOIN grids ON grid_number_sets.grid_id = grids.id
+INNER JOIN pools ON grids.pool_id = pools.id
+WHERE pools.number_set_config <> 'standard' AND
+      grid_number_sets.home_numbers IS NOT NULL AND
+      grid_number_sets.away_numbers IS NOT NULL
+GROUP BY grid_number_sets.grid_id;
@@ -0,0 +1,24 @@
+-- A series groups pools, or single grids of pools, so that wins and payouts can be totalled across them, such as every
+-- Sunday game of a season. An entry without a grid_id includes every grid of the pool.
+
+CREATE TABLE pool_series (
+    id BIGSERIAL PRIMARY KEY,
+    token TEXT NOT NULL UNIQUE REFERENCES tokens(token),
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    created TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'utc'),
+    modified TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'utc')
+);
+
+CREATE INDEX pool_series_user_id_idx ON pool_series (user_id);
+
+CREATE TABLE pool_series_entries (
+    id BIGSERIAL PRIMARY KEY,
+    series_id BIGINT NOT NULL REFERENCES pool_series(id) ON DELETE CASCADE,
+    pool_id BIGINT NOT NULL REFERENCES pools(id) ON DELETE CASCADE,
+    grid_id BIGINT REFERENCES grids(id) ON DELETE CASCADE,
+    created TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'utc')
+);
+
+CREATE UNIQUE INDEX pool_series_entries_unique_idx ON pool_series_entries (series_id, pool_id, COALESCE(grid_id, 0));
+CREATE INDEX pool_series_entries_pool_id_idx ON pool_series_entries (pool_id);
@@ -0,0 +1,3 @@
+ALTER TABLE pools_users
+    DROP COLUMN IF EXISTS manager_granted_by,
+    DROP COLUMN IF EXISTS manager_granted_at;
@@ -0,0 +1,5 @@
+-- Records who made a member a manager of a pool, and when. Managers made before this migration have neither.
+
+ALTER TABLE pools_users
+    ADD COLUMN manager_granted_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN manager_granted_at TIMESTAMP;
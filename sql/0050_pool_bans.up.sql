@@ -0,0 +1,9 @@
+-- Users removed from a pool who may not rejoin it, whether by password, invite token or invite JWT
+
+CREATE TABLE pool_bans (
+    pool_id BIGINT NOT NULL REFERENCES pools(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    banned_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'utc'),
+    PRIMARY KEY (pool_id, user_id)
+);
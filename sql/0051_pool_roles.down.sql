@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE pools_users DROP COLUMN is_manager;
+ALTER TABLE pools_users ADD COLUMN is_manager BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE pools_users SET is_manager = true WHERE role = 'manager';
+
+ALTER TABLE pools_users DROP COLUMN role;
+
+COMMIT;
@@ -0,0 +1,14 @@
+-- Members of a pool have a role that decides what they may do in it. is_manager is kept, derived from the role,
+-- so that existing queries for managers keep working.
+
+BEGIN;
+
+ALTER TABLE pools_users ADD COLUMN role TEXT NOT NULL DEFAULT 'claimer'
+    CHECK (role IN ('spectator', 'claimer', 'treasurer', 'grid-editor', 'manager'));
+
+UPDATE pools_users SET role = 'manager' WHERE is_manager;
+
+ALTER TABLE pools_users DROP COLUMN is_manager;
+ALTER TABLE pools_users ADD COLUMN is_manager BOOLEAN GENERATED ALWAYS AS (role = 'manager') STORED;
+
+COMMIT;
@@ -0,0 +1,21 @@
+-- Transfers of the ownership of a pool. The owner nominates a member, who becomes the owner when they accept, and
+-- site admins may force a transfer. Rows are kept once resolved as an audit trail.
+
+BEGIN;
+
+CREATE TABLE pool_ownership_transfers (
+    id BIGSERIAL PRIMARY KEY,
+    pool_id BIGINT NOT NULL REFERENCES pools(id) ON DELETE CASCADE,
+    from_user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    to_user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    requested_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    status TEXT NOT NULL CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled', 'forced')),
+    created TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'utc'),
+    resolved TIMESTAMP,
+    CHECK ((status = 'pending') = (resolved IS NULL))
+);
+
+CREATE INDEX pool_ownership_transfers_pool_id_idx ON pool_ownership_transfers (pool_id, created);
+CREATE UNIQUE INDEX pool_ownership_transfers_pending_idx ON pool_ownership_transfers (pool_id) WHERE status = 'pending';
+
+COMMIT;
@@ -0,0 +1,18 @@
+BEGIN;
+
+ALTER TABLE pools_users DROP COLUMN invite_token;
+
+DELETE FROM pool_invites WHERE check_id IS NULL OR expires_at IS NULL;
+
+ALTER TABLE pool_invites
+    DROP COLUMN label,
+    DROP COLUMN role,
+    DROP COLUMN max_uses,
+    DROP COLUMN uses,
+    DROP COLUMN created_by,
+    DROP COLUMN revoked,
+    ALTER COLUMN check_id SET DEFAULT 0,
+    ALTER COLUMN check_id SET NOT NULL,
+    ALTER COLUMN expires_at SET NOT NULL;
+
+COMMIT;
@@ -0,0 +1,21 @@
+-- Named invite links. A pool may have any number of them, each with its own expiry, cap on uses and role granted to
+-- the users who join with it. Links have no check_id, so they are revoked one at a time rather than by changing the
+-- pool's password. The members who joined with an invite record which one they used.
+
+BEGIN;
+
+ALTER TABLE pool_invites
+    ALTER COLUMN check_id DROP NOT NULL,
+    ALTER COLUMN check_id DROP DEFAULT,
+    ALTER COLUMN expires_at DROP NOT NULL,
+    ADD COLUMN label TEXT NOT NULL DEFAULT '',
+    ADD COLUMN role TEXT NOT NULL DEFAULT 'claimer' CHECK (role IN ('spectator', 'claimer', 'treasurer', 'grid-editor', 'manager')),
+    ADD COLUMN max_uses INTEGER CHECK (max_uses > 0),
+    ADD COLUMN uses INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN revoked TIMESTAMP,
+    ADD CHECK (max_uses IS NULL OR uses <= max_uses);
+
+ALTER TABLE pools_users ADD COLUMN invite_token TEXT REFERENCES pool_invites(token) ON DELETE SET NULL;
+
+COMMIT;
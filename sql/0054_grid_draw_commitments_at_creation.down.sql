@@ -0,0 +1,29 @@
+BEGIN;
+
+CREATE OR REPLACE FUNCTION new_grid(_pool_id bigint, _max_per_pool int) RETURNS grids
+    LANGUAGE plpgsql
+AS
+$$
+declare
+    _count int;
+    _row grids;
+begin
+    perform from grids where pool_id = _pool_id for update;
+
+    select count(*) into _count from grids where pool_id = _pool_id and state = 'active';
+
+    if _count >= _max_per_pool then
+        raise exception 'limit reached';
+    end if;
+
+    INSERT INTO grids (pool_id, ord)
+    VALUES (_pool_id, (SELECT COALESCE(MAX(ord), -1) + 1 FROM grids WHERE pool_id = _pool_id)) RETURNING * INTO _row;
+
+    INSERT INTO grid_settings (grid_id)
+    VALUES (_row.id);
+
+    RETURN _row;
+end;
+$$;
+
+COMMIT;
@@ -0,0 +1,43 @@
+-- Draw commitments are made when a grid is created instead of when the seed is first needed, so that the commitment
+-- is published well before the numbers are drawn, including when they are drawn automatically. Grids that do not
+-- have one yet get one now.
+
+BEGIN;
+
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+CREATE OR REPLACE FUNCTION new_grid(_pool_id bigint, _max_per_pool int) RETURNS grids
+    LANGUAGE plpgsql
+AS
+$$
+declare
+    _count int;
+    _row grids;
+begin
+    perform from grids where pool_id = _pool_id for update;
+
+    select count(*) into _count from grids where pool_id = _pool_id and state = 'active';
+
+    if _count >= _max_per_pool then
+        raise exception 'limit reached';
+    end if;
+
+    INSERT INTO grids (pool_id, ord)
+    VALUES (_pool_id, (SELECT COALESCE(MAX(ord), -1) + 1 FROM grids WHERE pool_id = _pool_id)) RETURNING * INTO _row;
+
+    INSERT INTO grid_settings (grid_id)
+    VALUES (_row.id);
+
+    INSERT INTO grid_draw_commitments (grid_id, seed)
+    VALUES (_row.id, gen_random_bytes(32));
+
+    RETURN _row;
+end;
+$$;
+
+INSERT INTO grid_draw_commitments (grid_id, seed)
+SELECT grids.id, gen_random_bytes(32)
+FROM grids
+WHERE NOT EXISTS (SELECT 1 FROM grid_draw_commitments WHERE grid_draw_commitments.grid_id = grids.id);
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE grid_draw_commitments
+    DROP CONSTRAINT grid_draw_commitments_next_seed_check,
+    DROP COLUMN next_created,
+    DROP COLUMN next_seed;
+
+COMMIT;
@@ -0,0 +1,19 @@
+-- Redraws use a seed that was committed to before the redraw. Every draw reveals its seed and commits to the seed of
+-- the next draw in the same transaction, so the numbers of a redraw are never derived from a seed made by the same
+-- request. Grids that have already been drawn get their next commitment now.
+
+BEGIN;
+
+ALTER TABLE grid_draw_commitments
+    ADD COLUMN next_seed BYTEA CHECK (octet_length(next_seed) = 32),
+    ADD COLUMN next_created TIMESTAMP;
+
+UPDATE grid_draw_commitments
+SET next_seed = gen_random_bytes(32),
+    next_created = (NOW() AT TIME ZONE 'utc')
+WHERE drawn IS NOT NULL;
+
+ALTER TABLE grid_draw_commitments
+    ADD CONSTRAINT grid_draw_commitments_next_seed_check CHECK (drawn IS NULL OR next_seed IS NOT NULL);
+
+COMMIT;